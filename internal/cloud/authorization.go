@@ -0,0 +1,40 @@
+package cloud
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrTokenCodeRequired is returned when signing in needs the code of an MFA device
+var ErrTokenCodeRequired = errors.New("MFA code required")
+
+// DeviceAuthorization represents a pending sign in the user approves in a browser by
+// confirming the user code at the verification URL
+type DeviceAuthorization struct {
+	VerificationURI string
+	// VerificationURIComplete is the verification URL with the user code filled in
+	VerificationURIComplete string
+	UserCode                string
+	ExpiresAt               time.Time
+}
+
+// DeviceAuthorizationFunc receives the device authorization of a sign in
+type DeviceAuthorizationFunc func(authorization DeviceAuthorization)
+
+// deviceAuthorizationKey is the context key of the DeviceAuthorizationFunc of a sign in
+type deviceAuthorizationKey struct{}
+
+// WithDeviceAuthorization returns a context whose sign ins report their device
+// authorization to fn
+func WithDeviceAuthorization(ctx context.Context, fn DeviceAuthorizationFunc) context.Context {
+	return context.WithValue(ctx, deviceAuthorizationKey{}, fn)
+}
+
+// ReportDeviceAuthorization reports the device authorization of a sign in to the
+// DeviceAuthorizationFunc of the context, if any
+func ReportDeviceAuthorization(ctx context.Context, authorization DeviceAuthorization) {
+	if fn, ok := ctx.Value(deviceAuthorizationKey{}).(DeviceAuthorizationFunc); ok && fn != nil {
+		fn(authorization)
+	}
+}
@@ -0,0 +1,423 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/codepipeline"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/ssooidc"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+)
+
+// maxBulkReadAttempts is the number of attempts of each call made by the clients that
+// read every resource of an account. The adaptive retry mode also slows the calls
+// down while the service throttles them.
+const maxBulkReadAttempts = 10
+
+// CodePipelineAPI is the part of the CodePipeline client used by the operations.
+type CodePipelineAPI interface {
+	ListPipelines(ctx context.Context, params *codepipeline.ListPipelinesInput, optFns ...func(*codepipeline.Options)) (*codepipeline.ListPipelinesOutput, error)
+	GetPipeline(ctx context.Context, params *codepipeline.GetPipelineInput, optFns ...func(*codepipeline.Options)) (*codepipeline.GetPipelineOutput, error)
+	GetPipelineState(ctx context.Context, params *codepipeline.GetPipelineStateInput, optFns ...func(*codepipeline.Options)) (*codepipeline.GetPipelineStateOutput, error)
+	GetPipelineExecution(ctx context.Context, params *codepipeline.GetPipelineExecutionInput, optFns ...func(*codepipeline.Options)) (*codepipeline.GetPipelineExecutionOutput, error)
+	ListPipelineExecutions(ctx context.Context, params *codepipeline.ListPipelineExecutionsInput, optFns ...func(*codepipeline.Options)) (*codepipeline.ListPipelineExecutionsOutput, error)
+	ListActionExecutions(ctx context.Context, params *codepipeline.ListActionExecutionsInput, optFns ...func(*codepipeline.Options)) (*codepipeline.ListActionExecutionsOutput, error)
+	PutApprovalResult(ctx context.Context, params *codepipeline.PutApprovalResultInput, optFns ...func(*codepipeline.Options)) (*codepipeline.PutApprovalResultOutput, error)
+	StartPipelineExecution(ctx context.Context, params *codepipeline.StartPipelineExecutionInput, optFns ...func(*codepipeline.Options)) (*codepipeline.StartPipelineExecutionOutput, error)
+	StopPipelineExecution(ctx context.Context, params *codepipeline.StopPipelineExecutionInput, optFns ...func(*codepipeline.Options)) (*codepipeline.StopPipelineExecutionOutput, error)
+	RetryStageExecution(ctx context.Context, params *codepipeline.RetryStageExecutionInput, optFns ...func(*codepipeline.Options)) (*codepipeline.RetryStageExecutionOutput, error)
+	EnableStageTransition(ctx context.Context, params *codepipeline.EnableStageTransitionInput, optFns ...func(*codepipeline.Options)) (*codepipeline.EnableStageTransitionOutput, error)
+	DisableStageTransition(ctx context.Context, params *codepipeline.DisableStageTransitionInput, optFns ...func(*codepipeline.Options)) (*codepipeline.DisableStageTransitionOutput, error)
+	RollbackStage(ctx context.Context, params *codepipeline.RollbackStageInput, optFns ...func(*codepipeline.Options)) (*codepipeline.RollbackStageOutput, error)
+	OverrideStageCondition(ctx context.Context, params *codepipeline.OverrideStageConditionInput, optFns ...func(*codepipeline.Options)) (*codepipeline.OverrideStageConditionOutput, error)
+}
+
+// LambdaAPI is the part of the Lambda client used by the operations.
+type LambdaAPI interface {
+	ListFunctions(ctx context.Context, params *lambda.ListFunctionsInput, optFns ...func(*lambda.Options)) (*lambda.ListFunctionsOutput, error)
+}
+
+// STSAPI is the part of the STS client used to identify the caller.
+type STSAPI interface {
+	GetCallerIdentity(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error)
+}
+
+// IAMAPI is the part of the IAM client used to name the caller's account.
+type IAMAPI interface {
+	ListAccountAliases(ctx context.Context, params *iam.ListAccountAliasesInput, optFns ...func(*iam.Options)) (*iam.ListAccountAliasesOutput, error)
+}
+
+// SSOOIDCAPI is the part of the IAM Identity Center OIDC client used to sign in.
+type SSOOIDCAPI interface {
+	RegisterClient(ctx context.Context, params *ssooidc.RegisterClientInput, optFns ...func(*ssooidc.Options)) (*ssooidc.RegisterClientOutput, error)
+	StartDeviceAuthorization(ctx context.Context, params *ssooidc.StartDeviceAuthorizationInput, optFns ...func(*ssooidc.Options)) (*ssooidc.StartDeviceAuthorizationOutput, error)
+	CreateToken(ctx context.Context, params *ssooidc.CreateTokenInput, optFns ...func(*ssooidc.Options)) (*ssooidc.CreateTokenOutput, error)
+}
+
+// Factory returns the AWS SDK clients of a profile and region.
+type Factory interface {
+	// Config returns the AWS config of the profile and region.
+	Config(ctx context.Context, profile, region string) (aws.Config, error)
+	// CodePipeline returns the CodePipeline client of the profile and region.
+	CodePipeline(ctx context.Context, profile, region string) (CodePipelineAPI, error)
+	// CodePipelineReader returns the CodePipeline client used to read every pipeline
+	// of an account, retrying throttled calls at an adaptive rate.
+	CodePipelineReader(ctx context.Context, profile, region string) (CodePipelineAPI, error)
+	// Lambda returns the Lambda client of the profile and region.
+	Lambda(ctx context.Context, profile, region string) (LambdaAPI, error)
+	// STS returns the STS client of the profile and region.
+	STS(ctx context.Context, profile, region string) (STSAPI, error)
+	// IAM returns the IAM client of the profile and region.
+	IAM(ctx context.Context, profile, region string) (IAMAPI, error)
+	// SSOOIDC returns the IAM Identity Center OIDC client of the region. Its calls
+	// are not signed, so it needs no profile.
+	SSOOIDC(ctx context.Context, region string) (SSOOIDCAPI, error)
+	// Invalidate drops the config and clients of the profile so they are loaded
+	// again with its new credentials.
+	Invalidate(profile string)
+	// SetTokenCode sets the MFA code the next role assumption of the profile signs in
+	// with. Role assumptions without a code fail with cloud.ErrTokenCodeRequired.
+	SetTokenCode(profile, code string)
+	// SetCredentials sets the credentials the configs of the profile sign with instead
+	// of those of the shared config, or clears them when nil. The profile then only
+	// names the credentials, and need not be in the shared config.
+	SetCredentials(profile string, credentials CredentialsFunc)
+}
+
+// ConfigLoader loads the AWS config of a profile and region.
+type ConfigLoader func(ctx context.Context, profile, region string) (aws.Config, error)
+
+// CredentialsFunc returns the credentials a config signs with. It is given the config
+// so credentials assuming a role can call STS in its region.
+type CredentialsFunc func(cfg aws.Config) aws.CredentialsProvider
+
+// clientKey identifies the clients of a profile and region.
+type clientKey struct {
+	profile string
+	region  string
+}
+
+// CachingFactory is a Factory that loads the AWS config of each profile and region
+// once and shares its clients between operations. The configs of a profile share one
+// credentials cache in every region, which refreshes the credentials when they expire,
+// so a role assumed with an MFA code is not assumed again for each region.
+type CachingFactory struct {
+	loadConfig ConfigLoader
+
+	mu                  sync.Mutex
+	configs             map[clientKey]aws.Config
+	codePipeline        map[clientKey]*codepipeline.Client
+	codePipelineReaders map[clientKey]*codepipeline.Client
+	lambda              map[clientKey]*lambda.Client
+	sts                 map[clientKey]*sts.Client
+	iam                 map[clientKey]*iam.Client
+	ssoOIDC             map[string]*ssooidc.Client
+	// credentialCaches holds the credentials cache shared by the configs of each
+	// profile in every region
+	credentialCaches map[string]*aws.CredentialsCache
+
+	// authMu guards the MFA codes and credentials of the profiles, which are read
+	// while loading configs with mu held
+	authMu      sync.Mutex
+	tokenCodes  map[string]string
+	credentials map[string]CredentialsFunc
+}
+
+// NewCachingFactory creates a new caching factory that loads the shared AWS config.
+// Profiles assuming a role with MFA sign in with the codes set with SetTokenCode, and
+// profiles with credentials set with SetCredentials sign with them.
+func NewCachingFactory() *CachingFactory {
+	factory := NewCachingFactoryWithLoader(nil)
+	factory.loadConfig = func(ctx context.Context, profile, region string) (aws.Config, error) {
+		if credentials := factory.getCredentials(profile); credentials != nil {
+			return LoadCredentialsConfig(ctx, region, credentials)
+		}
+		return LoadDefaultConfig(ctx, profile, region,
+			config.WithAssumeRoleCredentialOptions(func(options *stscreds.AssumeRoleOptions) {
+				options.TokenProvider = factory.tokenProvider(profile)
+			}),
+		)
+	}
+	return factory
+}
+
+// NewCachingFactoryWithLoader creates a new caching factory that loads the AWS config
+// with the given loader.
+func NewCachingFactoryWithLoader(loader ConfigLoader) *CachingFactory {
+	return &CachingFactory{
+		loadConfig:          loader,
+		configs:             make(map[clientKey]aws.Config),
+		codePipeline:        make(map[clientKey]*codepipeline.Client),
+		codePipelineReaders: make(map[clientKey]*codepipeline.Client),
+		lambda:              make(map[clientKey]*lambda.Client),
+		sts:                 make(map[clientKey]*sts.Client),
+		iam:                 make(map[clientKey]*iam.Client),
+		ssoOIDC:             make(map[string]*ssooidc.Client),
+		credentialCaches:    make(map[string]*aws.CredentialsCache),
+		tokenCodes:          make(map[string]string),
+		credentials:         make(map[string]CredentialsFunc),
+	}
+}
+
+// LoadDefaultConfig loads the shared AWS config of a profile and region with the
+// given options.
+func LoadDefaultConfig(ctx context.Context, profile, region string, optFns ...func(*config.LoadOptions) error) (aws.Config, error) {
+	optFns = append([]func(*config.LoadOptions) error{
+		config.WithSharedConfigProfile(profile),
+		config.WithRegion(region),
+	}, optFns...)
+	return config.LoadDefaultConfig(ctx, optFns...)
+}
+
+// LoadCredentialsConfig loads the AWS config of a region signing with the given
+// credentials. The shared config files are not read, so it works without them.
+func LoadCredentialsConfig(ctx context.Context, region string, credentials CredentialsFunc) (aws.Config, error) {
+	cfg, err := config.LoadDefaultConfig(ctx,
+		config.WithRegion(region),
+		config.WithSharedConfigFiles([]string{}),
+		config.WithSharedCredentialsFiles([]string{}),
+	)
+	if err != nil {
+		return aws.Config{}, err
+	}
+
+	cfg.Credentials = credentials(cfg)
+	return cfg, nil
+}
+
+// Config returns the AWS config of the profile and region, loading it on first use.
+// Configs are loaded one at a time so the credentials of a profile are never resolved
+// twice, and a config that fails to load is not cached.
+func (f *CachingFactory) Config(ctx context.Context, profile, region string) (aws.Config, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.getConfig(ctx, clientKey{profile: profile, region: region})
+}
+
+// CodePipeline returns the CodePipeline client of the profile and region.
+func (f *CachingFactory) CodePipeline(ctx context.Context, profile, region string) (CodePipelineAPI, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := clientKey{profile: profile, region: region}
+	if client, ok := f.codePipeline[key]; ok {
+		return client, nil
+	}
+
+	cfg, err := f.getConfig(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	client := codepipeline.NewFromConfig(cfg)
+	f.codePipeline[key] = client
+	return client, nil
+}
+
+// CodePipelineReader returns the CodePipeline client used to read every pipeline of
+// an account, retrying throttled calls at an adaptive rate.
+func (f *CachingFactory) CodePipelineReader(ctx context.Context, profile, region string) (CodePipelineAPI, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := clientKey{profile: profile, region: region}
+	if client, ok := f.codePipelineReaders[key]; ok {
+		return client, nil
+	}
+
+	cfg, err := f.getConfig(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.RetryMode = aws.RetryModeAdaptive
+	cfg.RetryMaxAttempts = maxBulkReadAttempts
+
+	client := codepipeline.NewFromConfig(cfg)
+	f.codePipelineReaders[key] = client
+	return client, nil
+}
+
+// Lambda returns the Lambda client of the profile and region.
+func (f *CachingFactory) Lambda(ctx context.Context, profile, region string) (LambdaAPI, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := clientKey{profile: profile, region: region}
+	if client, ok := f.lambda[key]; ok {
+		return client, nil
+	}
+
+	cfg, err := f.getConfig(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	client := lambda.NewFromConfig(cfg)
+	f.lambda[key] = client
+	return client, nil
+}
+
+// STS returns the STS client of the profile and region.
+func (f *CachingFactory) STS(ctx context.Context, profile, region string) (STSAPI, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := clientKey{profile: profile, region: region}
+	if client, ok := f.sts[key]; ok {
+		return client, nil
+	}
+
+	cfg, err := f.getConfig(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	client := sts.NewFromConfig(cfg)
+	f.sts[key] = client
+	return client, nil
+}
+
+// IAM returns the IAM client of the profile and region.
+func (f *CachingFactory) IAM(ctx context.Context, profile, region string) (IAMAPI, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := clientKey{profile: profile, region: region}
+	if client, ok := f.iam[key]; ok {
+		return client, nil
+	}
+
+	cfg, err := f.getConfig(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	client := iam.NewFromConfig(cfg)
+	f.iam[key] = client
+	return client, nil
+}
+
+// SSOOIDC returns the IAM Identity Center OIDC client of the region.
+func (f *CachingFactory) SSOOIDC(ctx context.Context, region string) (SSOOIDCAPI, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if client, ok := f.ssoOIDC[region]; ok {
+		return client, nil
+	}
+
+	client := ssooidc.NewFromConfig(aws.Config{Region: region})
+	f.ssoOIDC[region] = client
+	return client, nil
+}
+
+// Invalidate drops the config, clients and credentials of the profile in every region.
+func (f *CachingFactory) Invalidate(profile string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.credentialCaches, profile)
+
+	for key := range f.configs {
+		if key.profile == profile {
+			delete(f.configs, key)
+			delete(f.codePipeline, key)
+			delete(f.codePipelineReaders, key)
+			delete(f.lambda, key)
+			delete(f.sts, key)
+			delete(f.iam, key)
+		}
+	}
+}
+
+// SetTokenCode sets the MFA code the next role assumption of the profile signs in with.
+func (f *CachingFactory) SetTokenCode(profile, code string) {
+	f.authMu.Lock()
+	defer f.authMu.Unlock()
+
+	f.tokenCodes[profile] = code
+}
+
+// tokenProvider returns the MFA token provider of the role assumptions of the profile.
+// Each code is used once, as MFA devices do not accept a code twice; the credentials
+// of the role are then cached for every region of the profile until they expire.
+func (f *CachingFactory) tokenProvider(profile string) func() (string, error) {
+	return func() (string, error) {
+		f.authMu.Lock()
+		defer f.authMu.Unlock()
+
+		code, ok := f.tokenCodes[profile]
+		if !ok {
+			return "", fmt.Errorf("%w: %s", cloud.ErrTokenCodeRequired, profile)
+		}
+		delete(f.tokenCodes, profile)
+		return code, nil
+	}
+}
+
+// SetCredentials sets the credentials the configs of the profile sign with, dropping
+// the configs and clients loaded before with other credentials.
+func (f *CachingFactory) SetCredentials(profile string, credentials CredentialsFunc) {
+	f.authMu.Lock()
+	_, replaced := f.credentials[profile]
+	if credentials != nil {
+		f.credentials[profile] = credentials
+	} else {
+		delete(f.credentials, profile)
+	}
+	f.authMu.Unlock()
+
+	if credentials != nil || replaced {
+		f.Invalidate(profile)
+	}
+}
+
+// getCredentials returns the credentials set for the profile, or nil when it signs
+// with the credentials of the shared config.
+func (f *CachingFactory) getCredentials(profile string) CredentialsFunc {
+	f.authMu.Lock()
+	defer f.authMu.Unlock()
+
+	return f.credentials[profile]
+}
+
+// getConfig returns the cached AWS config of the key, loading it if needed.
+// The caller must hold the mutex.
+func (f *CachingFactory) getConfig(ctx context.Context, key clientKey) (aws.Config, error) {
+	if cfg, ok := f.configs[key]; ok {
+		return cfg, nil
+	}
+
+	cfg, err := f.loadConfig(ctx, key.profile, key.region)
+	if err != nil {
+		return aws.Config{}, err
+	}
+
+	// Share one credentials cache between the clients of the profile in every region
+	// so credentials are only resolved again once they expire
+	if cfg.Credentials != nil {
+		if credentials, ok := f.credentialCaches[key.profile]; ok {
+			cfg.Credentials = credentials
+		} else {
+			credentials, ok := cfg.Credentials.(*aws.CredentialsCache)
+			if !ok {
+				credentials = aws.NewCredentialsCache(cfg.Credentials)
+			}
+			f.credentialCaches[key.profile] = credentials
+			cfg.Credentials = credentials
+		}
+	}
+
+	f.configs[key] = cfg
+	return cfg, nil
+}
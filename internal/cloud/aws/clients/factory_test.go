@@ -0,0 +1,209 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+)
+
+// TestCachingFactory tests loading the config of each profile and region once
+func TestCachingFactory(t *testing.T) {
+	loads := map[string]int{}
+	factory := NewCachingFactoryWithLoader(func(ctx context.Context, profile, region string) (aws.Config, error) {
+		loads[profile+"/"+region]++
+		return aws.Config{Region: region}, nil
+	})
+	ctx := context.Background()
+
+	first, err := factory.CodePipeline(ctx, "dev", "us-east-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	second, _ := factory.CodePipeline(ctx, "dev", "us-east-1")
+	if first != second {
+		t.Error("Expected the CodePipeline client to be shared")
+	}
+	if _, err := factory.CodePipelineReader(ctx, "dev", "us-east-1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := factory.Lambda(ctx, "dev", "us-east-1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := factory.STS(ctx, "dev", "us-east-1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := factory.IAM(ctx, "dev", "us-east-1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if loads["dev/us-east-1"] != 1 {
+		t.Errorf("Expected the config to be loaded once, got %d loads", loads["dev/us-east-1"])
+	}
+
+	// Another region has its own config
+	if _, err := factory.Lambda(ctx, "dev", "eu-west-1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if loads["dev/eu-west-1"] != 1 {
+		t.Errorf("Expected another region to be loaded, got %d loads", loads["dev/eu-west-1"])
+	}
+}
+
+// TestCachingFactoryLoadError tests retrying a config that failed to load
+func TestCachingFactoryLoadError(t *testing.T) {
+	loadErr := errors.New("profile not found")
+	loads := 0
+	factory := NewCachingFactoryWithLoader(func(ctx context.Context, profile, region string) (aws.Config, error) {
+		loads++
+		if loads == 1 {
+			return aws.Config{}, loadErr
+		}
+		return aws.Config{}, nil
+	})
+	ctx := context.Background()
+
+	if _, err := factory.CodePipeline(ctx, "dev", "us-east-1"); !errors.Is(err, loadErr) {
+		t.Fatalf("Expected the load error, got %v", err)
+	}
+	if _, err := factory.CodePipeline(ctx, "dev", "us-east-1"); err != nil {
+		t.Fatalf("Expected the config to load again, got %v", err)
+	}
+	if loads != 2 {
+		t.Errorf("Expected the failed config not to be cached, got %d loads", loads)
+	}
+}
+
+// TestCachingFactoryCredentials tests sharing a credentials cache between clients
+func TestCachingFactoryCredentials(t *testing.T) {
+	retrievals := 0
+	credentials := aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+		retrievals++
+		return aws.Credentials{AccessKeyID: "AKID", SecretAccessKey: "SECRET"}, nil
+	})
+	factory := NewCachingFactoryWithLoader(func(ctx context.Context, profile, region string) (aws.Config, error) {
+		return aws.Config{Credentials: credentials}, nil
+	})
+	ctx := context.Background()
+
+	cfg, err := factory.Config(ctx, "dev", "us-east-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, ok := cfg.Credentials.(*aws.CredentialsCache); !ok {
+		t.Fatalf("Expected the credentials to be cached, got %T", cfg.Credentials)
+	}
+	cfg.Credentials.Retrieve(ctx)
+	cfg, _ = factory.Config(ctx, "dev", "us-east-1")
+	cfg.Credentials.Retrieve(ctx)
+	if retrievals != 1 {
+		t.Errorf("Expected the credentials to be resolved once, got %d retrievals", retrievals)
+	}
+
+	// The other regions of the profile share its credentials
+	cfg, _ = factory.Config(ctx, "dev", "eu-west-1")
+	cfg.Credentials.Retrieve(ctx)
+	if retrievals != 1 {
+		t.Errorf("Expected another region to share the credentials, got %d retrievals", retrievals)
+	}
+
+	// Other profiles and invalidated profiles resolve their own credentials
+	cfg, _ = factory.Config(ctx, "prod", "us-east-1")
+	cfg.Credentials.Retrieve(ctx)
+	factory.Invalidate("dev")
+	cfg, _ = factory.Config(ctx, "dev", "eu-west-1")
+	cfg.Credentials.Retrieve(ctx)
+	if retrievals != 3 {
+		t.Errorf("Expected the other profile and the invalidated one to be resolved, got %d retrievals", retrievals)
+	}
+}
+
+// TestCachingFactoryInvalidate tests loading the config of a profile again after it is invalidated
+func TestCachingFactoryInvalidate(t *testing.T) {
+	loads := map[string]int{}
+	factory := NewCachingFactoryWithLoader(func(ctx context.Context, profile, region string) (aws.Config, error) {
+		loads[profile]++
+		return aws.Config{Region: region}, nil
+	})
+	ctx := context.Background()
+
+	first, _ := factory.CodePipeline(ctx, "dev", "us-east-1")
+	factory.CodePipeline(ctx, "prod", "us-east-1")
+	factory.Invalidate("dev")
+
+	second, _ := factory.CodePipeline(ctx, "dev", "us-east-1")
+	if first == second {
+		t.Error("Expected a new CodePipeline client after invalidating the profile")
+	}
+	if loads["dev"] != 2 {
+		t.Errorf("Expected the invalidated config to be loaded again, got %d loads", loads["dev"])
+	}
+
+	factory.CodePipeline(ctx, "prod", "us-east-1")
+	if loads["prod"] != 1 {
+		t.Errorf("Expected other profiles to stay cached, got %d loads", loads["prod"])
+	}
+}
+
+// TestCachingFactoryTokenCode tests using each MFA code of a profile once
+func TestCachingFactoryTokenCode(t *testing.T) {
+	factory := NewCachingFactory()
+	tokenProvider := factory.tokenProvider("deploy")
+
+	if _, err := tokenProvider(); !errors.Is(err, cloud.ErrTokenCodeRequired) {
+		t.Fatalf("Expected ErrTokenCodeRequired, got %v", err)
+	}
+
+	factory.SetTokenCode("deploy", "123456")
+	factory.SetTokenCode("other", "654321")
+	if code, err := tokenProvider(); err != nil || code != "123456" {
+		t.Fatalf("Expected the code of the profile, got %q and %v", code, err)
+	}
+	if _, err := tokenProvider(); !errors.Is(err, cloud.ErrTokenCodeRequired) {
+		t.Errorf("Expected a code to be used once, got %v", err)
+	}
+}
+
+// TestCachingFactorySetCredentials tests signing the configs of a profile with the
+// credentials set for it
+func TestCachingFactorySetCredentials(t *testing.T) {
+	t.Setenv("AWS_CONFIG_FILE", "/nonexistent/config")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", "/nonexistent/credentials")
+	factory := NewCachingFactory()
+	staticCredentials := func(accessKeyID string) CredentialsFunc {
+		return func(cfg aws.Config) aws.CredentialsProvider {
+			return aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+				return aws.Credentials{AccessKeyID: accessKeyID, SecretAccessKey: "secret"}, nil
+			})
+		}
+	}
+	retrieve := func() string {
+		cfg, err := factory.Config(context.Background(), "ci", "eu-west-1")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		credentials, err := cfg.Credentials.Retrieve(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		return credentials.AccessKeyID
+	}
+
+	factory.SetCredentials("ci", staticCredentials("first"))
+	if accessKeyID := retrieve(); accessKeyID != "first" {
+		t.Fatalf("Expected the credentials set for the profile, got %q", accessKeyID)
+	}
+
+	// Setting other credentials drops the config signing with the old ones
+	factory.SetCredentials("ci", staticCredentials("second"))
+	if accessKeyID := retrieve(); accessKeyID != "second" {
+		t.Errorf("Expected the new credentials, got %q", accessKeyID)
+	}
+
+	factory.SetCredentials("ci", nil)
+	if factory.getCredentials("ci") != nil {
+		t.Error("Expected the credentials to be cleared")
+	}
+}
@@ -2,6 +2,7 @@ package codepipeline
 
 import (
 	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/cloud/aws/clients"
 )
 
 // WorkflowsCategory represents the CodePipeline workflows category.
@@ -12,7 +13,7 @@ type WorkflowsCategory struct {
 }
 
 // NewWorkflowsCategory creates a new CodePipeline workflows category.
-func NewWorkflowsCategory(profile, region string) *WorkflowsCategory {
+func NewWorkflowsCategory(profile, region string, factory clients.Factory) *WorkflowsCategory {
 	category := &WorkflowsCategory{
 		profile:    profile,
 		region:     region,
@@ -20,9 +21,12 @@ func NewWorkflowsCategory(profile, region string) *WorkflowsCategory {
 	}
 
 	// Register operations
-	category.operations = append(category.operations, NewCloudPipelineStatusOperation(profile, region))
-	category.operations = append(category.operations, NewCloudStartPipelineOperation(profile, region))
-	category.operations = append(category.operations, NewCloudManualApprovalOperation(profile, region))
+	category.operations = append(category.operations, NewCloudPipelineStatusOperation(profile, region, factory))
+	category.operations = append(category.operations, NewCloudStartPipelineOperation(profile, region, factory))
+	category.operations = append(category.operations, NewCloudManualApprovalOperation(profile, region, factory))
+	category.operations = append(category.operations, NewCloudExecutionHistoryOperation(profile, region, factory))
+	category.operations = append(category.operations, NewCloudApprovalsInboxOperation(profile, region, factory))
+	category.operations = append(category.operations, NewCloudPipelineDefinitionOperation(profile, region, factory))
 
 	return category
 }
@@ -55,7 +59,7 @@ type InternalOperationsCategory struct {
 }
 
 // NewInternalOperationsCategory creates a new CodePipeline internal operations category.
-func NewInternalOperationsCategory(profile, region string) *InternalOperationsCategory {
+func NewInternalOperationsCategory(profile, region string, factory clients.Factory) *InternalOperationsCategory {
 	category := &InternalOperationsCategory{
 		profile:    profile,
 		region:     region,
@@ -63,7 +67,13 @@ func NewInternalOperationsCategory(profile, region string) *InternalOperationsCa
 	}
 
 	// Register operations
-	category.operations = append(category.operations, NewCloudManualApprovalOperation(profile, region))
+	category.operations = append(category.operations, NewCloudManualApprovalOperation(profile, region, factory))
+	category.operations = append(category.operations, NewCloudStopPipelineExecutionOperation(profile, region, factory))
+	category.operations = append(category.operations, NewCloudRetryStageExecutionOperation(profile, region, factory))
+	category.operations = append(category.operations, NewCloudStageTransitionOperation(profile, region, factory))
+	category.operations = append(category.operations, NewCloudRollbackStageOperation(profile, region, factory))
+	category.operations = append(category.operations, NewCloudOverrideStageConditionOperation(profile, region, factory))
+	category.operations = append(category.operations, NewCloudExecutionWatchOperation(profile, region, factory))
 
 	return category
 }
@@ -2,28 +2,92 @@ package codepipeline
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/cloud/aws/clients"
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/codepipeline"
 	cpTypes "github.com/aws/aws-sdk-go-v2/service/codepipeline/types"
 )
 
+// Common errors.
+var (
+	ErrSourceActionNotFound    = errors.New("source action not found")
+	ErrSourceActionRequired    = errors.New("source action name is required")
+	ErrUnsupportedSourceAction = errors.New("source action does not support revision overrides")
+	ErrRevisionTypeMismatch    = errors.New("revision type does not match source action provider")
+	ErrInvalidSourceRevision   = errors.New("invalid source revision")
+	ErrDuplicateSourceRevision = errors.New("source action revision is overridden more than once")
+	ErrPipelineVariableUnknown = errors.New("pipeline variable is not declared")
+	ErrStopReasonRequired      = errors.New("a reason is required to stop a pipeline execution")
+	ErrStopReasonTooLong       = errors.New("stop reason is too long")
+	ErrInvalidRetryMode        = errors.New("invalid stage retry mode")
+	ErrDisableReasonRequired   = errors.New("a reason is required to disable a stage transition")
+	ErrDisableReasonTooLong    = errors.New("disable reason is too long")
+	ErrInvalidDisableReason    = errors.New("invalid disable reason")
+	ErrInvalidConditionType    = errors.New("stage condition type cannot be overridden")
+)
+
+// sourceRevisionTypes maps source action providers to the revision type they accept
+// when a pipeline execution overrides their revision.
+var sourceRevisionTypes = map[string]cpTypes.SourceRevisionType{
+	"CodeCommit":               cpTypes.SourceRevisionTypeCommitId,
+	"CodeStarSourceConnection": cpTypes.SourceRevisionTypeCommitId,
+	"S3":                       cpTypes.SourceRevisionTypeS3ObjectVersionId,
+	"ECR":                      cpTypes.SourceRevisionTypeImageDigest,
+}
+
+// executionHistoryPageSize is the number of pipeline executions loaded per page.
+const executionHistoryPageSize int32 = 25
+
+// maxStopReasonLength is the longest reason CodePipeline accepts when stopping an execution.
+const maxStopReasonLength = 200
+
+// maxDisableReasonLength is the longest reason CodePipeline accepts when disabling a stage transition.
+const maxDisableReasonLength = 300
+
+// approvalTokenLifetime is how long a manual approval waits before CodePipeline fails it.
+const approvalTokenLifetime = 7 * 24 * time.Hour
+
+// maxConcurrentApprovalTargets is the number of profile and region pairs whose pipelines
+// the approvals inbox lists at the same time.
+const maxConcurrentApprovalTargets = 8
+
+// maxConcurrentPipelineFetches is the number of pipelines whose details are loaded at
+// the same time when reading every pipeline of an account.
+const maxConcurrentPipelineFetches = 8
+
+var (
+	commitIDPattern    = regexp.MustCompile(`^[0-9a-fA-F]{7,64}$`)
+	imageDigestPattern = regexp.MustCompile(`^sha256:[0-9a-f]{64}$`)
+)
+
+// disableReasonPattern matches the characters CodePipeline accepts in the reason for
+// disabling a stage transition.
+var disableReasonPattern = regexp.MustCompile(`^[a-zA-Z0-9!@ ().*?\-]+$`)
+
 // CloudManualApprovalOperation represents an operation to manage manual approvals in CodePipeline.
 // It implements the cloud.CodePipelineManualApprovalOperation interface.
 type CloudManualApprovalOperation struct {
 	profile string
 	region  string
+	clients clients.Factory
 }
 
 // NewCloudManualApprovalOperation creates a new manual approval operation.
-func NewCloudManualApprovalOperation(profile, region string) *CloudManualApprovalOperation {
+func NewCloudManualApprovalOperation(profile, region string, factory clients.Factory) *CloudManualApprovalOperation {
 	return &CloudManualApprovalOperation{
 		profile: profile,
 		region:  region,
+		clients: factory,
 	}
 }
 
@@ -47,66 +111,75 @@ func (o *CloudManualApprovalOperation) Execute(ctx context.Context, params map[s
 	return o.GetPendingApprovals(ctx)
 }
 
-// GetPendingApprovals returns all pending manual approval actions.
+// GetPendingApprovals returns all pending manual approval actions, loading the
+// pipelines concurrently.
 func (o *CloudManualApprovalOperation) GetPendingApprovals(ctx context.Context) ([]cloud.ApprovalAction, error) {
-	// Create a new AWS SDK client
-	cfg, err := config.LoadDefaultConfig(ctx,
-		config.WithSharedConfigProfile(o.profile),
-		config.WithRegion(o.region),
-	)
+	// Get the shared AWS SDK client for reading every pipeline
+	client, err := o.clients.CodePipelineReader(ctx, o.profile, o.region)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load AWS config: %w", err)
 	}
 
-	client := codepipeline.NewFromConfig(cfg)
-
 	// List all pipelines
-	pipelineOutput, err := client.ListPipelines(ctx, &codepipeline.ListPipelinesInput{})
+	pipelines, err := listCloudPipelines(ctx, client)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list pipelines: %w", err)
+		return nil, err
 	}
 
+	// Get approvals for each pipeline
+	results := make([][]cloud.ApprovalAction, len(pipelines))
+	err = forEachCloudPipeline(ctx, pipelines, func(i int, pipelineName string) error {
+		approvals, err := o.getPipelineApprovals(ctx, client, pipelineName)
+		results[i] = approvals
+		return err
+	})
+
 	var approvals []cloud.ApprovalAction
+	for _, result := range results {
+		approvals = append(approvals, result...)
+	}
 
-	// Get approvals for each pipeline
-	for _, pipeline := range pipelineOutput.Pipelines {
-		// Get pipeline details
-		pipelineResp, err := client.GetPipeline(ctx, &codepipeline.GetPipelineInput{
-			Name: pipeline.Name,
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed to get pipeline details: %w", err)
-		}
+	return approvals, err
+}
 
-		// Get pipeline state
-		stateResp, err := client.GetPipelineState(ctx, &codepipeline.GetPipelineStateInput{
-			Name: pipeline.Name,
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed to get pipeline state: %w", err)
-		}
+// getPipelineApprovals returns the pending manual approval actions of a pipeline.
+func (o *CloudManualApprovalOperation) getPipelineApprovals(ctx context.Context, client clients.CodePipelineAPI, pipelineName string) ([]cloud.ApprovalAction, error) {
+	// Get pipeline details
+	pipelineResp, err := client.GetPipeline(ctx, &codepipeline.GetPipelineInput{
+		Name: aws.String(pipelineName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pipeline details: %w", err)
+	}
 
-		// Find pending approvals
-		pipelineApprovals := findCloudPendingApprovals(*pipeline.Name, pipelineResp.Pipeline.Stages, stateResp.StageStates)
-		approvals = append(approvals, pipelineApprovals...)
+	// Get pipeline state
+	stateResp, err := client.GetPipelineState(ctx, &codepipeline.GetPipelineStateInput{
+		Name: aws.String(pipelineName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pipeline state: %w", err)
 	}
 
+	// Find pending approvals
+	approvals := findCloudPendingApprovals(pipelineName, pipelineResp.Pipeline.Stages, stateResp.StageStates)
+	addCloudApprovalRevisions(ctx, client, pipelineResp.Pipeline.Stages, approvals)
+	accountID := parseCloudAccountID(pipelineResp.Metadata)
+	for i := range approvals {
+		approvals[i].Profile = o.profile
+		approvals[i].Region = o.region
+		approvals[i].AccountID = accountID
+	}
 	return approvals, nil
 }
 
 // ApproveAction approves or rejects an approval action.
 func (o *CloudManualApprovalOperation) ApproveAction(ctx context.Context, action cloud.ApprovalAction, approved bool, comment string) error {
-	// Create a new AWS SDK client
-	cfg, err := config.LoadDefaultConfig(ctx,
-		config.WithSharedConfigProfile(o.profile),
-		config.WithRegion(o.region),
-	)
+	// Get the shared AWS SDK client
+	client, err := o.clients.CodePipeline(ctx, o.profile, o.region)
 	if err != nil {
 		return fmt.Errorf("failed to load AWS config: %w", err)
 	}
 
-	client := codepipeline.NewFromConfig(cfg)
-
 	// Set the approval status
 	status := cpTypes.ApprovalStatusRejected
 	if approved {
@@ -135,13 +208,15 @@ func (o *CloudManualApprovalOperation) ApproveAction(ctx context.Context, action
 type CloudPipelineStatusOperation struct {
 	profile string
 	region  string
+	clients clients.Factory
 }
 
 // NewCloudPipelineStatusOperation creates a new pipeline status operation.
-func NewCloudPipelineStatusOperation(profile, region string) *CloudPipelineStatusOperation {
+func NewCloudPipelineStatusOperation(profile, region string, factory clients.Factory) *CloudPipelineStatusOperation {
 	return &CloudPipelineStatusOperation{
 		profile: profile,
 		region:  region,
+		clients: factory,
 	}
 }
 
@@ -165,88 +240,282 @@ func (o *CloudPipelineStatusOperation) Execute(ctx context.Context, params map[s
 	return o.GetPipelineStatus(ctx)
 }
 
-// GetPipelineStatus returns the status of all pipelines.
+// GetPipelineStatus returns the status of all pipelines, loading the pipelines
+// concurrently.
 func (o *CloudPipelineStatusOperation) GetPipelineStatus(ctx context.Context) ([]cloud.PipelineStatus, error) {
-	// Create a new AWS SDK client
-	cfg, err := config.LoadDefaultConfig(ctx,
-		config.WithSharedConfigProfile(o.profile),
-		config.WithRegion(o.region),
-	)
+	// Get the shared AWS SDK client for reading every pipeline
+	client, err := o.clients.CodePipelineReader(ctx, o.profile, o.region)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load AWS config: %w", err)
 	}
 
-	client := codepipeline.NewFromConfig(cfg)
-
 	// List all pipelines
-	pipelineOutput, err := client.ListPipelines(ctx, &codepipeline.ListPipelinesInput{})
+	pipelines, err := listCloudPipelines(ctx, client)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list pipelines: %w", err)
+		return nil, err
 	}
 
+	// Get status for each pipeline, keeping the listed order
+	results := make([]*cloud.PipelineStatus, len(pipelines))
+	err = forEachCloudPipeline(ctx, pipelines, func(i int, pipelineName string) error {
+		status, err := getCloudPipelineStatus(ctx, client, pipelineName)
+		if err != nil {
+			return err
+		}
+		results[i] = &status
+		return nil
+	})
+
 	var pipelineStatuses []cloud.PipelineStatus
+	for _, result := range results {
+		if result != nil {
+			pipelineStatuses = append(pipelineStatuses, *result)
+		}
+	}
 
-	// Get status for each pipeline
-	for _, pipeline := range pipelineOutput.Pipelines {
-		// Get pipeline state
-		stateOutput, err := client.GetPipelineState(ctx, &codepipeline.GetPipelineStateInput{
-			Name: pipeline.Name,
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed to get pipeline state: %w", err)
-		}
-
-		// Create cloud pipeline status
-		status := cloud.PipelineStatus{
-			Name:   *pipeline.Name,
-			Stages: make([]cloud.StageStatus, len(stateOutput.StageStates)),
-		}
-
-		// Fill in stage statuses
-		for i, stage := range stateOutput.StageStates {
-			stageStatus := "Unknown"
-			lastUpdated := "N/A"
-			if stage.LatestExecution != nil {
-				stageStatus = string(stage.LatestExecution.Status)
-				if len(stage.ActionStates) > 0 {
-					// Find the most recent action update time
-					var latestTime *time.Time
-					for _, action := range stage.ActionStates {
-						if action.LatestExecution != nil && action.LatestExecution.LastStatusChange != nil {
-							if latestTime == nil || action.LatestExecution.LastStatusChange.After(*latestTime) {
-								latestTime = action.LatestExecution.LastStatusChange
-							}
+	return pipelineStatuses, err
+}
+
+// getCloudPipelineStatus returns the status of a pipeline.
+func getCloudPipelineStatus(ctx context.Context, client clients.CodePipelineAPI, pipelineName string) (cloud.PipelineStatus, error) {
+	// Get pipeline details for the action providers and categories
+	pipelineResp, err := client.GetPipeline(ctx, &codepipeline.GetPipelineInput{
+		Name: aws.String(pipelineName),
+	})
+	if err != nil {
+		return cloud.PipelineStatus{}, fmt.Errorf("failed to get pipeline details: %w", err)
+	}
+
+	// Get pipeline state
+	stateOutput, err := client.GetPipelineState(ctx, &codepipeline.GetPipelineStateInput{
+		Name: aws.String(pipelineName),
+	})
+	if err != nil {
+		return cloud.PipelineStatus{}, fmt.Errorf("failed to get pipeline state: %w", err)
+	}
+
+	// Create cloud pipeline status
+	status := cloud.PipelineStatus{
+		Name:   pipelineName,
+		Stages: make([]cloud.StageStatus, len(stateOutput.StageStates)),
+	}
+
+	stageDeclarations := make(map[string]cpTypes.StageDeclaration, len(pipelineResp.Pipeline.Stages))
+	for _, stage := range pipelineResp.Pipeline.Stages {
+		stageDeclarations[aws.ToString(stage.Name)] = stage
+	}
+
+	// Fill in stage statuses
+	for i, stage := range stateOutput.StageStates {
+		stageStatus := "Unknown"
+		lastUpdated := "N/A"
+		executionID := ""
+		if stage.LatestExecution != nil {
+			stageStatus = string(stage.LatestExecution.Status)
+			executionID = aws.ToString(stage.LatestExecution.PipelineExecutionId)
+			if len(stage.ActionStates) > 0 {
+				// Find the most recent action update time
+				var latestTime *time.Time
+				for _, action := range stage.ActionStates {
+					if action.LatestExecution != nil && action.LatestExecution.LastStatusChange != nil {
+						if latestTime == nil || action.LatestExecution.LastStatusChange.After(*latestTime) {
+							latestTime = action.LatestExecution.LastStatusChange
 						}
 					}
-					if latestTime != nil {
-						lastUpdated = latestTime.UTC().Format("Jan 02 15:04:05") + " UTC"
-					}
+				}
+				if latestTime != nil {
+					lastUpdated = latestTime.UTC().Format("Jan 02 15:04:05") + " UTC"
 				}
 			}
-			status.Stages[i] = cloud.StageStatus{
-				Name:        *stage.StageName,
-				Status:      stageStatus,
-				LastUpdated: lastUpdated,
+		}
+		status.Stages[i] = cloud.StageStatus{
+			Name:        *stage.StageName,
+			Status:      stageStatus,
+			LastUpdated: lastUpdated,
+			ExecutionID: executionID,
+			Actions:     buildCloudActionStatuses(stageDeclarations[aws.ToString(stage.StageName)], stage.ActionStates),
+		}
+		if i > 0 && stage.InboundTransitionState != nil {
+			status.Stages[i].InboundTransition = convertCloudTransitionState(stage.InboundTransitionState)
+		}
+		status.Stages[i].Conditions = buildCloudConditionStatuses(stage)
+	}
+
+	return status, nil
+}
+
+// listCloudPipelines lists every pipeline of the account page by page, reporting the
+// number of pipelines listed so far.
+func listCloudPipelines(ctx context.Context, client clients.CodePipelineAPI) ([]cpTypes.PipelineSummary, error) {
+	var pipelines []cpTypes.PipelineSummary
+	paginator := codepipeline.NewListPipelinesPaginator(client, &codepipeline.ListPipelinesInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pipelines: %w", err)
+		}
+		pipelines = append(pipelines, page.Pipelines...)
+		cloud.ReportProgress(ctx, cloud.Progress{Loaded: len(pipelines)})
+	}
+	return pipelines, nil
+}
+
+// forEachCloudPipeline calls fetch for every pipeline with its index in the list from a
+// pool of at most maxConcurrentPipelineFetches workers, and reports the number of
+// pipelines loaded so far. It returns an error joining a *cloud.PipelineError for each
+// pipeline whose fetch failed, in the order of the list.
+func forEachCloudPipeline(ctx context.Context, pipelines []cpTypes.PipelineSummary, fetch func(i int, pipelineName string) error) error {
+	errs := make([]error, len(pipelines))
+	runCloudFetches(ctx, len(pipelines), func(i int) {
+		pipelineName := aws.ToString(pipelines[i].Name)
+		if err := fetch(i, pipelineName); err != nil {
+			errs[i] = &cloud.PipelineError{Pipeline: pipelineName, Err: err}
+		}
+	})
+	return errors.Join(errs...)
+}
+
+// runCloudFetches calls fetch for the indexes 0 to total-1 from a pool of at most
+// maxConcurrentPipelineFetches workers, and reports the number of fetches done so far.
+func runCloudFetches(ctx context.Context, total int, fetch func(i int)) {
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	loaded := 0
+	for range min(maxConcurrentPipelineFetches, total) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fetch(i)
+
+				// Report under the lock so that the counts arrive in order
+				mu.Lock()
+				loaded++
+				cloud.ReportProgress(ctx, cloud.Progress{Loaded: loaded, Total: total})
+				mu.Unlock()
 			}
+		}()
+	}
+	for i := range total {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+// buildCloudActionStatuses builds the statuses of a stage's actions in the order they
+// are declared, adding actions that only appear in the stage state at the end.
+func buildCloudActionStatuses(stage cpTypes.StageDeclaration, actionStates []cpTypes.ActionState) []cloud.ActionStatus {
+	stateMap := make(map[string]cpTypes.ActionState, len(actionStates))
+	for _, state := range actionStates {
+		stateMap[aws.ToString(state.ActionName)] = state
+	}
+
+	actions := make([]cloud.ActionStatus, 0, len(actionStates))
+	declared := make(map[string]bool, len(stage.Actions))
+	for _, declaration := range stage.Actions {
+		name := aws.ToString(declaration.Name)
+		declared[name] = true
+
+		action := convertCloudActionState(name, stateMap[name])
+		if declaration.RunOrder != nil {
+			action.RunOrder = int(*declaration.RunOrder)
+		}
+		if declaration.ActionTypeId != nil {
+			action.Category = string(declaration.ActionTypeId.Category)
+			action.Provider = aws.ToString(declaration.ActionTypeId.Provider)
+		}
+		actions = append(actions, action)
+	}
+
+	for _, state := range actionStates {
+		if name := aws.ToString(state.ActionName); !declared[name] {
+			actions = append(actions, convertCloudActionState(name, state))
 		}
+	}
+
+	return actions
+}
 
-		pipelineStatuses = append(pipelineStatuses, status)
+// convertCloudActionState converts an action state to an action status.
+func convertCloudActionState(name string, state cpTypes.ActionState) cloud.ActionStatus {
+	action := cloud.ActionStatus{
+		Name:             name,
+		Status:           "Unknown",
+		RunOrder:         1,
+		LastStatusChange: "N/A",
+		EntityURL:        aws.ToString(state.EntityUrl),
 	}
 
-	return pipelineStatuses, nil
+	execution := state.LatestExecution
+	if execution == nil {
+		return action
+	}
+
+	action.Status = string(execution.Status)
+	action.LastStatusChange = formatCloudTime(execution.LastStatusChange)
+	action.ExternalExecutionID = aws.ToString(execution.ExternalExecutionId)
+	action.ExternalExecutionURL = aws.ToString(execution.ExternalExecutionUrl)
+	action.Summary = aws.ToString(execution.Summary)
+	if execution.ErrorDetails != nil {
+		action.ErrorCode = aws.ToString(execution.ErrorDetails.Code)
+		action.ErrorMessage = aws.ToString(execution.ErrorDetails.Message)
+	}
+
+	return action
+}
+
+// convertCloudTransitionState converts the state of a stage's inbound transition.
+func convertCloudTransitionState(state *cpTypes.TransitionState) *cloud.TransitionState {
+	return &cloud.TransitionState{
+		Enabled:        state.Enabled,
+		DisabledReason: aws.ToString(state.DisabledReason),
+		LastChangedBy:  aws.ToString(state.LastChangedBy),
+		LastChangedAt:  formatCloudTime(state.LastChangedAt),
+	}
+}
+
+// buildCloudConditionStatuses builds the latest results of a stage's before-entry,
+// on-success and on-failure conditions, skipping condition types that have not run.
+func buildCloudConditionStatuses(stage cpTypes.StageState) []cloud.ConditionStatus {
+	conditionStates := []struct {
+		conditionType string
+		state         *cpTypes.StageConditionState
+	}{
+		{cloud.ConditionTypeBeforeEntry, stage.BeforeEntryConditionState},
+		{cloud.ConditionTypeOnSuccess, stage.OnSuccessConditionState},
+		{cloud.ConditionTypeOnFailure, stage.OnFailureConditionState},
+	}
+
+	var conditions []cloud.ConditionStatus
+	for _, conditionState := range conditionStates {
+		if conditionState.state == nil || conditionState.state.LatestExecution == nil {
+			continue
+		}
+		conditions = append(conditions, cloud.ConditionStatus{
+			Type:    conditionState.conditionType,
+			Status:  string(conditionState.state.LatestExecution.Status),
+			Summary: aws.ToString(conditionState.state.LatestExecution.Summary),
+		})
+	}
+	return conditions
 }
 
 // CloudStartPipelineOperation represents an operation to start a pipeline execution.
 type CloudStartPipelineOperation struct {
 	profile string
 	region  string
+	clients clients.Factory
 }
 
 // NewCloudStartPipelineOperation creates a new start pipeline operation.
-func NewCloudStartPipelineOperation(profile, region string) *CloudStartPipelineOperation {
+func NewCloudStartPipelineOperation(profile, region string, factory clients.Factory) *CloudStartPipelineOperation {
 	return &CloudStartPipelineOperation{
 		profile: profile,
 		region:  region,
+		clients: factory,
 	}
 }
 
@@ -267,45 +536,266 @@ func (o *CloudStartPipelineOperation) IsUIVisible() bool {
 
 // Execute executes the operation with the given parameters.
 func (o *CloudStartPipelineOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
-	// Get pipeline name and commit ID from parameters
+	// Get pipeline name and source revisions from parameters
 	pipelineName, ok := params["pipeline_name"].(string)
 	if !ok {
 		return nil, fmt.Errorf("pipeline_name parameter is required")
 	}
 
-	commitID, _ := params["commit_id"].(string)
+	sourceRevisions, _ := params["source_revisions"].([]cloud.SourceRevision)
+
+	// A bare commit ID is applied to the pipeline's only source action
+	if commitID, _ := params["commit_id"].(string); commitID != "" {
+		actionName, _ := params["action_name"].(string)
+		sourceRevisions = append(sourceRevisions, cloud.SourceRevision{
+			ActionName:    actionName,
+			RevisionType:  cloud.RevisionTypeCommitID,
+			RevisionValue: commitID,
+		})
+	}
+
+	variables, _ := params["variables"].([]cloud.PipelineVariable)
 
 	// Start the pipeline
-	return nil, o.StartPipelineExecution(ctx, pipelineName, commitID)
+	return o.StartPipelineExecution(ctx, pipelineName, sourceRevisions, variables)
 }
 
-// StartPipelineExecution starts a pipeline execution.
-func (o *CloudStartPipelineOperation) StartPipelineExecution(ctx context.Context, pipelineName, commitID string) error {
-	// Create a new AWS SDK client
-	cfg, err := config.LoadDefaultConfig(ctx,
-		config.WithSharedConfigProfile(o.profile),
-		config.WithRegion(o.region),
-	)
+// GetSourceActions returns the source actions declared in a pipeline.
+func (o *CloudStartPipelineOperation) GetSourceActions(ctx context.Context, pipelineName string) ([]cloud.SourceAction, error) {
+	// Get the shared AWS SDK client
+	client, err := o.clients.CodePipeline(ctx, o.profile, o.region)
 	if err != nil {
-		return fmt.Errorf("failed to load AWS config: %w", err)
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	return getCloudSourceActions(ctx, client, pipelineName)
+}
+
+// GetPipelineVariables returns the pipeline-level variables declared in a pipeline.
+func (o *CloudStartPipelineOperation) GetPipelineVariables(ctx context.Context, pipelineName string) ([]cloud.PipelineVariable, error) {
+	// Get the shared AWS SDK client
+	client, err := o.clients.CodePipeline(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	pipelineResp, err := client.GetPipeline(ctx, &codepipeline.GetPipelineInput{
+		Name: aws.String(pipelineName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pipeline details: %w", err)
 	}
 
-	client := codepipeline.NewFromConfig(cfg)
+	return convertCloudPipelineVariables(pipelineResp.Pipeline.Variables), nil
+}
+
+// StartPipelineExecution starts a pipeline execution and returns the ID of the new execution.
+func (o *CloudStartPipelineOperation) StartPipelineExecution(ctx context.Context, pipelineName string, sourceRevisions []cloud.SourceRevision, variables []cloud.PipelineVariable) (string, error) {
+	// Get the shared AWS SDK client
+	client, err := o.clients.CodePipeline(ctx, o.profile, o.region)
+	if err != nil {
+		return "", fmt.Errorf("failed to load AWS config: %w", err)
+	}
 
 	// Create the input
 	input := &codepipeline.StartPipelineExecutionInput{
 		Name: aws.String(pipelineName),
 	}
 
+	// Validate the source revisions and variables against the pipeline's declaration,
+	// fetched once for both
+	if len(sourceRevisions) > 0 || len(variables) > 0 {
+		pipelineResp, err := client.GetPipeline(ctx, &codepipeline.GetPipelineInput{
+			Name: aws.String(pipelineName),
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to get pipeline details: %w", err)
+		}
+
+		if len(sourceRevisions) > 0 {
+			sourceActions := findCloudSourceActions(pipelineResp.Pipeline.Stages)
+			overrides, err := buildCloudSourceRevisionOverrides(sourceActions, sourceRevisions)
+			if err != nil {
+				return "", err
+			}
+			input.SourceRevisions = overrides
+		}
+
+		if len(variables) > 0 {
+			pipelineVariables, err := buildCloudPipelineVariables(pipelineResp.Pipeline.Variables, variables)
+			if err != nil {
+				return "", err
+			}
+			input.Variables = pipelineVariables
+		}
+	}
+
 	// Start the pipeline execution
-	_, err = client.StartPipelineExecution(ctx, input)
+	output, err := client.StartPipelineExecution(ctx, input)
+	if err != nil {
+		return "", fmt.Errorf("failed to start pipeline execution: %w", err)
+	}
+
+	return aws.ToString(output.PipelineExecutionId), nil
+}
+
+// getCloudSourceActions returns the source actions declared in a pipeline.
+func getCloudSourceActions(ctx context.Context, client clients.CodePipelineAPI, pipelineName string) ([]cloud.SourceAction, error) {
+	pipelineResp, err := client.GetPipeline(ctx, &codepipeline.GetPipelineInput{
+		Name: aws.String(pipelineName),
+	})
 	if err != nil {
-		return fmt.Errorf("failed to start pipeline execution: %w", err)
+		return nil, fmt.Errorf("failed to get pipeline details: %w", err)
+	}
+
+	return findCloudSourceActions(pipelineResp.Pipeline.Stages), nil
+}
+
+// findCloudSourceActions finds all source actions in a pipeline.
+func findCloudSourceActions(stages []cpTypes.StageDeclaration) []cloud.SourceAction {
+	var sourceActions []cloud.SourceAction
+	for _, stage := range stages {
+		for _, action := range stage.Actions {
+			if action.ActionTypeId == nil || action.ActionTypeId.Category != cpTypes.ActionCategorySource {
+				continue
+			}
+			provider := aws.ToString(action.ActionTypeId.Provider)
+			sourceActions = append(sourceActions, cloud.SourceAction{
+				Name:         aws.ToString(action.Name),
+				Provider:     provider,
+				RevisionType: string(sourceRevisionTypes[provider]),
+			})
+		}
+	}
+	return sourceActions
+}
+
+// buildCloudSourceRevisionOverrides validates source revisions against the pipeline's
+// source actions and converts them to source revision overrides, one per action.
+func buildCloudSourceRevisionOverrides(sourceActions []cloud.SourceAction, sourceRevisions []cloud.SourceRevision) ([]cpTypes.SourceRevisionOverride, error) {
+	overrides := make([]cpTypes.SourceRevisionOverride, 0, len(sourceRevisions))
+	overridden := make(map[string]bool, len(sourceRevisions))
+	for _, revision := range sourceRevisions {
+		action, err := findCloudSourceAction(sourceActions, revision.ActionName)
+		if err != nil {
+			return nil, err
+		}
+		if overridden[action.Name] {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateSourceRevision, action.Name)
+		}
+		overridden[action.Name] = true
+
+		if action.RevisionType == "" {
+			return nil, fmt.Errorf("%w: %s (%s)", ErrUnsupportedSourceAction, action.Name, action.Provider)
+		}
+
+		// Default to the revision type accepted by the action's provider
+		revisionType := revision.RevisionType
+		if revisionType == "" {
+			revisionType = action.RevisionType
+		}
+		if revisionType != action.RevisionType {
+			return nil, fmt.Errorf("%w: %s accepts %s, got %s", ErrRevisionTypeMismatch, action.Name, action.RevisionType, revisionType)
+		}
+
+		if err := validateCloudRevisionValue(revisionType, revision.RevisionValue); err != nil {
+			return nil, err
+		}
+
+		overrides = append(overrides, cpTypes.SourceRevisionOverride{
+			ActionName:    aws.String(action.Name),
+			RevisionType:  cpTypes.SourceRevisionType(revisionType),
+			RevisionValue: aws.String(strings.TrimSpace(revision.RevisionValue)),
+		})
+	}
+	return overrides, nil
+}
+
+// findCloudSourceAction finds a source action by name. An empty name matches
+// the pipeline's only source action.
+func findCloudSourceAction(sourceActions []cloud.SourceAction, actionName string) (cloud.SourceAction, error) {
+	if actionName == "" {
+		if len(sourceActions) == 1 {
+			return sourceActions[0], nil
+		}
+		return cloud.SourceAction{}, fmt.Errorf("%w: pipeline has %d source actions", ErrSourceActionRequired, len(sourceActions))
+	}
+
+	for _, action := range sourceActions {
+		if action.Name == actionName {
+			return action, nil
+		}
+	}
+	return cloud.SourceAction{}, fmt.Errorf("%w: %s", ErrSourceActionNotFound, actionName)
+}
+
+// validateCloudRevisionValue checks that a revision value is well-formed for its revision type.
+func validateCloudRevisionValue(revisionType, value string) error {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return fmt.Errorf("%w: revision value cannot be empty", ErrInvalidSourceRevision)
 	}
 
+	switch revisionType {
+	case cloud.RevisionTypeCommitID:
+		if !commitIDPattern.MatchString(value) {
+			return fmt.Errorf("%w: %q is not a commit ID", ErrInvalidSourceRevision, value)
+		}
+	case cloud.RevisionTypeImageDigest:
+		if !imageDigestPattern.MatchString(value) {
+			return fmt.Errorf("%w: %q is not an image digest (sha256:...)", ErrInvalidSourceRevision, value)
+		}
+	case cloud.RevisionTypeS3ObjectVersionID:
+		if strings.ContainsAny(value, " \t") {
+			return fmt.Errorf("%w: %q is not an S3 object version ID", ErrInvalidSourceRevision, value)
+		}
+	default:
+		return fmt.Errorf("%w: unknown revision type %s", ErrInvalidSourceRevision, revisionType)
+	}
 	return nil
 }
 
+// convertCloudPipelineVariables converts variable declarations to pipeline variables
+// whose values default to the declared default values.
+func convertCloudPipelineVariables(declarations []cpTypes.PipelineVariableDeclaration) []cloud.PipelineVariable {
+	variables := make([]cloud.PipelineVariable, 0, len(declarations))
+	for _, declaration := range declarations {
+		defaultValue := aws.ToString(declaration.DefaultValue)
+		variables = append(variables, cloud.PipelineVariable{
+			Name:         aws.ToString(declaration.Name),
+			Value:        defaultValue,
+			DefaultValue: defaultValue,
+			Description:  aws.ToString(declaration.Description),
+		})
+	}
+	return variables
+}
+
+// buildCloudPipelineVariables validates variables against the pipeline's variable
+// declarations and converts them to pipeline variables. Variables without a value
+// are omitted so the pipeline falls back to the declared default.
+func buildCloudPipelineVariables(declarations []cpTypes.PipelineVariableDeclaration, variables []cloud.PipelineVariable) ([]cpTypes.PipelineVariable, error) {
+	declared := make(map[string]bool, len(declarations))
+	for _, declaration := range declarations {
+		declared[aws.ToString(declaration.Name)] = true
+	}
+
+	pipelineVariables := make([]cpTypes.PipelineVariable, 0, len(variables))
+	for _, variable := range variables {
+		if !declared[variable.Name] {
+			return nil, fmt.Errorf("%w: %s", ErrPipelineVariableUnknown, variable.Name)
+		}
+		if variable.Value == "" {
+			continue
+		}
+		pipelineVariables = append(pipelineVariables, cpTypes.PipelineVariable{
+			Name:  aws.String(variable.Name),
+			Value: aws.String(variable.Value),
+		})
+	}
+	return pipelineVariables, nil
+}
+
 // findCloudPendingApprovals finds all pending manual approval actions in a pipeline.
 func findCloudPendingApprovals(pipelineName string, stages []cpTypes.StageDeclaration, stageStates []cpTypes.StageState) []cloud.ApprovalAction {
 	// Build a map of action types for quick lookup
@@ -347,18 +837,37 @@ func buildCloudStageStateMap(stageStates []cpTypes.StageState) map[string]cpType
 
 // findCloudStageApprovals finds all pending manual approval actions in a stage.
 func findCloudStageApprovals(pipelineName string, stage cpTypes.StageDeclaration, state cpTypes.StageState, actionTypes map[string]cpTypes.ActionCategory) []cloud.ApprovalAction {
+	// Build a map of action configurations for the approval details
+	configurations := make(map[string]map[string]string)
+	for _, action := range stage.Actions {
+		configurations[aws.ToString(action.Name)] = action.Configuration
+	}
+
+	var executionID string
+	if state.LatestExecution != nil {
+		executionID = aws.ToString(state.LatestExecution.PipelineExecutionId)
+	}
+
 	var approvals []cloud.ApprovalAction
 	for _, actionState := range state.ActionStates {
 		if actionState.ActionName != nil && isCloudApprovalAction(*actionState.ActionName, actionTypes) {
 			// Check if the action is waiting for approval
 			if actionState.LatestExecution != nil && actionState.LatestExecution.Status == cpTypes.ActionExecutionStatusInProgress {
 				if actionState.LatestExecution.Token != nil {
-					approvals = append(approvals, cloud.ApprovalAction{
-						PipelineName: pipelineName,
-						StageName:    *state.StageName,
-						ActionName:   *actionState.ActionName,
-						Token:        *actionState.LatestExecution.Token,
-					})
+					approval := cloud.ApprovalAction{
+						PipelineName:       pipelineName,
+						StageName:          *state.StageName,
+						ActionName:         *actionState.ActionName,
+						Token:              *actionState.LatestExecution.Token,
+						ExecutionID:        executionID,
+						CustomData:         configurations[*actionState.ActionName]["CustomData"],
+						ExternalEntityLink: configurations[*actionState.ActionName]["ExternalEntityLink"],
+					}
+					if requestedAt := actionState.LatestExecution.LastStatusChange; requestedAt != nil {
+						approval.RequestedAt = *requestedAt
+						approval.ExpiresAt = requestedAt.Add(approvalTokenLifetime)
+					}
+					approvals = append(approvals, approval)
 				}
 			}
 		}
@@ -366,8 +875,1346 @@ func findCloudStageApprovals(pipelineName string, stage cpTypes.StageDeclaration
 	return approvals
 }
 
+// addCloudApprovalRevisions adds the source revisions of the pipeline executions
+// waiting for the approvals, loading each execution only once. The revisions only add
+// context to the approvals, so approvals whose execution fails to load are kept
+// without revisions.
+func addCloudApprovalRevisions(ctx context.Context, client clients.CodePipelineAPI, stages []cpTypes.StageDeclaration, approvals []cloud.ApprovalAction) {
+	revisions := make(map[string][]cloud.ExecutionSourceRevision)
+	for i := range approvals {
+		executionID := approvals[i].ExecutionID
+		if executionID == "" {
+			continue
+		}
+
+		if _, ok := revisions[executionID]; !ok {
+			executionResp, err := client.GetPipelineExecution(ctx, &codepipeline.GetPipelineExecutionInput{
+				PipelineName:        aws.String(approvals[i].PipelineName),
+				PipelineExecutionId: aws.String(executionID),
+			})
+			var executionRevisions []cloud.ExecutionSourceRevision
+			if err == nil && executionResp.PipelineExecution != nil {
+				executionRevisions = convertCloudArtifactRevisions(stages, executionResp.PipelineExecution.ArtifactRevisions)
+			}
+			revisions[executionID] = executionRevisions
+		}
+
+		approvals[i].SourceRevisions = revisions[executionID]
+	}
+}
+
+// convertCloudArtifactRevisions converts the artifact revisions of a pipeline execution
+// to source revisions, naming each after the action that produced its artifact.
+func convertCloudArtifactRevisions(stages []cpTypes.StageDeclaration, artifactRevisions []cpTypes.ArtifactRevision) []cloud.ExecutionSourceRevision {
+	artifactActions := make(map[string]string)
+	for _, stage := range stages {
+		for _, action := range stage.Actions {
+			for _, artifact := range action.OutputArtifacts {
+				artifactActions[aws.ToString(artifact.Name)] = aws.ToString(action.Name)
+			}
+		}
+	}
+
+	revisions := make([]cloud.ExecutionSourceRevision, 0, len(artifactRevisions))
+	for _, revision := range artifactRevisions {
+		artifactName := aws.ToString(revision.Name)
+		actionName, ok := artifactActions[artifactName]
+		if !ok {
+			actionName = artifactName
+		}
+
+		revisions = append(revisions, cloud.ExecutionSourceRevision{
+			ActionName:      actionName,
+			RevisionID:      aws.ToString(revision.RevisionId),
+			RevisionSummary: parseCloudRevisionSummary(aws.ToString(revision.RevisionSummary)),
+			RevisionURL:     aws.ToString(revision.RevisionUrl),
+		})
+	}
+	return revisions
+}
+
+// parseCloudRevisionSummary returns the commit message of a revision summary. Connection
+// sources report the summary as JSON, other sources as the plain commit message.
+func parseCloudRevisionSummary(summary string) string {
+	var details struct {
+		CommitMessage string `json:"CommitMessage"`
+	}
+	if err := json.Unmarshal([]byte(summary), &details); err == nil && details.CommitMessage != "" {
+		return details.CommitMessage
+	}
+	return summary
+}
+
+// parseCloudAccountID returns the ID of the account that owns a pipeline, taken from its ARN.
+func parseCloudAccountID(metadata *cpTypes.PipelineMetadata) string {
+	if metadata == nil {
+		return ""
+	}
+	// arn:partition:codepipeline:region:account-id:pipeline-name
+	parts := strings.Split(aws.ToString(metadata.PipelineArn), ":")
+	if len(parts) < 6 {
+		return ""
+	}
+	return parts[4]
+}
+
 // isCloudApprovalAction checks if an action is a manual approval action.
 func isCloudApprovalAction(actionName string, actionTypes map[string]cpTypes.ActionCategory) bool {
 	category, ok := actionTypes[actionName]
 	return ok && category == cpTypes.ActionCategoryApproval
 }
+
+// CloudExecutionHistoryOperation represents an operation to view the execution history of a pipeline.
+type CloudExecutionHistoryOperation struct {
+	profile string
+	region  string
+	clients clients.Factory
+}
+
+// NewCloudExecutionHistoryOperation creates a new execution history operation.
+func NewCloudExecutionHistoryOperation(profile, region string, factory clients.Factory) *CloudExecutionHistoryOperation {
+	return &CloudExecutionHistoryOperation{
+		profile: profile,
+		region:  region,
+		clients: factory,
+	}
+}
+
+// Name returns the operation's name.
+func (o *CloudExecutionHistoryOperation) Name() string {
+	return "Execution History"
+}
+
+// Description returns the operation's description.
+func (o *CloudExecutionHistoryOperation) Description() string {
+	return "View Pipeline Execution History"
+}
+
+// IsUIVisible returns whether this operation should be visible in the UI.
+func (o *CloudExecutionHistoryOperation) IsUIVisible() bool {
+	return true
+}
+
+// Execute executes the operation with the given parameters.
+func (o *CloudExecutionHistoryOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	pipelineName, ok := params["pipeline_name"].(string)
+	if !ok {
+		return nil, fmt.Errorf("pipeline_name parameter is required")
+	}
+
+	nextToken, _ := params["next_token"].(string)
+
+	executions, _, err := o.GetPipelineExecutions(ctx, pipelineName, nextToken)
+	return executions, err
+}
+
+// GetPipelineExecutions returns a page of a pipeline's executions, most recent first.
+func (o *CloudExecutionHistoryOperation) GetPipelineExecutions(ctx context.Context, pipelineName, nextToken string) ([]cloud.PipelineExecution, string, error) {
+	// Get the shared AWS SDK client
+	client, err := o.clients.CodePipeline(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	input := &codepipeline.ListPipelineExecutionsInput{
+		PipelineName: aws.String(pipelineName),
+		MaxResults:   aws.Int32(executionHistoryPageSize),
+	}
+	if nextToken != "" {
+		input.NextToken = aws.String(nextToken)
+	}
+
+	output, err := client.ListPipelineExecutions(ctx, input)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list pipeline executions: %w", err)
+	}
+
+	executions := make([]cloud.PipelineExecution, 0, len(output.PipelineExecutionSummaries))
+	for _, summary := range output.PipelineExecutionSummaries {
+		executions = append(executions, convertCloudPipelineExecution(summary))
+	}
+
+	return executions, aws.ToString(output.NextToken), nil
+}
+
+// convertCloudPipelineExecution converts a pipeline execution summary to a pipeline execution.
+func convertCloudPipelineExecution(summary cpTypes.PipelineExecutionSummary) cloud.PipelineExecution {
+	execution := cloud.PipelineExecution{
+		ExecutionID:   aws.ToString(summary.PipelineExecutionId),
+		Status:        string(summary.Status),
+		StatusSummary: aws.ToString(summary.StatusSummary),
+		StartTime:     formatCloudTime(summary.StartTime),
+	}
+
+	if summary.Trigger != nil {
+		execution.TriggerType = string(summary.Trigger.TriggerType)
+		execution.TriggerDetail = aws.ToString(summary.Trigger.TriggerDetail)
+	}
+
+	for _, revision := range summary.SourceRevisions {
+		execution.SourceRevisions = append(execution.SourceRevisions, cloud.ExecutionSourceRevision{
+			ActionName:      aws.ToString(revision.ActionName),
+			RevisionID:      aws.ToString(revision.RevisionId),
+			RevisionSummary: aws.ToString(revision.RevisionSummary),
+			RevisionURL:     aws.ToString(revision.RevisionUrl),
+		})
+	}
+
+	// The last update of a finished execution is the time it ended
+	if isCloudExecutionFinished(summary.Status) && summary.LastUpdateTime != nil {
+		execution.EndTime = formatCloudTime(summary.LastUpdateTime)
+		if summary.StartTime != nil {
+			execution.Duration = summary.LastUpdateTime.Sub(*summary.StartTime).Round(time.Second).String()
+		}
+	}
+
+	return execution
+}
+
+// isCloudExecutionFinished checks if a pipeline execution has reached a final status.
+func isCloudExecutionFinished(status cpTypes.PipelineExecutionStatus) bool {
+	switch status {
+	case cpTypes.PipelineExecutionStatusSucceeded,
+		cpTypes.PipelineExecutionStatusFailed,
+		cpTypes.PipelineExecutionStatusStopped,
+		cpTypes.PipelineExecutionStatusSuperseded,
+		cpTypes.PipelineExecutionStatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// formatCloudTime formats a timestamp the way pipeline times are shown in the UI.
+func formatCloudTime(t *time.Time) string {
+	if t == nil {
+		return "N/A"
+	}
+	return t.UTC().Format("Jan 02 15:04:05") + " UTC"
+}
+
+// CloudStopPipelineExecutionOperation represents an operation to stop a running pipeline execution.
+type CloudStopPipelineExecutionOperation struct {
+	profile string
+	region  string
+	clients clients.Factory
+}
+
+// NewCloudStopPipelineExecutionOperation creates a new stop pipeline execution operation.
+func NewCloudStopPipelineExecutionOperation(profile, region string, factory clients.Factory) *CloudStopPipelineExecutionOperation {
+	return &CloudStopPipelineExecutionOperation{
+		profile: profile,
+		region:  region,
+		clients: factory,
+	}
+}
+
+// Name returns the operation's name.
+func (o *CloudStopPipelineExecutionOperation) Name() string {
+	return "Stop Pipeline Execution"
+}
+
+// Description returns the operation's description.
+func (o *CloudStopPipelineExecutionOperation) Description() string {
+	return "Stop a Running Pipeline Execution"
+}
+
+// IsUIVisible returns whether this operation should be visible in the UI.
+// Executions are stopped from the pipeline stages view.
+func (o *CloudStopPipelineExecutionOperation) IsUIVisible() bool {
+	return false
+}
+
+// Execute executes the operation with the given parameters.
+func (o *CloudStopPipelineExecutionOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	pipelineName, ok := params["pipeline_name"].(string)
+	if !ok {
+		return nil, fmt.Errorf("pipeline_name parameter is required")
+	}
+
+	executionID, ok := params["execution_id"].(string)
+	if !ok {
+		return nil, fmt.Errorf("execution_id parameter is required")
+	}
+
+	abandon, _ := params["abandon"].(bool)
+	reason, _ := params["reason"].(string)
+
+	return nil, o.StopPipelineExecution(ctx, pipelineName, executionID, abandon, reason)
+}
+
+// StopPipelineExecution stops a pipeline execution.
+func (o *CloudStopPipelineExecutionOperation) StopPipelineExecution(ctx context.Context, pipelineName, executionID string, abandon bool, reason string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrStopReasonRequired
+	}
+	if len(reason) > maxStopReasonLength {
+		return fmt.Errorf("%w: %d characters, at most %d are allowed", ErrStopReasonTooLong, len(reason), maxStopReasonLength)
+	}
+
+	// Get the shared AWS SDK client
+	client, err := o.clients.CodePipeline(ctx, o.profile, o.region)
+	if err != nil {
+		return fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	_, err = client.StopPipelineExecution(ctx, &codepipeline.StopPipelineExecutionInput{
+		PipelineName:        aws.String(pipelineName),
+		PipelineExecutionId: aws.String(executionID),
+		Abandon:             abandon,
+		Reason:              aws.String(reason),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to stop pipeline execution: %w", err)
+	}
+
+	return nil
+}
+
+// CloudRetryStageExecutionOperation represents an operation to retry a failed stage.
+type CloudRetryStageExecutionOperation struct {
+	profile string
+	region  string
+	clients clients.Factory
+}
+
+// NewCloudRetryStageExecutionOperation creates a new retry stage execution operation.
+func NewCloudRetryStageExecutionOperation(profile, region string, factory clients.Factory) *CloudRetryStageExecutionOperation {
+	return &CloudRetryStageExecutionOperation{
+		profile: profile,
+		region:  region,
+		clients: factory,
+	}
+}
+
+// Name returns the operation's name.
+func (o *CloudRetryStageExecutionOperation) Name() string {
+	return "Retry Stage Execution"
+}
+
+// Description returns the operation's description.
+func (o *CloudRetryStageExecutionOperation) Description() string {
+	return "Retry a Failed Pipeline Stage"
+}
+
+// IsUIVisible returns whether this operation should be visible in the UI.
+// Stages are retried from the pipeline stages view.
+func (o *CloudRetryStageExecutionOperation) IsUIVisible() bool {
+	return false
+}
+
+// Execute executes the operation with the given parameters.
+func (o *CloudRetryStageExecutionOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	pipelineName, ok := params["pipeline_name"].(string)
+	if !ok {
+		return nil, fmt.Errorf("pipeline_name parameter is required")
+	}
+
+	stageName, ok := params["stage_name"].(string)
+	if !ok {
+		return nil, fmt.Errorf("stage_name parameter is required")
+	}
+
+	executionID, ok := params["execution_id"].(string)
+	if !ok {
+		return nil, fmt.Errorf("execution_id parameter is required")
+	}
+
+	retryMode, _ := params["retry_mode"].(string)
+	if retryMode == "" {
+		retryMode = cloud.RetryModeFailedActions
+	}
+
+	return nil, o.RetryStageExecution(ctx, pipelineName, stageName, executionID, retryMode)
+}
+
+// RetryStageExecution retries the failed or all actions of a stage in a pipeline execution.
+func (o *CloudRetryStageExecutionOperation) RetryStageExecution(ctx context.Context, pipelineName, stageName, executionID, retryMode string) error {
+	var mode cpTypes.StageRetryMode
+	switch retryMode {
+	case cloud.RetryModeFailedActions:
+		mode = cpTypes.StageRetryModeFailedActions
+	case cloud.RetryModeAllActions:
+		mode = cpTypes.StageRetryModeAllActions
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidRetryMode, retryMode)
+	}
+
+	// Get the shared AWS SDK client
+	client, err := o.clients.CodePipeline(ctx, o.profile, o.region)
+	if err != nil {
+		return fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	_, err = client.RetryStageExecution(ctx, &codepipeline.RetryStageExecutionInput{
+		PipelineName:        aws.String(pipelineName),
+		StageName:           aws.String(stageName),
+		PipelineExecutionId: aws.String(executionID),
+		RetryMode:           mode,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to retry stage execution: %w", err)
+	}
+
+	return nil
+}
+
+// CloudApprovalsInboxOperation represents an operation to manage pending approvals
+// across several profiles and regions.
+type CloudApprovalsInboxOperation struct {
+	profile string
+	region  string
+	clients clients.Factory
+}
+
+// NewCloudApprovalsInboxOperation creates a new approvals inbox operation.
+func NewCloudApprovalsInboxOperation(profile, region string, factory clients.Factory) *CloudApprovalsInboxOperation {
+	return &CloudApprovalsInboxOperation{
+		profile: profile,
+		region:  region,
+		clients: factory,
+	}
+}
+
+// Name returns the operation's name.
+func (o *CloudApprovalsInboxOperation) Name() string {
+	return "Approvals Inbox"
+}
+
+// Description returns the operation's description.
+func (o *CloudApprovalsInboxOperation) Description() string {
+	return "Manage Approvals Across Accounts"
+}
+
+// IsUIVisible returns whether this operation should be visible in the UI.
+func (o *CloudApprovalsInboxOperation) IsUIVisible() bool {
+	return true
+}
+
+// Execute executes the operation with the given parameters.
+func (o *CloudApprovalsInboxOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	targets, ok := params["targets"].([]cloud.ApprovalTarget)
+	if !ok {
+		targets = []cloud.ApprovalTarget{{Profile: o.profile, Region: o.region}}
+	}
+	return o.GetPendingApprovals(ctx, targets)
+}
+
+// inboxPipeline is a pipeline of an approvals inbox target.
+type inboxPipeline struct {
+	target    cloud.ApprovalTarget
+	operation *CloudManualApprovalOperation
+	client    clients.CodePipelineAPI
+	name      string
+}
+
+// GetPendingApprovals returns the pending manual approval actions of all targets. The
+// pipelines of at most maxConcurrentApprovalTargets targets are listed at a time, then
+// the pipelines of all targets are read from one pool of workers, so the calls in
+// flight do not grow with the number of targets.
+func (o *CloudApprovalsInboxOperation) GetPendingApprovals(ctx context.Context, targets []cloud.ApprovalTarget) ([]cloud.ApprovalAction, error) {
+	targetPipelines := make([][]inboxPipeline, len(targets))
+	errs := make([]error, len(targets))
+
+	// The listings of the targets overlap, so only the pipeline reads report progress
+	listCtx := cloud.WithProgress(ctx, nil)
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, maxConcurrentApprovalTargets)
+	for i, target := range targets {
+		wg.Add(1)
+		go func(i int, target cloud.ApprovalTarget) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			operation := NewCloudManualApprovalOperation(target.Profile, target.Region, o.clients)
+			client, err := o.clients.CodePipelineReader(listCtx, target.Profile, target.Region)
+			if err != nil {
+				errs[i] = &cloud.ApprovalTargetError{Target: target, Err: fmt.Errorf("failed to load AWS config: %w", err)}
+				return
+			}
+
+			pipelines, err := listCloudPipelines(listCtx, client)
+			if err != nil {
+				errs[i] = &cloud.ApprovalTargetError{Target: target, Err: err}
+				return
+			}
+			for _, pipeline := range pipelines {
+				targetPipelines[i] = append(targetPipelines[i], inboxPipeline{
+					target:    target,
+					operation: operation,
+					client:    client,
+					name:      aws.ToString(pipeline.Name),
+				})
+			}
+		}(i, target)
+	}
+	wg.Wait()
+
+	var pipelines []inboxPipeline
+	for _, result := range targetPipelines {
+		pipelines = append(pipelines, result...)
+	}
+
+	// Keep the approvals of the pipelines that loaded when others failed, reporting
+	// each failed pipeline under its target
+	results := make([][]cloud.ApprovalAction, len(pipelines))
+	pipelineErrs := make([]error, len(pipelines))
+	runCloudFetches(ctx, len(pipelines), func(i int) {
+		pipeline := pipelines[i]
+		approvals, err := pipeline.operation.getPipelineApprovals(ctx, pipeline.client, pipeline.name)
+		if err != nil {
+			pipelineErrs[i] = &cloud.ApprovalTargetError{
+				Target: pipeline.target,
+				Err:    &cloud.PipelineError{Pipeline: pipeline.name, Err: err},
+			}
+		}
+		results[i] = approvals
+	})
+
+	var approvals []cloud.ApprovalAction
+	for _, result := range results {
+		approvals = append(approvals, result...)
+	}
+	errs = append(errs, pipelineErrs...)
+
+	// Keep the approvals of the same account and region together
+	sort.SliceStable(approvals, func(i, j int) bool {
+		if approvals[i].AccountID != approvals[j].AccountID {
+			return approvals[i].AccountID < approvals[j].AccountID
+		}
+		return approvals[i].Region < approvals[j].Region
+	})
+
+	return approvals, errors.Join(errs...)
+}
+
+// ApproveAction approves or rejects an approval action in the profile and region it was found in.
+func (o *CloudApprovalsInboxOperation) ApproveAction(ctx context.Context, action cloud.ApprovalAction, approved bool, comment string) error {
+	profile, region := action.Profile, action.Region
+	if profile == "" || region == "" {
+		profile, region = o.profile, o.region
+	}
+	return NewCloudManualApprovalOperation(profile, region, o.clients).ApproveAction(ctx, action, approved, comment)
+}
+
+// CloudStageTransitionOperation represents an operation to enable or disable the
+// inbound transition of a stage.
+type CloudStageTransitionOperation struct {
+	profile string
+	region  string
+	clients clients.Factory
+}
+
+// NewCloudStageTransitionOperation creates a new stage transition operation.
+func NewCloudStageTransitionOperation(profile, region string, factory clients.Factory) *CloudStageTransitionOperation {
+	return &CloudStageTransitionOperation{
+		profile: profile,
+		region:  region,
+		clients: factory,
+	}
+}
+
+// Name returns the operation's name.
+func (o *CloudStageTransitionOperation) Name() string {
+	return "Stage Transition"
+}
+
+// Description returns the operation's description.
+func (o *CloudStageTransitionOperation) Description() string {
+	return "Enable or Disable a Stage Transition"
+}
+
+// IsUIVisible returns whether this operation should be visible in the UI.
+// Transitions are toggled from the pipeline stages view.
+func (o *CloudStageTransitionOperation) IsUIVisible() bool {
+	return false
+}
+
+// Execute executes the operation with the given parameters.
+func (o *CloudStageTransitionOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	pipelineName, ok := params["pipeline_name"].(string)
+	if !ok {
+		return nil, fmt.Errorf("pipeline_name parameter is required")
+	}
+
+	stageName, ok := params["stage_name"].(string)
+	if !ok {
+		return nil, fmt.Errorf("stage_name parameter is required")
+	}
+
+	enable, _ := params["enable"].(bool)
+	if enable {
+		return nil, o.EnableStageTransition(ctx, pipelineName, stageName)
+	}
+
+	reason, _ := params["reason"].(string)
+	return nil, o.DisableStageTransition(ctx, pipelineName, stageName, reason)
+}
+
+// EnableStageTransition enables the inbound transition of a stage.
+func (o *CloudStageTransitionOperation) EnableStageTransition(ctx context.Context, pipelineName, stageName string) error {
+	// Get the shared AWS SDK client
+	client, err := o.clients.CodePipeline(ctx, o.profile, o.region)
+	if err != nil {
+		return fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	_, err = client.EnableStageTransition(ctx, &codepipeline.EnableStageTransitionInput{
+		PipelineName:   aws.String(pipelineName),
+		StageName:      aws.String(stageName),
+		TransitionType: cpTypes.StageTransitionTypeInbound,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to enable stage transition: %w", err)
+	}
+
+	return nil
+}
+
+// DisableStageTransition disables the inbound transition of a stage.
+func (o *CloudStageTransitionOperation) DisableStageTransition(ctx context.Context, pipelineName, stageName, reason string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrDisableReasonRequired
+	}
+	if len(reason) > maxDisableReasonLength {
+		return fmt.Errorf("%w: %d characters, at most %d are allowed", ErrDisableReasonTooLong, len(reason), maxDisableReasonLength)
+	}
+	if !disableReasonPattern.MatchString(reason) {
+		return fmt.Errorf("%w: only letters, digits, spaces and !@().*?- are allowed", ErrInvalidDisableReason)
+	}
+
+	// Get the shared AWS SDK client
+	client, err := o.clients.CodePipeline(ctx, o.profile, o.region)
+	if err != nil {
+		return fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	_, err = client.DisableStageTransition(ctx, &codepipeline.DisableStageTransitionInput{
+		PipelineName:   aws.String(pipelineName),
+		StageName:      aws.String(stageName),
+		TransitionType: cpTypes.StageTransitionTypeInbound,
+		Reason:         aws.String(reason),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to disable stage transition: %w", err)
+	}
+
+	return nil
+}
+
+// CloudRollbackStageOperation represents an operation to roll a stage back to a
+// previous successful execution.
+type CloudRollbackStageOperation struct {
+	profile string
+	region  string
+	clients clients.Factory
+}
+
+// NewCloudRollbackStageOperation creates a new rollback stage operation.
+func NewCloudRollbackStageOperation(profile, region string, factory clients.Factory) *CloudRollbackStageOperation {
+	return &CloudRollbackStageOperation{
+		profile: profile,
+		region:  region,
+		clients: factory,
+	}
+}
+
+// Name returns the operation's name.
+func (o *CloudRollbackStageOperation) Name() string {
+	return "Rollback Stage"
+}
+
+// Description returns the operation's description.
+func (o *CloudRollbackStageOperation) Description() string {
+	return "Roll a Stage Back to a Previous Execution"
+}
+
+// IsUIVisible returns whether this operation should be visible in the UI.
+// Stages are rolled back from the pipeline stages view.
+func (o *CloudRollbackStageOperation) IsUIVisible() bool {
+	return false
+}
+
+// Execute executes the operation with the given parameters.
+func (o *CloudRollbackStageOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	pipelineName, ok := params["pipeline_name"].(string)
+	if !ok {
+		return nil, fmt.Errorf("pipeline_name parameter is required")
+	}
+
+	stageName, ok := params["stage_name"].(string)
+	if !ok {
+		return nil, fmt.Errorf("stage_name parameter is required")
+	}
+
+	// Without a target execution, list the executions the stage can be rolled back to
+	targetExecutionID, ok := params["target_execution_id"].(string)
+	if !ok {
+		nextToken, _ := params["next_token"].(string)
+		executions, _, err := o.GetRollbackTargets(ctx, pipelineName, stageName, nextToken)
+		return executions, err
+	}
+
+	return o.RollbackStage(ctx, pipelineName, stageName, targetExecutionID)
+}
+
+// GetRollbackTargets returns a page of the executions that succeeded in the stage, most
+// recent first, and the token of the next page.
+func (o *CloudRollbackStageOperation) GetRollbackTargets(ctx context.Context, pipelineName, stageName, nextToken string) ([]cloud.PipelineExecution, string, error) {
+	// Get the shared AWS SDK client
+	client, err := o.clients.CodePipeline(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	input := &codepipeline.ListPipelineExecutionsInput{
+		PipelineName: aws.String(pipelineName),
+		MaxResults:   aws.Int32(executionHistoryPageSize),
+		Filter: &cpTypes.PipelineExecutionFilter{
+			SucceededInStage: &cpTypes.SucceededInStageFilter{
+				StageName: aws.String(stageName),
+			},
+		},
+	}
+	if nextToken != "" {
+		input.NextToken = aws.String(nextToken)
+	}
+
+	output, err := client.ListPipelineExecutions(ctx, input)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list rollback targets: %w", err)
+	}
+
+	executions := make([]cloud.PipelineExecution, 0, len(output.PipelineExecutionSummaries))
+	for _, summary := range output.PipelineExecutionSummaries {
+		executions = append(executions, convertCloudPipelineExecution(summary))
+	}
+
+	return executions, aws.ToString(output.NextToken), nil
+}
+
+// RollbackStage rolls the stage back to the target execution and returns the ID of
+// the execution running the rollback.
+func (o *CloudRollbackStageOperation) RollbackStage(ctx context.Context, pipelineName, stageName, targetExecutionID string) (string, error) {
+	// Get the shared AWS SDK client
+	client, err := o.clients.CodePipeline(ctx, o.profile, o.region)
+	if err != nil {
+		return "", fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	output, err := client.RollbackStage(ctx, &codepipeline.RollbackStageInput{
+		PipelineName:              aws.String(pipelineName),
+		StageName:                 aws.String(stageName),
+		TargetPipelineExecutionId: aws.String(targetExecutionID),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to roll back stage: %w", err)
+	}
+
+	return aws.ToString(output.PipelineExecutionId), nil
+}
+
+// CloudOverrideStageConditionOperation represents an operation to override the failed
+// conditions of a stage.
+type CloudOverrideStageConditionOperation struct {
+	profile string
+	region  string
+	clients clients.Factory
+}
+
+// NewCloudOverrideStageConditionOperation creates a new override stage condition operation.
+func NewCloudOverrideStageConditionOperation(profile, region string, factory clients.Factory) *CloudOverrideStageConditionOperation {
+	return &CloudOverrideStageConditionOperation{
+		profile: profile,
+		region:  region,
+		clients: factory,
+	}
+}
+
+// Name returns the operation's name.
+func (o *CloudOverrideStageConditionOperation) Name() string {
+	return "Override Stage Condition"
+}
+
+// Description returns the operation's description.
+func (o *CloudOverrideStageConditionOperation) Description() string {
+	return "Override a Failed Stage Condition"
+}
+
+// IsUIVisible returns whether this operation should be visible in the UI.
+// Conditions are overridden from the pipeline stages view.
+func (o *CloudOverrideStageConditionOperation) IsUIVisible() bool {
+	return false
+}
+
+// Execute executes the operation with the given parameters.
+func (o *CloudOverrideStageConditionOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	pipelineName, ok := params["pipeline_name"].(string)
+	if !ok {
+		return nil, fmt.Errorf("pipeline_name parameter is required")
+	}
+
+	stageName, ok := params["stage_name"].(string)
+	if !ok {
+		return nil, fmt.Errorf("stage_name parameter is required")
+	}
+
+	executionID, ok := params["execution_id"].(string)
+	if !ok {
+		return nil, fmt.Errorf("execution_id parameter is required")
+	}
+
+	conditionType, ok := params["condition_type"].(string)
+	if !ok {
+		return nil, fmt.Errorf("condition_type parameter is required")
+	}
+
+	return nil, o.OverrideStageCondition(ctx, pipelineName, stageName, executionID, conditionType)
+}
+
+// OverrideStageCondition skips the failed before-entry or on-success conditions of a
+// stage in a pipeline execution.
+func (o *CloudOverrideStageConditionOperation) OverrideStageCondition(ctx context.Context, pipelineName, stageName, executionID, conditionType string) error {
+	var condition cpTypes.ConditionType
+	switch conditionType {
+	case cloud.ConditionTypeBeforeEntry:
+		condition = cpTypes.ConditionTypeBeforeEntry
+	case cloud.ConditionTypeOnSuccess:
+		condition = cpTypes.ConditionTypeOnSuccess
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidConditionType, conditionType)
+	}
+
+	// Get the shared AWS SDK client
+	client, err := o.clients.CodePipeline(ctx, o.profile, o.region)
+	if err != nil {
+		return fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	_, err = client.OverrideStageCondition(ctx, &codepipeline.OverrideStageConditionInput{
+		PipelineName:        aws.String(pipelineName),
+		StageName:           aws.String(stageName),
+		PipelineExecutionId: aws.String(executionID),
+		ConditionType:       condition,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to override stage condition: %w", err)
+	}
+
+	return nil
+}
+
+// CloudPipelineDefinitionOperation represents an operation to view the definition of a pipeline.
+type CloudPipelineDefinitionOperation struct {
+	profile string
+	region  string
+	clients clients.Factory
+}
+
+// NewCloudPipelineDefinitionOperation creates a new pipeline definition operation.
+func NewCloudPipelineDefinitionOperation(profile, region string, factory clients.Factory) *CloudPipelineDefinitionOperation {
+	return &CloudPipelineDefinitionOperation{
+		profile: profile,
+		region:  region,
+		clients: factory,
+	}
+}
+
+// Name returns the operation's name.
+func (o *CloudPipelineDefinitionOperation) Name() string {
+	return "Pipeline Definition"
+}
+
+// Description returns the operation's description.
+func (o *CloudPipelineDefinitionOperation) Description() string {
+	return "View, Export and Diff a Pipeline Definition"
+}
+
+// IsUIVisible returns whether this operation should be visible in the UI.
+func (o *CloudPipelineDefinitionOperation) IsUIVisible() bool {
+	return true
+}
+
+// Execute executes the operation with the given parameters.
+func (o *CloudPipelineDefinitionOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	pipelineName, ok := params["pipeline_name"].(string)
+	if !ok {
+		return nil, fmt.Errorf("pipeline_name parameter is required")
+	}
+
+	return o.GetPipelineDefinition(ctx, pipelineName)
+}
+
+// GetPipelineDefinition returns the declared structure of a pipeline.
+func (o *CloudPipelineDefinitionOperation) GetPipelineDefinition(ctx context.Context, pipelineName string) (*cloud.PipelineDefinition, error) {
+	// Get the shared AWS SDK client
+	client, err := o.clients.CodePipeline(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	output, err := client.GetPipeline(ctx, &codepipeline.GetPipelineInput{
+		Name: aws.String(pipelineName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pipeline: %w", err)
+	}
+
+	return convertCloudPipelineDefinition(output.Pipeline), nil
+}
+
+// convertCloudPipelineDefinition converts a pipeline declaration to a pipeline definition.
+func convertCloudPipelineDefinition(pipeline *cpTypes.PipelineDeclaration) *cloud.PipelineDefinition {
+	if pipeline == nil {
+		return &cloud.PipelineDefinition{}
+	}
+
+	definition := &cloud.PipelineDefinition{
+		Name:          aws.ToString(pipeline.Name),
+		RoleArn:       aws.ToString(pipeline.RoleArn),
+		ArtifactStore: convertCloudArtifactStore(pipeline.ArtifactStore),
+		Stages:        make([]cloud.StageDefinition, 0, len(pipeline.Stages)),
+		Version:       aws.ToInt32(pipeline.Version),
+		ExecutionMode: string(pipeline.ExecutionMode),
+		PipelineType:  string(pipeline.PipelineType),
+	}
+
+	if len(pipeline.ArtifactStores) > 0 {
+		definition.ArtifactStores = make(map[string]cloud.ArtifactStoreDefinition, len(pipeline.ArtifactStores))
+		for region, store := range pipeline.ArtifactStores {
+			definition.ArtifactStores[region] = *convertCloudArtifactStore(&store)
+		}
+	}
+
+	for _, trigger := range pipeline.Triggers {
+		definition.Triggers = append(definition.Triggers, convertCloudTriggerDefinition(trigger))
+	}
+
+	for _, variable := range pipeline.Variables {
+		definition.Variables = append(definition.Variables, cloud.VariableDefinition{
+			Name:         aws.ToString(variable.Name),
+			DefaultValue: aws.ToString(variable.DefaultValue),
+			Description:  aws.ToString(variable.Description),
+		})
+	}
+
+	for _, stage := range pipeline.Stages {
+		definition.Stages = append(definition.Stages, convertCloudStageDefinition(stage))
+	}
+
+	return definition
+}
+
+// convertCloudArtifactStore converts an artifact store to its definition.
+func convertCloudArtifactStore(store *cpTypes.ArtifactStore) *cloud.ArtifactStoreDefinition {
+	if store == nil {
+		return nil
+	}
+
+	definition := &cloud.ArtifactStoreDefinition{
+		Type:     string(store.Type),
+		Location: aws.ToString(store.Location),
+	}
+	if store.EncryptionKey != nil {
+		definition.EncryptionKey = &cloud.EncryptionKeyDefinition{
+			ID:   aws.ToString(store.EncryptionKey.Id),
+			Type: string(store.EncryptionKey.Type),
+		}
+	}
+	return definition
+}
+
+// convertCloudTriggerDefinition converts a trigger declaration to a trigger definition.
+func convertCloudTriggerDefinition(trigger cpTypes.PipelineTriggerDeclaration) cloud.TriggerDefinition {
+	definition := cloud.TriggerDefinition{ProviderType: string(trigger.ProviderType)}
+	if trigger.GitConfiguration == nil {
+		return definition
+	}
+
+	git := &cloud.GitConfigurationDefinition{
+		SourceActionName: aws.ToString(trigger.GitConfiguration.SourceActionName),
+	}
+	for _, push := range trigger.GitConfiguration.Push {
+		var filter cloud.GitPushFilterDefinition
+		if push.Tags != nil {
+			filter.Tags = &cloud.GitFilterCriteria{Includes: push.Tags.Includes, Excludes: push.Tags.Excludes}
+		}
+		if push.Branches != nil {
+			filter.Branches = &cloud.GitFilterCriteria{Includes: push.Branches.Includes, Excludes: push.Branches.Excludes}
+		}
+		if push.FilePaths != nil {
+			filter.FilePaths = &cloud.GitFilterCriteria{Includes: push.FilePaths.Includes, Excludes: push.FilePaths.Excludes}
+		}
+		git.Push = append(git.Push, filter)
+	}
+	for _, pullRequest := range trigger.GitConfiguration.PullRequest {
+		var filter cloud.GitPullRequestFilterDefinition
+		for _, event := range pullRequest.Events {
+			filter.Events = append(filter.Events, string(event))
+		}
+		if pullRequest.Branches != nil {
+			filter.Branches = &cloud.GitFilterCriteria{Includes: pullRequest.Branches.Includes, Excludes: pullRequest.Branches.Excludes}
+		}
+		if pullRequest.FilePaths != nil {
+			filter.FilePaths = &cloud.GitFilterCriteria{Includes: pullRequest.FilePaths.Includes, Excludes: pullRequest.FilePaths.Excludes}
+		}
+		git.PullRequest = append(git.PullRequest, filter)
+	}
+	definition.GitConfiguration = git
+	return definition
+}
+
+// convertCloudStageDefinition converts a stage declaration to a stage definition.
+func convertCloudStageDefinition(stage cpTypes.StageDeclaration) cloud.StageDefinition {
+	definition := cloud.StageDefinition{
+		Name:    aws.ToString(stage.Name),
+		Actions: make([]cloud.ActionDefinition, 0, len(stage.Actions)),
+	}
+
+	for _, blocker := range stage.Blockers {
+		definition.Blockers = append(definition.Blockers, cloud.BlockerDefinition{
+			Name: aws.ToString(blocker.Name),
+			Type: string(blocker.Type),
+		})
+	}
+	for _, action := range stage.Actions {
+		definition.Actions = append(definition.Actions, convertCloudActionDefinition(action))
+	}
+
+	if stage.OnFailure != nil {
+		definition.OnFailure = &cloud.FailureConditionsDefinition{
+			Result:     string(stage.OnFailure.Result),
+			Conditions: convertCloudConditionDefinitions(stage.OnFailure.Conditions),
+		}
+		if stage.OnFailure.RetryConfiguration != nil {
+			definition.OnFailure.RetryConfiguration = &cloud.RetryConfigurationDefinition{
+				RetryMode: string(stage.OnFailure.RetryConfiguration.RetryMode),
+			}
+		}
+	}
+	if stage.OnSuccess != nil {
+		definition.OnSuccess = &cloud.ConditionsDefinition{Conditions: convertCloudConditionDefinitions(stage.OnSuccess.Conditions)}
+	}
+	if stage.BeforeEntry != nil {
+		definition.BeforeEntry = &cloud.ConditionsDefinition{Conditions: convertCloudConditionDefinitions(stage.BeforeEntry.Conditions)}
+	}
+
+	return definition
+}
+
+// convertCloudConditionDefinitions converts the conditions of a stage to condition definitions.
+func convertCloudConditionDefinitions(conditions []cpTypes.Condition) []cloud.ConditionDefinition {
+	definitions := make([]cloud.ConditionDefinition, 0, len(conditions))
+	for _, condition := range conditions {
+		definition := cloud.ConditionDefinition{Result: string(condition.Result)}
+		for _, rule := range condition.Rules {
+			ruleDefinition := cloud.RuleDefinition{
+				Name:             aws.ToString(rule.Name),
+				Configuration:    rule.Configuration,
+				Commands:         rule.Commands,
+				InputArtifacts:   convertCloudInputArtifacts(rule.InputArtifacts),
+				Region:           aws.ToString(rule.Region),
+				RoleArn:          aws.ToString(rule.RoleArn),
+				TimeoutInMinutes: aws.ToInt32(rule.TimeoutInMinutes),
+			}
+			if rule.RuleTypeId != nil {
+				ruleDefinition.RuleTypeID = cloud.RuleTypeID{
+					Category: string(rule.RuleTypeId.Category),
+					Owner:    string(rule.RuleTypeId.Owner),
+					Provider: aws.ToString(rule.RuleTypeId.Provider),
+					Version:  aws.ToString(rule.RuleTypeId.Version),
+				}
+			}
+			definition.Rules = append(definition.Rules, ruleDefinition)
+		}
+		definitions = append(definitions, definition)
+	}
+	return definitions
+}
+
+// convertCloudActionDefinition converts an action declaration to an action definition.
+func convertCloudActionDefinition(action cpTypes.ActionDeclaration) cloud.ActionDefinition {
+	definition := cloud.ActionDefinition{
+		Name:             aws.ToString(action.Name),
+		RunOrder:         aws.ToInt32(action.RunOrder),
+		Configuration:    action.Configuration,
+		Commands:         action.Commands,
+		InputArtifacts:   convertCloudInputArtifacts(action.InputArtifacts),
+		OutputArtifacts:  make([]cloud.ArtifactDefinition, 0, len(action.OutputArtifacts)),
+		OutputVariables:  action.OutputVariables,
+		Region:           aws.ToString(action.Region),
+		Namespace:        aws.ToString(action.Namespace),
+		RoleArn:          aws.ToString(action.RoleArn),
+		TimeoutInMinutes: aws.ToInt32(action.TimeoutInMinutes),
+	}
+
+	if action.ActionTypeId != nil {
+		definition.ActionTypeID = cloud.ActionTypeID{
+			Category: string(action.ActionTypeId.Category),
+			Owner:    string(action.ActionTypeId.Owner),
+			Provider: aws.ToString(action.ActionTypeId.Provider),
+			Version:  aws.ToString(action.ActionTypeId.Version),
+		}
+	}
+
+	for _, artifact := range action.OutputArtifacts {
+		definition.OutputArtifacts = append(definition.OutputArtifacts, cloud.ArtifactDefinition{
+			Name:  aws.ToString(artifact.Name),
+			Files: artifact.Files,
+		})
+	}
+	for _, variable := range action.EnvironmentVariables {
+		definition.EnvironmentVariables = append(definition.EnvironmentVariables, cloud.EnvironmentVariableDefinition{
+			Name:  aws.ToString(variable.Name),
+			Value: aws.ToString(variable.Value),
+		})
+	}
+
+	return definition
+}
+
+// convertCloudInputArtifacts converts the input artifacts of an action or rule.
+func convertCloudInputArtifacts(artifacts []cpTypes.InputArtifact) []cloud.ArtifactDefinition {
+	definitions := make([]cloud.ArtifactDefinition, 0, len(artifacts))
+	for _, artifact := range artifacts {
+		definitions = append(definitions, cloud.ArtifactDefinition{Name: aws.ToString(artifact.Name)})
+	}
+	return definitions
+}
+
+// CloudExecutionWatchOperation represents an operation to follow the progress of a pipeline execution.
+type CloudExecutionWatchOperation struct {
+	profile string
+	region  string
+	clients clients.Factory
+}
+
+// NewCloudExecutionWatchOperation creates a new pipeline execution watch operation.
+func NewCloudExecutionWatchOperation(profile, region string, factory clients.Factory) *CloudExecutionWatchOperation {
+	return &CloudExecutionWatchOperation{
+		profile: profile,
+		region:  region,
+		clients: factory,
+	}
+}
+
+// Name returns the operation's name.
+func (o *CloudExecutionWatchOperation) Name() string {
+	return "Execution Watch"
+}
+
+// Description returns the operation's description.
+func (o *CloudExecutionWatchOperation) Description() string {
+	return "Follow the Progress of a Pipeline Execution"
+}
+
+// IsUIVisible returns whether this operation should be visible in the UI.
+// The watch is started from the pipeline start flow.
+func (o *CloudExecutionWatchOperation) IsUIVisible() bool {
+	return false
+}
+
+// Execute executes the operation with the given parameters.
+func (o *CloudExecutionWatchOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	pipelineName, ok := params["pipeline_name"].(string)
+	if !ok {
+		return nil, fmt.Errorf("pipeline_name parameter is required")
+	}
+
+	executionID, ok := params["execution_id"].(string)
+	if !ok {
+		return nil, fmt.Errorf("execution_id parameter is required")
+	}
+
+	return o.GetExecutionProgress(ctx, pipelineName, executionID, nil)
+}
+
+// GetExecutionProgress returns the status of a pipeline execution and of each stage and action in it.
+// Polls given the previous progress of the execution reuse its pipeline structure and finished stages.
+func (o *CloudExecutionWatchOperation) GetExecutionProgress(ctx context.Context, pipelineName, executionID string, previous *cloud.ExecutionProgress) (*cloud.ExecutionProgress, error) {
+	// Get the shared AWS SDK client
+	client, err := o.clients.CodePipeline(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	executionResp, err := client.GetPipelineExecution(ctx, &codepipeline.GetPipelineExecutionInput{
+		PipelineName:        aws.String(pipelineName),
+		PipelineExecutionId: aws.String(executionID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pipeline execution: %w", err)
+	}
+
+	stateResp, err := client.GetPipelineState(ctx, &codepipeline.GetPipelineStateInput{
+		Name: aws.String(pipelineName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pipeline state: %w", err)
+	}
+
+	progress := &cloud.ExecutionProgress{
+		ExecutionID: executionID,
+		Status:      "Unknown",
+	}
+	var pipelineVersion *int32
+	if execution := executionResp.PipelineExecution; execution != nil {
+		progress.Status = string(execution.Status)
+		progress.StatusSummary = aws.ToString(execution.StatusSummary)
+		pipelineVersion = execution.PipelineVersion
+	}
+	if previous != nil && previous.ExecutionID != executionID {
+		previous = nil
+	}
+
+	// The stages and actions in declared order come from the pipeline version the
+	// execution runs with, which does not change while it runs
+	if previous != nil && previous.Definition != nil {
+		progress.Definition = previous.Definition
+	} else {
+		pipelineResp, err := client.GetPipeline(ctx, &codepipeline.GetPipelineInput{
+			Name:    aws.String(pipelineName),
+			Version: pipelineVersion,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pipeline details: %w", err)
+		}
+		progress.Definition = convertCloudPipelineDefinition(pipelineResp.Pipeline)
+	}
+
+	previousStages := make(map[string]cloud.StageProgress)
+	if previous != nil {
+		for _, stage := range previous.Stages {
+			previousStages[stage.Name] = stage
+		}
+	}
+
+	// Stages keep their last progress until they run or change status, so the action
+	// executions are only listed while a stage of the execution is in progress
+	stageStates := buildCloudStageStateMap(stateResp.StageStates)
+	var actionExecutions []cpTypes.ActionExecutionDetail
+	listed := false
+	for _, stage := range progress.Definition.Stages {
+		status := getCloudStageExecutionStatus(stageStates[stage.Name], executionID)
+		if last, ok := previousStages[stage.Name]; ok && last.Status == status && !isCloudStageActive(status) {
+			progress.Stages = append(progress.Stages, last)
+			continue
+		}
+		if !listed {
+			if actionExecutions, err = listCloudActionExecutions(ctx, client, pipelineName, executionID); err != nil {
+				return nil, err
+			}
+			listed = true
+		}
+		progress.Stages = append(progress.Stages, buildCloudStageProgress(stage, status, actionExecutions))
+	}
+
+	return progress, nil
+}
+
+// listCloudActionExecutions lists the action executions of a pipeline execution. The
+// pipeline state only holds the latest action executions, which may belong to an
+// earlier pipeline execution, so the actions are taken from the execution itself.
+func listCloudActionExecutions(ctx context.Context, client clients.CodePipelineAPI, pipelineName, executionID string) ([]cpTypes.ActionExecutionDetail, error) {
+	var actionExecutions []cpTypes.ActionExecutionDetail
+	var nextToken *string
+	for {
+		actionsResp, err := client.ListActionExecutions(ctx, &codepipeline.ListActionExecutionsInput{
+			PipelineName: aws.String(pipelineName),
+			Filter: &cpTypes.ActionExecutionFilter{
+				PipelineExecutionId: aws.String(executionID),
+			},
+			NextToken: nextToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list action executions: %w", err)
+		}
+		actionExecutions = append(actionExecutions, actionsResp.ActionExecutionDetails...)
+		if actionsResp.NextToken == nil {
+			return actionExecutions, nil
+		}
+		nextToken = actionsResp.NextToken
+	}
+}
+
+// getCloudStageExecutionStatus returns the status of a stage in a pipeline execution,
+// which is pending until the latest execution of the stage is the pipeline execution.
+func getCloudStageExecutionStatus(state cpTypes.StageState, executionID string) string {
+	if state.LatestExecution != nil && aws.ToString(state.LatestExecution.PipelineExecutionId) == executionID {
+		return string(state.LatestExecution.Status)
+	}
+	return cloud.ExecutionStatusPending
+}
+
+// buildCloudStageProgress builds the progress of a stage in a pipeline execution from
+// the stage status and the action executions of the pipeline execution.
+func buildCloudStageProgress(stage cloud.StageDefinition, status string, actionExecutions []cpTypes.ActionExecutionDetail) cloud.StageProgress {
+	progress := cloud.StageProgress{
+		Name:   stage.Name,
+		Status: status,
+	}
+
+	// Use the latest run of each action, as failed actions may have been retried
+	latest := make(map[string]cpTypes.ActionExecutionDetail)
+	var lastUpdate time.Time
+	for _, detail := range actionExecutions {
+		if aws.ToString(detail.StageName) != stage.Name {
+			continue
+		}
+		if detail.StartTime != nil && (progress.StartTime.IsZero() || detail.StartTime.Before(progress.StartTime)) {
+			progress.StartTime = *detail.StartTime
+		}
+		if detail.LastUpdateTime != nil && detail.LastUpdateTime.After(lastUpdate) {
+			lastUpdate = *detail.LastUpdateTime
+		}
+		name := aws.ToString(detail.ActionName)
+		if previous, ok := latest[name]; !ok || aws.ToTime(detail.StartTime).After(aws.ToTime(previous.StartTime)) {
+			latest[name] = detail
+		}
+	}
+	if !isCloudStageRunning(progress.Status) {
+		progress.EndTime = lastUpdate
+	}
+
+	for _, declaration := range stage.Actions {
+		action := cloud.ActionStatus{
+			Name:             declaration.Name,
+			Category:         declaration.ActionTypeID.Category,
+			Provider:         declaration.ActionTypeID.Provider,
+			Status:           cloud.ExecutionStatusPending,
+			LastStatusChange: "N/A",
+			RunOrder:         1,
+		}
+		if declaration.RunOrder > 0 {
+			action.RunOrder = int(declaration.RunOrder)
+		}
+		if detail, ok := latest[declaration.Name]; ok {
+			action.Status = string(detail.Status)
+			action.LastStatusChange = formatCloudTime(detail.LastUpdateTime)
+		}
+		progress.Actions = append(progress.Actions, action)
+	}
+
+	return progress
+}
+
+// isCloudStageActive checks if a stage status means the stage is running its actions.
+func isCloudStageActive(status string) bool {
+	return status == string(cpTypes.StageExecutionStatusInProgress) || status == string(cpTypes.StageExecutionStatusStopping)
+}
+
+// isCloudStageRunning checks if a stage status means the stage has not stopped running.
+func isCloudStageRunning(status string) bool {
+	switch status {
+	case cloud.ExecutionStatusPending,
+		string(cpTypes.StageExecutionStatusInProgress),
+		string(cpTypes.StageExecutionStatusStopping):
+		return true
+	default:
+		return false
+	}
+}
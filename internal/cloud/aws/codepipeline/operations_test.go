@@ -0,0 +1,987 @@
+package codepipeline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/cloud/aws/clients"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/codepipeline"
+	cpTypes "github.com/aws/aws-sdk-go-v2/service/codepipeline/types"
+)
+
+// approvalRequestedAt is when the manual approvals of the fake pipelines started waiting
+var approvalRequestedAt = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// fakeCodePipeline is a CodePipeline client of an account whose pipelines are listed
+// one page at a time. Each pipeline gets its source from a connection and waits for
+// the manual approval of its latest execution.
+type fakeCodePipeline struct {
+	clients.CodePipelineAPI
+	pages [][]string
+	// stateErrs are the errors reading the state of the pipelines, by pipeline name
+	stateErrs map[string]error
+	listErr   error
+	startErr  error
+	// executionErr is the error reading the executions of the pipelines
+	executionErr error
+	// listTokens are the next tokens the pipelines were listed with, in order
+	listTokens []string
+	approval   *codepipeline.PutApprovalResultInput
+	started    *codepipeline.StartPipelineExecutionInput
+	// approveStatus overrides the status of the approval stage when set
+	approveStatus cpTypes.StageExecutionStatus
+
+	mu sync.Mutex
+	// pipelineVersions are the versions the pipelines were read at, in order
+	pipelineVersions []int32
+	// actionListings counts the pages of action executions listed
+	actionListings int
+}
+
+func (f *fakeCodePipeline) ListPipelines(ctx context.Context, params *codepipeline.ListPipelinesInput, optFns ...func(*codepipeline.Options)) (*codepipeline.ListPipelinesOutput, error) {
+	if f.listErr != nil {
+		return nil, f.listErr
+	}
+	page := 0
+	if params.NextToken != nil {
+		page, _ = strconv.Atoi(*params.NextToken)
+	}
+	f.listTokens = append(f.listTokens, aws.ToString(params.NextToken))
+
+	output := &codepipeline.ListPipelinesOutput{}
+	if len(f.pages) == 0 {
+		return output, nil
+	}
+	for _, name := range f.pages[page] {
+		output.Pipelines = append(output.Pipelines, cpTypes.PipelineSummary{Name: aws.String(name)})
+	}
+	if page+1 < len(f.pages) {
+		output.NextToken = aws.String(strconv.Itoa(page + 1))
+	}
+	return output, nil
+}
+
+func (f *fakeCodePipeline) GetPipeline(ctx context.Context, params *codepipeline.GetPipelineInput, optFns ...func(*codepipeline.Options)) (*codepipeline.GetPipelineOutput, error) {
+	name := aws.ToString(params.Name)
+	f.mu.Lock()
+	f.pipelineVersions = append(f.pipelineVersions, aws.ToInt32(params.Version))
+	f.mu.Unlock()
+	return &codepipeline.GetPipelineOutput{
+		Metadata: &cpTypes.PipelineMetadata{PipelineArn: aws.String("arn:aws:codepipeline:us-east-1:111111111111:" + name)},
+		Pipeline: &cpTypes.PipelineDeclaration{
+			Name: aws.String(name),
+			Stages: []cpTypes.StageDeclaration{
+				{Name: aws.String("Source"), Actions: []cpTypes.ActionDeclaration{{
+					Name:            aws.String("Source"),
+					ActionTypeId:    &cpTypes.ActionTypeId{Category: cpTypes.ActionCategorySource, Provider: aws.String("CodeStarSourceConnection")},
+					OutputArtifacts: []cpTypes.OutputArtifact{{Name: aws.String("SourceArtifact")}},
+				}}},
+				{Name: aws.String("Approve"), Actions: []cpTypes.ActionDeclaration{{
+					Name:          aws.String("Manual"),
+					ActionTypeId:  &cpTypes.ActionTypeId{Category: cpTypes.ActionCategoryApproval, Provider: aws.String("Manual")},
+					Configuration: map[string]string{"CustomData": "Check the staging deploy"},
+				}}},
+			},
+			Variables: []cpTypes.PipelineVariableDeclaration{{Name: aws.String("ENV"), DefaultValue: aws.String("dev")}},
+		},
+	}, nil
+}
+
+func (f *fakeCodePipeline) GetPipelineState(ctx context.Context, params *codepipeline.GetPipelineStateInput, optFns ...func(*codepipeline.Options)) (*codepipeline.GetPipelineStateOutput, error) {
+	name := aws.ToString(params.Name)
+	if err := f.stateErrs[name]; err != nil {
+		return nil, err
+	}
+	executionID := aws.String("exec-" + name)
+	approveStatus := cpTypes.StageExecutionStatusInProgress
+	if f.approveStatus != "" {
+		approveStatus = f.approveStatus
+	}
+	return &codepipeline.GetPipelineStateOutput{
+		PipelineName: aws.String(name),
+		StageStates: []cpTypes.StageState{
+			{
+				StageName:       aws.String("Source"),
+				LatestExecution: &cpTypes.StageExecution{PipelineExecutionId: executionID, Status: cpTypes.StageExecutionStatusSucceeded},
+				ActionStates: []cpTypes.ActionState{{
+					ActionName:      aws.String("Source"),
+					LatestExecution: &cpTypes.ActionExecution{Status: cpTypes.ActionExecutionStatusSucceeded, LastStatusChange: aws.Time(approvalRequestedAt.Add(-time.Minute))},
+				}},
+			},
+			{
+				StageName:       aws.String("Approve"),
+				LatestExecution: &cpTypes.StageExecution{PipelineExecutionId: executionID, Status: approveStatus},
+				ActionStates: []cpTypes.ActionState{{
+					ActionName: aws.String("Manual"),
+					LatestExecution: &cpTypes.ActionExecution{
+						Status:           cpTypes.ActionExecutionStatusInProgress,
+						Token:            aws.String("token-" + name),
+						LastStatusChange: aws.Time(approvalRequestedAt),
+					},
+				}},
+			},
+		},
+	}, nil
+}
+
+func (f *fakeCodePipeline) GetPipelineExecution(ctx context.Context, params *codepipeline.GetPipelineExecutionInput, optFns ...func(*codepipeline.Options)) (*codepipeline.GetPipelineExecutionOutput, error) {
+	if f.executionErr != nil {
+		return nil, f.executionErr
+	}
+	return &codepipeline.GetPipelineExecutionOutput{
+		PipelineExecution: &cpTypes.PipelineExecution{
+			PipelineExecutionId: params.PipelineExecutionId,
+			PipelineVersion:     aws.Int32(3),
+			Status:              cpTypes.PipelineExecutionStatusInProgress,
+			ArtifactRevisions: []cpTypes.ArtifactRevision{{
+				Name:            aws.String("SourceArtifact"),
+				RevisionId:      aws.String("a1b2c3d4e5f6"),
+				RevisionSummary: aws.String(`{"ProviderType":"GitHub","CommitMessage":"Fix the build"}`),
+			}},
+		},
+	}, nil
+}
+
+func (f *fakeCodePipeline) ListActionExecutions(ctx context.Context, params *codepipeline.ListActionExecutionsInput, optFns ...func(*codepipeline.Options)) (*codepipeline.ListActionExecutionsOutput, error) {
+	f.mu.Lock()
+	f.actionListings++
+	f.mu.Unlock()
+	executionID := params.Filter.PipelineExecutionId
+	approveStatus := cpTypes.ActionExecutionStatusInProgress
+	if f.approveStatus == cpTypes.StageExecutionStatusSucceeded {
+		approveStatus = cpTypes.ActionExecutionStatusSucceeded
+	}
+	return &codepipeline.ListActionExecutionsOutput{
+		ActionExecutionDetails: []cpTypes.ActionExecutionDetail{
+			{
+				PipelineExecutionId: executionID,
+				StageName:           aws.String("Source"),
+				ActionName:          aws.String("Source"),
+				Status:              cpTypes.ActionExecutionStatusSucceeded,
+				StartTime:           aws.Time(approvalRequestedAt.Add(-2 * time.Minute)),
+				LastUpdateTime:      aws.Time(approvalRequestedAt.Add(-time.Minute)),
+			},
+			{
+				PipelineExecutionId: executionID,
+				StageName:           aws.String("Approve"),
+				ActionName:          aws.String("Manual"),
+				Status:              approveStatus,
+				StartTime:           aws.Time(approvalRequestedAt),
+				LastUpdateTime:      aws.Time(approvalRequestedAt),
+			},
+		},
+	}, nil
+}
+
+func (f *fakeCodePipeline) PutApprovalResult(ctx context.Context, params *codepipeline.PutApprovalResultInput, optFns ...func(*codepipeline.Options)) (*codepipeline.PutApprovalResultOutput, error) {
+	f.approval = params
+	return &codepipeline.PutApprovalResultOutput{}, nil
+}
+
+func (f *fakeCodePipeline) StartPipelineExecution(ctx context.Context, params *codepipeline.StartPipelineExecutionInput, optFns ...func(*codepipeline.Options)) (*codepipeline.StartPipelineExecutionOutput, error) {
+	if f.startErr != nil {
+		return nil, f.startErr
+	}
+	f.started = params
+	return &codepipeline.StartPipelineExecutionOutput{PipelineExecutionId: aws.String("exec-new")}, nil
+}
+
+// fakeFactory is a client factory returning a fake CodePipeline client
+type fakeFactory struct {
+	clients.Factory
+	codePipeline *fakeCodePipeline
+}
+
+func (f *fakeFactory) CodePipeline(ctx context.Context, profile, region string) (clients.CodePipelineAPI, error) {
+	return f.codePipeline, nil
+}
+
+func (f *fakeFactory) CodePipelineReader(ctx context.Context, profile, region string) (clients.CodePipelineAPI, error) {
+	return f.codePipeline, nil
+}
+
+// TestGetPendingApprovals tests finding the approvals waiting in every pipeline, with
+// the revisions of their executions
+func TestGetPendingApprovals(t *testing.T) {
+	factory := &fakeFactory{codePipeline: &fakeCodePipeline{pages: [][]string{{"build"}, {"deploy"}}}}
+
+	approvals, err := NewCloudManualApprovalOperation("dev", "us-east-1", factory).GetPendingApprovals(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(approvals) != 2 || approvals[0].PipelineName != "build" || approvals[1].PipelineName != "deploy" {
+		t.Fatalf("Expected the approvals of both pipelines in order, got %+v", approvals)
+	}
+
+	approval := approvals[1]
+	if approval.StageName != "Approve" || approval.ActionName != "Manual" || approval.Token != "token-deploy" ||
+		approval.ExecutionID != "exec-deploy" || approval.CustomData != "Check the staging deploy" {
+		t.Errorf("Expected the details of the waiting action, got %+v", approval)
+	}
+	if approval.Profile != "dev" || approval.Region != "us-east-1" || approval.AccountID != "111111111111" {
+		t.Errorf("Expected the account of the pipeline, got %s/%s/%s", approval.Profile, approval.Region, approval.AccountID)
+	}
+	if !approval.ExpiresAt.Equal(approvalRequestedAt.Add(approvalTokenLifetime)) {
+		t.Errorf("Expected the approval to expire after %v, got %v", approvalTokenLifetime, approval.ExpiresAt)
+	}
+	if len(approval.SourceRevisions) != 1 || approval.SourceRevisions[0].ActionName != "Source" ||
+		approval.SourceRevisions[0].RevisionSummary != "Fix the build" {
+		t.Errorf("Expected the source revision of the execution, got %+v", approval.SourceRevisions)
+	}
+}
+
+// TestGetPendingApprovalsWithoutRevisions tests that approvals are still listed when
+// the executions they wait in cannot be read
+func TestGetPendingApprovalsWithoutRevisions(t *testing.T) {
+	factory := &fakeFactory{codePipeline: &fakeCodePipeline{
+		pages:        [][]string{{"build", "deploy"}},
+		executionErr: errors.New("AccessDeniedException: not authorized to perform codepipeline:GetPipelineExecution"),
+	}}
+
+	approvals, err := NewCloudManualApprovalOperation("dev", "us-east-1", factory).GetPendingApprovals(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(approvals) != 2 || approvals[0].Token != "token-build" || approvals[1].Token != "token-deploy" {
+		t.Fatalf("Expected the approvals of both pipelines, got %+v", approvals)
+	}
+	for _, approval := range approvals {
+		if len(approval.SourceRevisions) != 0 {
+			t.Errorf("Expected no source revisions for %s, got %+v", approval.PipelineName, approval.SourceRevisions)
+		}
+	}
+}
+
+// TestApproveAction tests approving and rejecting an approval with a comment
+func TestApproveAction(t *testing.T) {
+	client := &fakeCodePipeline{}
+	operation := NewCloudManualApprovalOperation("dev", "us-east-1", &fakeFactory{codePipeline: client})
+	action := cloud.ApprovalAction{PipelineName: "deploy", StageName: "Approve", ActionName: "Manual", Token: "token-deploy"}
+
+	tests := []struct {
+		name     string
+		approved bool
+		expected cpTypes.ApprovalStatus
+	}{
+		{"approve", true, cpTypes.ApprovalStatusApproved},
+		{"reject", false, cpTypes.ApprovalStatusRejected},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := operation.ApproveAction(context.Background(), action, tt.approved, "Looks good"); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			input := client.approval
+			if input.Result.Status != tt.expected || aws.ToString(input.Result.Summary) != "Looks good" {
+				t.Errorf("Expected a %s result with the comment, got %+v", tt.expected, input.Result)
+			}
+			if aws.ToString(input.Token) != "token-deploy" || aws.ToString(input.PipelineName) != "deploy" ||
+				aws.ToString(input.StageName) != "Approve" || aws.ToString(input.ActionName) != "Manual" {
+				t.Errorf("Expected the result for the approval action, got %+v", input)
+			}
+		})
+	}
+}
+
+// TestStartPipelineExecution tests starting a pipeline with a source revision and
+// variables checked against its declaration
+func TestStartPipelineExecution(t *testing.T) {
+	client := &fakeCodePipeline{}
+	operation := NewCloudStartPipelineOperation("dev", "us-east-1", &fakeFactory{codePipeline: client})
+	ctx := context.Background()
+
+	executionID, err := operation.StartPipelineExecution(ctx, "deploy",
+		[]cloud.SourceRevision{{RevisionValue: " a1b2c3d "}},
+		[]cloud.PipelineVariable{{Name: "ENV", Value: "prod"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if executionID != "exec-new" {
+		t.Errorf("Expected the ID of the new execution, got %q", executionID)
+	}
+
+	input := client.started
+	if len(input.SourceRevisions) != 1 || aws.ToString(input.SourceRevisions[0].ActionName) != "Source" ||
+		input.SourceRevisions[0].RevisionType != cpTypes.SourceRevisionTypeCommitId ||
+		aws.ToString(input.SourceRevisions[0].RevisionValue) != "a1b2c3d" {
+		t.Errorf("Expected the commit to override the only source action, got %+v", input.SourceRevisions)
+	}
+	if len(input.Variables) != 1 || aws.ToString(input.Variables[0].Name) != "ENV" || aws.ToString(input.Variables[0].Value) != "prod" {
+		t.Errorf("Expected the ENV variable, got %+v", input.Variables)
+	}
+	if len(client.pipelineVersions) != 1 {
+		t.Errorf("Expected the declaration to be fetched once, got %d calls", len(client.pipelineVersions))
+	}
+
+	// Without overrides the pipeline starts from its latest source
+	if _, err := operation.StartPipelineExecution(ctx, "deploy", nil, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if client.started.SourceRevisions != nil || client.started.Variables != nil {
+		t.Errorf("Expected no overrides, got %+v", client.started)
+	}
+
+	client.started = nil
+	_, err = operation.StartPipelineExecution(ctx, "deploy", nil, []cloud.PipelineVariable{{Name: "REGION", Value: "eu-west-1"}})
+	if !errors.Is(err, ErrPipelineVariableUnknown) || client.started != nil {
+		t.Errorf("Expected an undeclared variable not to start the pipeline, got %v", err)
+	}
+
+	client.startErr = errors.New("pipeline is disabled")
+	if _, err := operation.StartPipelineExecution(ctx, "deploy", nil, nil); !errors.Is(err, client.startErr) {
+		t.Errorf("Expected the client error to be wrapped, got %v", err)
+	}
+}
+
+// TestGetPipelineStatus tests reading the stages and actions of every pipeline
+func TestGetPipelineStatus(t *testing.T) {
+	factory := &fakeFactory{codePipeline: &fakeCodePipeline{pages: [][]string{{"build", "deploy"}}}}
+
+	statuses, err := NewCloudPipelineStatusOperation("dev", "us-east-1", factory).GetPipelineStatus(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(statuses) != 2 || statuses[0].Name != "build" || statuses[1].Name != "deploy" {
+		t.Fatalf("Expected the status of both pipelines in order, got %+v", statuses)
+	}
+
+	stages := statuses[1].Stages
+	if len(stages) != 2 || stages[0].Name != "Source" || stages[0].Status != "Succeeded" || stages[1].Status != "InProgress" {
+		t.Fatalf("Expected the Source and Approve stages, got %+v", stages)
+	}
+	if stages[1].ExecutionID != "exec-deploy" || stages[1].LastUpdated != "Jan 01 12:00:00 UTC" {
+		t.Errorf("Expected the latest execution of the stage, got %+v", stages[1])
+	}
+	actions := stages[1].Actions
+	if len(actions) != 1 || actions[0].Name != "Manual" || actions[0].Category != "Approval" || actions[0].Status != "InProgress" {
+		t.Errorf("Expected the declared approval action, got %+v", actions)
+	}
+}
+
+// TestListCloudPipelines tests listing every page of pipelines in order while reporting
+// how many were listed
+func TestListCloudPipelines(t *testing.T) {
+	client := &fakeCodePipeline{pages: [][]string{{"alpha", "bravo"}, {"charlie"}, {"delta", "echo"}}}
+	var reported []cloud.Progress
+	ctx := cloud.WithProgress(context.Background(), func(progress cloud.Progress) {
+		reported = append(reported, progress)
+	})
+
+	pipelines, err := listCloudPipelines(ctx, client)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var names []string
+	for _, pipeline := range pipelines {
+		names = append(names, aws.ToString(pipeline.Name))
+	}
+	if strings.Join(names, ",") != "alpha,bravo,charlie,delta,echo" {
+		t.Errorf("Expected the pipelines of every page in order, got %v", names)
+	}
+	if strings.Join(client.listTokens, ",") != ",1,2" {
+		t.Errorf("Expected each page to be listed once with the token of the previous one, got %q", client.listTokens)
+	}
+	expected := []cloud.Progress{{Loaded: 2}, {Loaded: 3}, {Loaded: 5}}
+	if len(reported) != len(expected) {
+		t.Fatalf("Expected %v to be reported, got %v", expected, reported)
+	}
+	for i := range expected {
+		if reported[i] != expected[i] {
+			t.Errorf("Expected %v to be reported, got %v", expected, reported)
+			break
+		}
+	}
+
+	// A failed page fails the listing
+	client = &fakeCodePipeline{listErr: errors.New("throttled")}
+	if _, err := listCloudPipelines(context.Background(), client); !errors.Is(err, client.listErr) {
+		t.Errorf("Expected the client error to be wrapped, got %v", err)
+	}
+}
+
+// pipelineSummaries returns the summaries of pipelines named pipeline-0 to pipeline-n-1
+func pipelineSummaries(n int) []cpTypes.PipelineSummary {
+	pipelines := make([]cpTypes.PipelineSummary, n)
+	for i := range pipelines {
+		pipelines[i] = cpTypes.PipelineSummary{Name: aws.String(fmt.Sprintf("pipeline-%d", i))}
+	}
+	return pipelines
+}
+
+// pipelineNames returns the names of n pipelines
+func pipelineNames(n int) []string {
+	names := make([]string, n)
+	for i, pipeline := range pipelineSummaries(n) {
+		names[i] = aws.ToString(pipeline.Name)
+	}
+	return names
+}
+
+// TestForEachCloudPipelineConcurrency tests fetching every pipeline from a bounded pool
+// of workers while reporting each pipeline loaded
+func TestForEachCloudPipelineConcurrency(t *testing.T) {
+	pipelines := pipelineSummaries(50)
+	var reported []cloud.Progress
+	ctx := cloud.WithProgress(context.Background(), func(progress cloud.Progress) {
+		reported = append(reported, progress)
+	})
+
+	baseline := runtime.NumGoroutine()
+	var mu sync.Mutex
+	active, maxActive, maxGoroutines := 0, 0, 0
+	fetched := make(map[string]int)
+	err := forEachCloudPipeline(ctx, pipelines, func(i int, pipelineName string) error {
+		mu.Lock()
+		active++
+		maxActive = max(maxActive, active)
+		maxGoroutines = max(maxGoroutines, runtime.NumGoroutine())
+		fetched[pipelineName] = i
+		mu.Unlock()
+
+		time.Sleep(time.Millisecond)
+
+		mu.Lock()
+		active--
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(fetched) != len(pipelines) {
+		t.Fatalf("Expected every pipeline to be fetched once, got %d", len(fetched))
+	}
+	for i, pipeline := range pipelines {
+		if index, ok := fetched[aws.ToString(pipeline.Name)]; !ok || index != i {
+			t.Errorf("Expected %s to be fetched with index %d, got %d", aws.ToString(pipeline.Name), i, index)
+		}
+	}
+	if maxActive > maxConcurrentPipelineFetches {
+		t.Errorf("Expected at most %d fetches at a time, got %d", maxConcurrentPipelineFetches, maxActive)
+	}
+	if workers := maxGoroutines - baseline; workers > maxConcurrentPipelineFetches {
+		t.Errorf("Expected at most %d workers, got %d goroutines", maxConcurrentPipelineFetches, workers)
+	}
+	if len(reported) != len(pipelines) || reported[len(reported)-1] != (cloud.Progress{Loaded: 50, Total: 50}) {
+		t.Errorf("Expected each pipeline loaded to be reported, got %v", reported)
+	}
+	for i, progress := range reported {
+		if progress.Loaded != i+1 {
+			t.Errorf("Expected the loaded counts in order, got %v", reported)
+			break
+		}
+	}
+
+	// No pipelines need no workers
+	if err := forEachCloudPipeline(ctx, nil, nil); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+// TestForEachCloudPipelineErrors tests joining the errors of the pipelines that failed
+// in the order they are listed, keeping the results of the others in order
+func TestForEachCloudPipelineErrors(t *testing.T) {
+	pipelines := pipelineSummaries(10)
+	denied := errors.New("access denied")
+
+	// Later pipelines finish first, so the results arrive out of order
+	results := make([]string, len(pipelines))
+	err := forEachCloudPipeline(context.Background(), pipelines, func(i int, pipelineName string) error {
+		time.Sleep(time.Duration(len(pipelines)-i) * time.Millisecond)
+		if i == 3 || i == 7 {
+			return denied
+		}
+		results[i] = pipelineName
+		return nil
+	})
+
+	for i, result := range results {
+		expected := fmt.Sprintf("pipeline-%d", i)
+		if i == 3 || i == 7 {
+			expected = ""
+		}
+		if result != expected {
+			t.Errorf("Expected result %d to be %q, got %q", i, expected, result)
+		}
+	}
+
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("Expected the joined errors of the failed pipelines, got %v", err)
+	}
+	var failed []string
+	for _, err := range joined.Unwrap() {
+		var pipelineErr *cloud.PipelineError
+		if !errors.As(err, &pipelineErr) || !errors.Is(pipelineErr, denied) {
+			t.Fatalf("Expected a *cloud.PipelineError wrapping the fetch error, got %v", err)
+		}
+		failed = append(failed, pipelineErr.Pipeline)
+	}
+	if strings.Join(failed, ",") != "pipeline-3,pipeline-7" {
+		t.Errorf("Expected the failed pipelines in order, got %v", failed)
+	}
+
+	// The status of the pipelines that loaded is kept
+	client := &fakeCodePipeline{pages: [][]string{{"build", "deploy", "release"}}, stateErrs: map[string]error{"deploy": denied}}
+	statuses, err := NewCloudPipelineStatusOperation("dev", "us-east-1", &fakeFactory{codePipeline: client}).GetPipelineStatus(context.Background())
+	var pipelineErr *cloud.PipelineError
+	if !errors.As(err, &pipelineErr) || pipelineErr.Pipeline != "deploy" {
+		t.Errorf("Expected the deploy pipeline to fail, got %v", err)
+	}
+	if len(statuses) != 2 || statuses[0].Name != "build" || statuses[1].Name != "release" {
+		t.Errorf("Expected the status of the other pipelines in order, got %+v", statuses)
+	}
+}
+
+// inboxFactory is a client factory returning the fake CodePipeline client of each
+// profile and region, counting the pipelines read at the same time across all clients
+type inboxFactory struct {
+	clients.Factory
+	codePipelines map[string]*fakeCodePipeline
+
+	mu                sync.Mutex
+	active, maxActive int
+}
+
+func (f *inboxFactory) CodePipelineReader(ctx context.Context, profile, region string) (clients.CodePipelineAPI, error) {
+	return &countingCodePipeline{fakeCodePipeline: f.codePipelines[profile+"/"+region], factory: f}, nil
+}
+
+// countingCodePipeline is a fake CodePipeline client reporting its pipeline reads to its factory
+type countingCodePipeline struct {
+	*fakeCodePipeline
+	factory *inboxFactory
+}
+
+func (c *countingCodePipeline) GetPipeline(ctx context.Context, params *codepipeline.GetPipelineInput, optFns ...func(*codepipeline.Options)) (*codepipeline.GetPipelineOutput, error) {
+	c.factory.mu.Lock()
+	c.factory.active++
+	c.factory.maxActive = max(c.factory.maxActive, c.factory.active)
+	c.factory.mu.Unlock()
+
+	time.Sleep(time.Millisecond)
+
+	c.factory.mu.Lock()
+	c.factory.active--
+	c.factory.mu.Unlock()
+	return c.fakeCodePipeline.GetPipeline(ctx, params, optFns...)
+}
+
+// TestGetInboxPendingApprovals tests reading the pipelines of every target from one
+// bounded pool, reporting a failed pipeline under its target without failing the target
+func TestGetInboxPendingApprovals(t *testing.T) {
+	denied := errors.New("access denied")
+	factory := &inboxFactory{codePipelines: map[string]*fakeCodePipeline{
+		"dev/us-east-1":  {pages: [][]string{{"build", "deploy"}}, stateErrs: map[string]error{"deploy": denied}},
+		"dev/eu-west-1":  {pages: [][]string{{"release"}}},
+		"prod/us-east-1": {listErr: denied},
+	}}
+	var targets []cloud.ApprovalTarget
+	for i := range 8 {
+		profile := fmt.Sprintf("team-%d", i)
+		factory.codePipelines[profile+"/us-east-1"] = &fakeCodePipeline{pages: [][]string{pipelineNames(8)}}
+		targets = append(targets, cloud.ApprovalTarget{Profile: profile, Region: "us-east-1"})
+	}
+	targets = append(targets,
+		cloud.ApprovalTarget{Profile: "dev", Region: "us-east-1"},
+		cloud.ApprovalTarget{Profile: "dev", Region: "eu-west-1"},
+		cloud.ApprovalTarget{Profile: "prod", Region: "us-east-1"},
+	)
+
+	approvals, err := NewCloudApprovalsInboxOperation("dev", "us-east-1", factory).GetPendingApprovals(context.Background(), targets)
+	if len(approvals) != 8*8+2 {
+		t.Errorf("Expected the approvals of every pipeline that loaded, got %d", len(approvals))
+	}
+	if factory.maxActive > maxConcurrentPipelineFetches {
+		t.Errorf("Expected at most %d pipeline reads at a time across targets, got %d", maxConcurrentPipelineFetches, factory.maxActive)
+	}
+
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok || len(joined.Unwrap()) != 2 {
+		t.Fatalf("Expected the failed target and the failed pipeline, got %v", err)
+	}
+	var failed []string
+	for _, err := range joined.Unwrap() {
+		var targetErr *cloud.ApprovalTargetError
+		if !errors.As(err, &targetErr) || !errors.Is(err, denied) {
+			t.Fatalf("Expected a *cloud.ApprovalTargetError wrapping the error, got %v", err)
+		}
+		failure := targetErr.Target.Profile + "/" + targetErr.Target.Region
+		var pipelineErr *cloud.PipelineError
+		if errors.As(targetErr.Err, &pipelineErr) {
+			failure += "/" + pipelineErr.Pipeline
+		}
+		failed = append(failed, failure)
+	}
+	if strings.Join(failed, ",") != "prod/us-east-1,dev/us-east-1/deploy" {
+		t.Errorf("Expected the failed target and the failed pipeline under its target, got %v", failed)
+	}
+}
+
+// TestFindCloudSourceActions tests mapping the providers of source actions to the
+// revision type they accept
+func TestFindCloudSourceActions(t *testing.T) {
+	action := func(name string, category cpTypes.ActionCategory, provider string) cpTypes.ActionDeclaration {
+		return cpTypes.ActionDeclaration{
+			Name:         aws.String(name),
+			ActionTypeId: &cpTypes.ActionTypeId{Category: category, Provider: aws.String(provider)},
+		}
+	}
+	stages := []cpTypes.StageDeclaration{
+		{Name: aws.String("Source"), Actions: []cpTypes.ActionDeclaration{
+			action("Repo", cpTypes.ActionCategorySource, "CodeCommit"),
+			action("App", cpTypes.ActionCategorySource, "CodeStarSourceConnection"),
+			action("Artifacts", cpTypes.ActionCategorySource, "S3"),
+			action("Image", cpTypes.ActionCategorySource, "ECR"),
+			action("Legacy", cpTypes.ActionCategorySource, "GitHub"),
+		}},
+		{Name: aws.String("Build"), Actions: []cpTypes.ActionDeclaration{
+			action("Build", cpTypes.ActionCategoryBuild, "CodeBuild"),
+		}},
+	}
+
+	expected := []cloud.SourceAction{
+		{Name: "Repo", Provider: "CodeCommit", RevisionType: cloud.RevisionTypeCommitID},
+		{Name: "App", Provider: "CodeStarSourceConnection", RevisionType: cloud.RevisionTypeCommitID},
+		{Name: "Artifacts", Provider: "S3", RevisionType: cloud.RevisionTypeS3ObjectVersionID},
+		{Name: "Image", Provider: "ECR", RevisionType: cloud.RevisionTypeImageDigest},
+		{Name: "Legacy", Provider: "GitHub", RevisionType: ""},
+	}
+	sourceActions := findCloudSourceActions(stages)
+	if len(sourceActions) != len(expected) {
+		t.Fatalf("Expected %d source actions, got %+v", len(expected), sourceActions)
+	}
+	for i := range expected {
+		if sourceActions[i] != expected[i] {
+			t.Errorf("Expected %+v, got %+v", expected[i], sourceActions[i])
+		}
+	}
+}
+
+// TestBuildCloudSourceRevisionOverrides tests validating source revisions against the
+// source actions of a pipeline
+func TestBuildCloudSourceRevisionOverrides(t *testing.T) {
+	sourceActions := []cloud.SourceAction{
+		{Name: "App", Provider: "CodeStarSourceConnection", RevisionType: cloud.RevisionTypeCommitID},
+		{Name: "Artifacts", Provider: "S3", RevisionType: cloud.RevisionTypeS3ObjectVersionID},
+		{Name: "Image", Provider: "ECR", RevisionType: cloud.RevisionTypeImageDigest},
+		{Name: "Legacy", Provider: "GitHub"},
+	}
+	digest := "sha256:" + strings.Repeat("ab", 32)
+
+	tests := []struct {
+		name         string
+		revisions    []cloud.SourceRevision
+		expectedType cpTypes.SourceRevisionType
+		expectedErr  error
+	}{
+		{
+			name:         "commit ID defaults to the provider type",
+			revisions:    []cloud.SourceRevision{{ActionName: "App", RevisionValue: "a1b2c3d"}},
+			expectedType: cpTypes.SourceRevisionTypeCommitId,
+		},
+		{
+			name:         "S3 object version ID",
+			revisions:    []cloud.SourceRevision{{ActionName: "Artifacts", RevisionType: cloud.RevisionTypeS3ObjectVersionID, RevisionValue: "3HL4kqtJlcpXroDTDmJ-rmSpXd3dIbrHY"}},
+			expectedType: cpTypes.SourceRevisionTypeS3ObjectVersionId,
+		},
+		{
+			name:         "image digest",
+			revisions:    []cloud.SourceRevision{{ActionName: "Image", RevisionValue: digest}},
+			expectedType: cpTypes.SourceRevisionTypeImageDigest,
+		},
+		{
+			name:        "unknown action",
+			revisions:   []cloud.SourceRevision{{ActionName: "Missing", RevisionValue: "a1b2c3d"}},
+			expectedErr: ErrSourceActionNotFound,
+		},
+		{
+			name:        "action required with several source actions",
+			revisions:   []cloud.SourceRevision{{RevisionValue: "a1b2c3d"}},
+			expectedErr: ErrSourceActionRequired,
+		},
+		{
+			name:        "provider without overrides",
+			revisions:   []cloud.SourceRevision{{ActionName: "Legacy", RevisionValue: "a1b2c3d"}},
+			expectedErr: ErrUnsupportedSourceAction,
+		},
+		{
+			name:        "image digest for a repository",
+			revisions:   []cloud.SourceRevision{{ActionName: "App", RevisionType: cloud.RevisionTypeImageDigest, RevisionValue: digest}},
+			expectedErr: ErrRevisionTypeMismatch,
+		},
+		{
+			name:        "malformed commit ID",
+			revisions:   []cloud.SourceRevision{{ActionName: "App", RevisionValue: "main"}},
+			expectedErr: ErrInvalidSourceRevision,
+		},
+		{
+			name: "duplicate override",
+			revisions: []cloud.SourceRevision{
+				{ActionName: "App", RevisionValue: "a1b2c3d"},
+				{ActionName: "App", RevisionValue: "e5f6a7b"},
+			},
+			expectedErr: ErrDuplicateSourceRevision,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			overrides, err := buildCloudSourceRevisionOverrides(sourceActions, tt.revisions)
+			if tt.expectedErr != nil {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Errorf("Expected %v, got %v", tt.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if len(overrides) != 1 || overrides[0].RevisionType != tt.expectedType ||
+				aws.ToString(overrides[0].ActionName) != tt.revisions[0].ActionName ||
+				aws.ToString(overrides[0].RevisionValue) != tt.revisions[0].RevisionValue {
+				t.Errorf("Expected a %s override of %s, got %+v", tt.expectedType, tt.revisions[0].ActionName, overrides)
+			}
+		})
+	}
+
+	// The only source action of a pipeline needs no name
+	overrides, err := buildCloudSourceRevisionOverrides(sourceActions[:1], []cloud.SourceRevision{{RevisionValue: "a1b2c3d"}})
+	if err != nil || len(overrides) != 1 || aws.ToString(overrides[0].ActionName) != "App" {
+		t.Errorf("Expected the only source action to be overridden, got %+v, %v", overrides, err)
+	}
+}
+
+// TestValidateCloudRevisionValue tests the format of the revision values of each type
+func TestValidateCloudRevisionValue(t *testing.T) {
+	tests := []struct {
+		name         string
+		revisionType string
+		value        string
+		valid        bool
+	}{
+		{"short commit ID", cloud.RevisionTypeCommitID, "a1b2c3d", true},
+		{"full commit ID", cloud.RevisionTypeCommitID, "0123456789abcdef0123456789abcdef01234567", true},
+		{"commit ID with spaces around", cloud.RevisionTypeCommitID, "  a1b2c3d\n", true},
+		{"too short commit ID", cloud.RevisionTypeCommitID, "a1b2c3", false},
+		{"branch name", cloud.RevisionTypeCommitID, "main", false},
+		{"image digest", cloud.RevisionTypeImageDigest, "sha256:" + strings.Repeat("0f", 32), true},
+		{"image tag", cloud.RevisionTypeImageDigest, "latest", false},
+		{"uppercase image digest", cloud.RevisionTypeImageDigest, "sha256:" + strings.Repeat("0F", 32), false},
+		{"S3 object version ID", cloud.RevisionTypeS3ObjectVersionID, "3HL4kqtJlcpXroDTDmJ-rmSpXd3dIbrHY", true},
+		{"S3 object version ID with a space", cloud.RevisionTypeS3ObjectVersionID, "3HL4 kqtJ", false},
+		{"empty value", cloud.RevisionTypeCommitID, "  ", false},
+		{"unknown revision type", "BRANCH", "main", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCloudRevisionValue(tt.revisionType, tt.value)
+			if tt.valid && err != nil {
+				t.Errorf("Expected %q to be valid, got %v", tt.value, err)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidSourceRevision) {
+				t.Errorf("Expected %q to be invalid, got %v", tt.value, err)
+			}
+		})
+	}
+}
+
+// TestConvertCloudPipelineDefinition tests that a definition keeps every part of a V2
+// pipeline, encoded as in the get-pipeline output of the AWS CLI
+func TestConvertCloudPipelineDefinition(t *testing.T) {
+	declaration := &cpTypes.PipelineDeclaration{
+		Name:    aws.String("deploy"),
+		RoleArn: aws.String("arn:aws:iam::111111111111:role/pipeline"),
+		ArtifactStores: map[string]cpTypes.ArtifactStore{
+			"us-east-1": {
+				Type:          cpTypes.ArtifactStoreTypeS3,
+				Location:      aws.String("artifacts-us-east-1"),
+				EncryptionKey: &cpTypes.EncryptionKey{Id: aws.String("alias/artifacts"), Type: cpTypes.EncryptionKeyTypeKms},
+			},
+			"eu-west-1": {Type: cpTypes.ArtifactStoreTypeS3, Location: aws.String("artifacts-eu-west-1")},
+		},
+		Version:       aws.Int32(7),
+		ExecutionMode: cpTypes.ExecutionModeQueued,
+		PipelineType:  cpTypes.PipelineTypeV2,
+		Triggers: []cpTypes.PipelineTriggerDeclaration{{
+			ProviderType: cpTypes.PipelineTriggerProviderTypeCodeStarSourceConnection,
+			GitConfiguration: &cpTypes.GitConfiguration{
+				SourceActionName: aws.String("Source"),
+				Push: []cpTypes.GitPushFilter{{
+					Branches:  &cpTypes.GitBranchFilterCriteria{Includes: []string{"main"}},
+					FilePaths: &cpTypes.GitFilePathFilterCriteria{Excludes: []string{"docs/**"}},
+				}},
+				PullRequest: []cpTypes.GitPullRequestFilter{{
+					Events:   []cpTypes.GitPullRequestEventType{cpTypes.GitPullRequestEventTypeOpen},
+					Branches: &cpTypes.GitBranchFilterCriteria{Includes: []string{"release/*"}},
+				}},
+			},
+		}},
+		Stages: []cpTypes.StageDeclaration{{
+			Name:     aws.String("Deploy"),
+			Blockers: []cpTypes.BlockerDeclaration{{Name: aws.String("Freeze"), Type: cpTypes.BlockerTypeSchedule}},
+			Actions: []cpTypes.ActionDeclaration{{
+				Name:             aws.String("Migrate"),
+				ActionTypeId:     &cpTypes.ActionTypeId{Category: cpTypes.ActionCategoryCompute, Owner: cpTypes.ActionOwnerAws, Provider: aws.String("Commands"), Version: aws.String("1")},
+				RunOrder:         aws.Int32(1),
+				Commands:         []string{"make migrate"},
+				InputArtifacts:   []cpTypes.InputArtifact{{Name: aws.String("SourceArtifact")}},
+				OutputArtifacts:  []cpTypes.OutputArtifact{{Name: aws.String("MigrateOutput"), Files: []string{"report.json"}}},
+				OutputVariables:  []string{"SCHEMA_VERSION"},
+				Namespace:        aws.String("MigrateVariables"),
+				Region:           aws.String("eu-west-1"),
+				TimeoutInMinutes: aws.Int32(30),
+				EnvironmentVariables: []cpTypes.EnvironmentVariable{
+					{Name: aws.String("STAGE"), Value: aws.String("prod")},
+				},
+			}},
+			OnFailure: &cpTypes.FailureConditions{
+				Result:             cpTypes.ResultRetry,
+				RetryConfiguration: &cpTypes.RetryConfiguration{RetryMode: cpTypes.StageRetryModeFailedActions},
+			},
+			BeforeEntry: &cpTypes.BeforeEntryConditions{Conditions: []cpTypes.Condition{{
+				Result: cpTypes.ResultFail,
+				Rules: []cpTypes.RuleDeclaration{{
+					Name:          aws.String("NoAlarms"),
+					RuleTypeId:    &cpTypes.RuleTypeId{Category: cpTypes.RuleCategoryRule, Owner: cpTypes.RuleOwnerAws, Provider: aws.String("CloudWatchAlarm"), Version: aws.String("1")},
+					Configuration: map[string]string{"AlarmName": "errors"},
+				}},
+			}}},
+		}},
+	}
+
+	expected := `{
+		"name": "deploy",
+		"roleArn": "arn:aws:iam::111111111111:role/pipeline",
+		"artifactStores": {
+			"us-east-1": {"type": "S3", "location": "artifacts-us-east-1", "encryptionKey": {"id": "alias/artifacts", "type": "KMS"}},
+			"eu-west-1": {"type": "S3", "location": "artifacts-eu-west-1"}
+		},
+		"stages": [{
+			"name": "Deploy",
+			"blockers": [{"name": "Freeze", "type": "Schedule"}],
+			"actions": [{
+				"name": "Migrate",
+				"actionTypeId": {"category": "Compute", "owner": "AWS", "provider": "Commands", "version": "1"},
+				"runOrder": 1,
+				"commands": ["make migrate"],
+				"outputArtifacts": [{"name": "MigrateOutput", "files": ["report.json"]}],
+				"inputArtifacts": [{"name": "SourceArtifact"}],
+				"outputVariables": ["SCHEMA_VERSION"],
+				"region": "eu-west-1",
+				"namespace": "MigrateVariables",
+				"timeoutInMinutes": 30,
+				"environmentVariables": [{"name": "STAGE", "value": "prod"}]
+			}],
+			"onFailure": {"result": "RETRY", "retryConfiguration": {"retryMode": "FAILED_ACTIONS"}},
+			"beforeEntry": {"conditions": [{
+				"result": "FAIL",
+				"rules": [{
+					"name": "NoAlarms",
+					"ruleTypeId": {"category": "Rule", "owner": "AWS", "provider": "CloudWatchAlarm", "version": "1"},
+					"configuration": {"AlarmName": "errors"}
+				}]
+			}]}
+		}],
+		"version": 7,
+		"executionMode": "QUEUED",
+		"pipelineType": "V2",
+		"triggers": [{
+			"providerType": "CodeStarSourceConnection",
+			"gitConfiguration": {
+				"sourceActionName": "Source",
+				"push": [{"branches": {"includes": ["main"]}, "filePaths": {"excludes": ["docs/**"]}}],
+				"pullRequest": [{"events": ["OPEN"], "branches": {"includes": ["release/*"]}}]
+			}
+		}]
+	}`
+
+	data, err := json.Marshal(convertCloudPipelineDefinition(declaration))
+	if err != nil {
+		t.Fatalf("Expected the definition to encode, got %v", err)
+	}
+	var actual, want interface{}
+	if err := json.Unmarshal(data, &actual); err != nil {
+		t.Fatalf("Expected the definition to decode, got %v", err)
+	}
+	if err := json.Unmarshal([]byte(expected), &want); err != nil {
+		t.Fatalf("Expected valid JSON, got %v", err)
+	}
+	if !reflect.DeepEqual(actual, want) {
+		t.Errorf("Expected the get-pipeline structure, got\n%s", data)
+	}
+}
+
+// TestGetExecutionProgress tests polling a running execution, loading the pipeline
+// version it runs once and listing its actions only while a stage is in progress
+func TestGetExecutionProgress(t *testing.T) {
+	client := &fakeCodePipeline{}
+	operation := NewCloudExecutionWatchOperation("dev", "us-east-1", &fakeFactory{codePipeline: client})
+
+	progress, err := operation.GetExecutionProgress(context.Background(), "deploy", "exec-deploy", nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(client.pipelineVersions) != 1 || client.pipelineVersions[0] != 3 {
+		t.Errorf("Expected the pipeline to be read at the version of the execution, got %v", client.pipelineVersions)
+	}
+	if progress.Status != "InProgress" || len(progress.Stages) != 2 {
+		t.Fatalf("Expected the progress of both stages, got %+v", progress)
+	}
+	source, approve := progress.Stages[0], progress.Stages[1]
+	if source.Status != "Succeeded" || source.Actions[0].Status != "Succeeded" || source.EndTime.IsZero() {
+		t.Errorf("Expected the source stage to have finished, got %+v", source)
+	}
+	if approve.Status != "InProgress" || approve.Actions[0].Status != "InProgress" || approve.Actions[0].Category != "Approval" {
+		t.Errorf("Expected the approval stage to be in progress, got %+v", approve)
+	}
+
+	// Later polls reuse the pipeline structure and list the actions of the running stage
+	progress, err = operation.GetExecutionProgress(context.Background(), "deploy", "exec-deploy", progress)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(client.pipelineVersions) != 1 || client.actionListings != 2 {
+		t.Errorf("Expected only the actions to be listed again, got %d reads and %d listings", len(client.pipelineVersions), client.actionListings)
+	}
+
+	// A stage that just finished is listed once more for its final action statuses
+	client.approveStatus = cpTypes.StageExecutionStatusSucceeded
+	progress, err = operation.GetExecutionProgress(context.Background(), "deploy", "exec-deploy", progress)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if client.actionListings != 3 || progress.Stages[1].Status != "Succeeded" || progress.Stages[1].Actions[0].Status != "Succeeded" {
+		t.Errorf("Expected the finished approval stage, got %+v after %d listings", progress.Stages[1], client.actionListings)
+	}
+
+	// Once no stage is in progress, the stages keep their last progress
+	finished, err := operation.GetExecutionProgress(context.Background(), "deploy", "exec-deploy", progress)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if client.actionListings != 3 || !reflect.DeepEqual(finished.Stages, progress.Stages) {
+		t.Errorf("Expected the stages to be kept without listing the actions, got %d listings", client.actionListings)
+	}
+
+	// The progress of another execution is not reused
+	if _, err := operation.GetExecutionProgress(context.Background(), "deploy", "exec-other", progress); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(client.pipelineVersions) != 2 || client.actionListings != 4 {
+		t.Errorf("Expected another execution to be loaded in full, got %d reads and %d listings", len(client.pipelineVersions), client.actionListings)
+	}
+}
@@ -2,6 +2,7 @@ package codepipeline
 
 import (
 	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/cloud/aws/clients"
 )
 
 // Service represents the CodePipeline service.
@@ -12,7 +13,7 @@ type Service struct {
 }
 
 // NewService creates a new CodePipeline service.
-func NewService(profile, region string) *Service {
+func NewService(profile, region string, factory clients.Factory) *Service {
 	service := &Service{
 		profile:    profile,
 		region:     region,
@@ -20,8 +21,8 @@ func NewService(profile, region string) *Service {
 	}
 
 	// Register categories
-	service.categories = append(service.categories, NewWorkflowsCategory(profile, region))
-	service.categories = append(service.categories, NewInternalOperationsCategory(profile, region))
+	service.categories = append(service.categories, NewWorkflowsCategory(profile, region, factory))
+	service.categories = append(service.categories, NewInternalOperationsCategory(profile, region, factory))
 
 	return service
 }
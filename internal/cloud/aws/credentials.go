@@ -0,0 +1,129 @@
+package aws
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/credentials/processcreds"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud/aws/clients"
+)
+
+// Authentication config keys of the credential sources.
+const (
+	authConfigAccessKeyID          = "access_key_id"
+	authConfigSecretAccessKey      = "secret_access_key"
+	authConfigSessionToken         = "session_token"
+	authConfigCredentialProcess    = "credential_process"
+	authConfigRoleARN              = "role_arn"
+	authConfigWebIdentityTokenFile = "web_identity_token_file"
+)
+
+// Environment variables the credential sources read, as the AWS CLI and SDKs name them.
+const (
+	envAccessKeyID          = "AWS_ACCESS_KEY_ID"
+	envSecretAccessKey      = "AWS_SECRET_ACCESS_KEY"
+	envSessionToken         = "AWS_SESSION_TOKEN"
+	envRoleARN              = "AWS_ROLE_ARN"
+	envRoleSessionName      = "AWS_ROLE_SESSION_NAME"
+	envWebIdentityTokenFile = "AWS_WEB_IDENTITY_TOKEN_FILE"
+)
+
+// Credential source errors
+var (
+	ErrNoEnvironmentCredentials = errors.New("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY are not set")
+	ErrMissingAuthConfig        = errors.New("missing authentication config")
+)
+
+// isCredentialSource returns whether the authentication method signs with credentials
+// of its own rather than those of a profile of the shared config.
+func isCredentialSource(method string) bool {
+	switch method {
+	case AuthMethodEnvironment, AuthMethodStaticKeys, AuthMethodCredentialProcess, AuthMethodWebIdentity:
+		return true
+	default:
+		return false
+	}
+}
+
+// getCredentialSource returns the credentials of a credential source method from its
+// authentication config. Static keys are only kept in memory by the returned function.
+func getCredentialSource(method string, authConfig map[string]string) (clients.CredentialsFunc, error) {
+	switch method {
+	case AuthMethodEnvironment:
+		accessKeyID, secretAccessKey := os.Getenv(envAccessKeyID), os.Getenv(envSecretAccessKey)
+		if accessKeyID == "" || secretAccessKey == "" {
+			return nil, ErrNoEnvironmentCredentials
+		}
+		return staticCredentials(accessKeyID, secretAccessKey, os.Getenv(envSessionToken)), nil
+	case AuthMethodStaticKeys:
+		if err := requireAuthConfig(authConfig, authConfigAccessKeyID, authConfigSecretAccessKey); err != nil {
+			return nil, err
+		}
+		return staticCredentials(authConfig[authConfigAccessKeyID], authConfig[authConfigSecretAccessKey],
+			authConfig[authConfigSessionToken]), nil
+	case AuthMethodCredentialProcess:
+		if err := requireAuthConfig(authConfig, authConfigCredentialProcess); err != nil {
+			return nil, err
+		}
+		command := authConfig[authConfigCredentialProcess]
+		return func(cfg aws.Config) aws.CredentialsProvider {
+			return processcreds.NewProvider(command)
+		}, nil
+	case AuthMethodWebIdentity:
+		return getWebIdentityCredentials(authConfig)
+	default:
+		return nil, fmt.Errorf("unknown credential source: %s", method)
+	}
+}
+
+// getWebIdentityCredentials returns the credentials of the role assumed with the web
+// identity token file. The role and token file default to those of the environment,
+// as set in EKS pods and CI runners.
+func getWebIdentityCredentials(authConfig map[string]string) (clients.CredentialsFunc, error) {
+	roleARN := authConfig[authConfigRoleARN]
+	if roleARN == "" {
+		roleARN = os.Getenv(envRoleARN)
+	}
+	tokenFile := authConfig[authConfigWebIdentityTokenFile]
+	if tokenFile == "" {
+		tokenFile = os.Getenv(envWebIdentityTokenFile)
+	}
+	if roleARN == "" {
+		return nil, fmt.Errorf("%w: %s", ErrMissingAuthConfig, authConfigRoleARN)
+	}
+	if tokenFile == "" {
+		return nil, fmt.Errorf("%w: %s", ErrMissingAuthConfig, authConfigWebIdentityTokenFile)
+	}
+	sessionName := os.Getenv(envRoleSessionName)
+
+	return func(cfg aws.Config) aws.CredentialsProvider {
+		return stscreds.NewWebIdentityRoleProvider(sts.NewFromConfig(cfg), roleARN, stscreds.IdentityTokenFile(tokenFile),
+			func(options *stscreds.WebIdentityRoleOptions) {
+				options.RoleSessionName = sessionName
+			})
+	}, nil
+}
+
+// staticCredentials returns credentials of the given keys.
+func staticCredentials(accessKeyID, secretAccessKey, sessionToken string) clients.CredentialsFunc {
+	return func(cfg aws.Config) aws.CredentialsProvider {
+		return credentials.NewStaticCredentialsProvider(accessKeyID, secretAccessKey, sessionToken)
+	}
+}
+
+// requireAuthConfig returns an error naming the first of the keys missing from the
+// authentication config.
+func requireAuthConfig(authConfig map[string]string, keys ...string) error {
+	for _, key := range keys {
+		if authConfig[key] == "" {
+			return fmt.Errorf("%w: %s", ErrMissingAuthConfig, key)
+		}
+	}
+	return nil
+}
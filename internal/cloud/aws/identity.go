@@ -0,0 +1,54 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+)
+
+// GetCallerIdentity returns the account and principal the provider signs its requests
+// as. The alias of the account is only looked up when the caller may list it, as many
+// deployment roles are not allowed to.
+func (p *Provider) GetCallerIdentity(ctx context.Context) (*cloud.CallerIdentity, error) {
+	if p.profile == "" || p.region == "" {
+		return nil, ErrNotAuthenticated
+	}
+
+	stsClient, err := p.clients.STS(ctx, p.profile, p.region)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := stsClient.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the caller identity: %w", err)
+	}
+
+	identity := &cloud.CallerIdentity{
+		Account: aws.ToString(output.Account),
+		ARN:     aws.ToString(output.Arn),
+		UserID:  aws.ToString(output.UserId),
+	}
+	identity.AccountAlias = p.getAccountAlias(ctx)
+	return identity, nil
+}
+
+// getAccountAlias returns the alias of the account, or an empty string when it has
+// none or the caller may not list it
+func (p *Provider) getAccountAlias(ctx context.Context) string {
+	iamClient, err := p.clients.IAM(ctx, p.profile, p.region)
+	if err != nil {
+		return ""
+	}
+
+	output, err := iamClient.ListAccountAliases(ctx, &iam.ListAccountAliasesInput{})
+	if err != nil || len(output.AccountAliases) == 0 {
+		return ""
+	}
+	return output.AccountAliases[0]
+}
@@ -2,6 +2,7 @@ package lambda
 
 import (
 	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/cloud/aws/clients"
 )
 
 // WorkflowsCategory represents the Lambda workflows category.
@@ -12,7 +13,7 @@ type WorkflowsCategory struct {
 }
 
 // NewWorkflowsCategory creates a new Lambda workflows category.
-func NewWorkflowsCategory(profile, region string) *WorkflowsCategory {
+func NewWorkflowsCategory(profile, region string, factory clients.Factory) *WorkflowsCategory {
 	category := &WorkflowsCategory{
 		profile:    profile,
 		region:     region,
@@ -20,7 +21,7 @@ func NewWorkflowsCategory(profile, region string) *WorkflowsCategory {
 	}
 
 	// Register operations
-	category.operations = append(category.operations, NewFunctionStatusOperation(profile, region))
+	category.operations = append(category.operations, NewFunctionStatusOperation(profile, region, factory))
 
 	return category
 }
@@ -6,8 +6,8 @@ import (
 	"fmt"
 
 	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/cloud/aws/clients"
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/lambda"
 	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
 )
@@ -23,13 +23,15 @@ var (
 type FunctionStatusOperation struct {
 	profile string
 	region  string
+	clients clients.Factory
 }
 
 // NewFunctionStatusOperation creates a new function status operation.
-func NewFunctionStatusOperation(profile, region string) *FunctionStatusOperation {
+func NewFunctionStatusOperation(profile, region string, factory clients.Factory) *FunctionStatusOperation {
 	return &FunctionStatusOperation{
 		profile: profile,
 		region:  region,
+		clients: factory,
 	}
 }
 
@@ -50,10 +52,10 @@ func (o *FunctionStatusOperation) IsUIVisible() bool {
 
 // GetFunctionStatus returns the status of all Lambda functions.
 func (o *FunctionStatusOperation) GetFunctionStatus(ctx context.Context) ([]cloud.FunctionStatus, error) {
-	// Create a new AWS SDK client
-	client, err := getClient(ctx, o.profile, o.region)
+	// Get the shared AWS SDK client
+	client, err := o.clients.Lambda(ctx, o.profile, o.region)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", ErrLoadConfig, err)
 	}
 
 	// List all functions
@@ -116,21 +118,8 @@ func (o *FunctionStatusOperation) Execute(ctx context.Context, params map[string
 	return o.GetFunctionStatus(ctx)
 }
 
-// getClient creates a new Lambda client.
-func getClient(ctx context.Context, profile, region string) (*lambda.Client, error) {
-	cfg, err := config.LoadDefaultConfig(ctx,
-		config.WithSharedConfigProfile(profile),
-		config.WithRegion(region),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("%w: %w", ErrLoadConfig, err)
-	}
-
-	return lambda.NewFromConfig(cfg), nil
-}
-
 // listFunctions returns a list of all Lambda functions.
-func listFunctions(ctx context.Context, client *lambda.Client) ([]types.FunctionConfiguration, error) {
+func listFunctions(ctx context.Context, client clients.LambdaAPI) ([]types.FunctionConfiguration, error) {
 	var functions []types.FunctionConfiguration
 	var marker *string
 
@@ -0,0 +1,83 @@
+package lambda
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud/aws/clients"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+// fakeLambda is a Lambda client returning functions one page at a time
+type fakeLambda struct {
+	pages [][]types.FunctionConfiguration
+	err   error
+}
+
+func (f *fakeLambda) ListFunctions(ctx context.Context, params *lambda.ListFunctionsInput, optFns ...func(*lambda.Options)) (*lambda.ListFunctionsOutput, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	page := 0
+	if params.Marker != nil {
+		page, _ = strconv.Atoi(*params.Marker)
+	}
+	output := &lambda.ListFunctionsOutput{Functions: f.pages[page]}
+	if page+1 < len(f.pages) {
+		output.NextMarker = aws.String(strconv.Itoa(page + 1))
+	}
+	return output, nil
+}
+
+// fakeFactory is a client factory returning a fake Lambda client
+type fakeFactory struct {
+	clients.Factory
+	lambda *fakeLambda
+	err    error
+}
+
+func (f *fakeFactory) Lambda(ctx context.Context, profile, region string) (clients.LambdaAPI, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.lambda, nil
+}
+
+// TestGetFunctionStatus tests listing every page of functions
+func TestGetFunctionStatus(t *testing.T) {
+	factory := &fakeFactory{lambda: &fakeLambda{pages: [][]types.FunctionConfiguration{
+		{{FunctionName: aws.String("first")}},
+		{{FunctionName: aws.String("second"), Architectures: []types.Architecture{"arm64"}}},
+	}}}
+
+	functions, err := NewFunctionStatusOperation("dev", "us-east-1", factory).GetFunctionStatus(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(functions) != 2 {
+		t.Fatalf("Expected 2 functions, got %d", len(functions))
+	}
+	if functions[0].Architecture != "x86_64" || functions[1].Architecture != "arm64" {
+		t.Errorf("Expected x86_64 and arm64 architectures, got %s and %s", functions[0].Architecture, functions[1].Architecture)
+	}
+}
+
+// TestGetFunctionStatusErrors tests wrapping client errors
+func TestGetFunctionStatusErrors(t *testing.T) {
+	ctx := context.Background()
+
+	factory := &fakeFactory{err: errors.New("profile not found")}
+	if _, err := NewFunctionStatusOperation("dev", "us-east-1", factory).GetFunctionStatus(ctx); !errors.Is(err, ErrLoadConfig) {
+		t.Errorf("Expected ErrLoadConfig, got %v", err)
+	}
+
+	factory = &fakeFactory{lambda: &fakeLambda{err: errors.New("access denied")}}
+	if _, err := NewFunctionStatusOperation("dev", "us-east-1", factory).GetFunctionStatus(ctx); !errors.Is(err, ErrListFunctions) {
+		t.Errorf("Expected ErrListFunctions, got %v", err)
+	}
+}
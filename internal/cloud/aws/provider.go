@@ -5,14 +5,31 @@ import (
 	"fmt"
 
 	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/cloud/aws/clients"
 	"github.com/HenryOwenz/cloudgate/internal/cloud/aws/codepipeline"
 	"github.com/HenryOwenz/cloudgate/internal/cloud/aws/lambda"
 )
 
+// Authentication methods. Profiles of the shared config sign in with the profile, SSO
+// or MFA method, while the credential sources sign with credentials of their own.
+const (
+	AuthMethodProfile           = "profile"
+	AuthMethodSSO               = "sso"
+	AuthMethodMFA               = "mfa"
+	AuthMethodEnvironment       = "environment"
+	AuthMethodStaticKeys        = "static_keys"
+	AuthMethodCredentialProcess = "credential_process"
+	AuthMethodWebIdentity       = "web_identity"
+)
+
+// authConfigTokenCode is the authentication config key of the code of an MFA device
+const authConfigTokenCode = "mfa_code"
+
 // Common errors
 var (
 	ErrNotAuthenticated = fmt.Errorf("not authenticated")
 	ErrNotImplemented   = fmt.Errorf("not implemented")
+	ErrNotMFAProfile    = fmt.Errorf("profile does not assume a role with MFA")
 )
 
 // Provider represents the AWS cloud provider.
@@ -20,12 +37,20 @@ type Provider struct {
 	profile  string
 	region   string
 	services []cloud.Service
+	clients  clients.Factory
 }
 
 // New creates a new AWS provider.
 func New() *Provider {
+	return NewWithClients(clients.NewCachingFactory())
+}
+
+// NewWithClients creates a new AWS provider whose operations get their AWS SDK
+// clients from the given factory.
+func NewWithClients(factory clients.Factory) *Provider {
 	return &Provider{
 		services: make([]cloud.Service, 0),
+		clients:  factory,
 	}
 }
 
@@ -46,7 +71,44 @@ func (p *Provider) Services() []cloud.Service {
 
 // GetProfiles returns all available profiles for this provider.
 func (p *Provider) GetProfiles() ([]string, error) {
-	return getAWSProfiles()
+	profiles, err := getAWSProfiles()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(profiles))
+	for i, profile := range profiles {
+		names[i] = profile.Name
+	}
+	return names, nil
+}
+
+// GetProfileDetails returns the configured details of all available profiles.
+func (p *Provider) GetProfileDetails() ([]cloud.ProfileDetails, error) {
+	profiles, err := getAWSProfiles()
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]cloud.ProfileDetails, len(profiles))
+	for i, profile := range profiles {
+		details[i] = cloud.ProfileDetails{
+			Name:     profile.Name,
+			Region:   profile.Region,
+			Account:  profile.AccountID(),
+			Role:     profile.RoleName(),
+			AuthType: profile.AuthType(),
+		}
+		details[i].AuthMethod = AuthMethodProfile
+		switch {
+		case details[i].AuthType == AuthTypeSSO:
+			details[i].AuthMethod = AuthMethodSSO
+		case requiresTokenCode(profile):
+			details[i].AuthMethod = AuthMethodMFA
+			details[i].MFADevice = profile.MFASerial
+		}
+	}
+	return details, nil
 }
 
 // LoadConfig loads the provider configuration with the given profile and region.
@@ -56,18 +118,23 @@ func (p *Provider) LoadConfig(profile, region string) error {
 
 	// Register services
 	p.services = make([]cloud.Service, 0)
-	p.services = append(p.services, lambda.NewService(profile, region))
-	p.services = append(p.services, codepipeline.NewService(profile, region))
+	p.services = append(p.services, lambda.NewService(profile, region, p.clients))
+	p.services = append(p.services, codepipeline.NewService(profile, region, p.clients))
 
 	return nil
 }
 
+// Clients returns the factory the provider's operations get their AWS SDK clients from.
+func (p *Provider) Clients() clients.Factory {
+	return p.clients
+}
+
 // GetFunctionStatusOperation returns the function status operation
 func (p *Provider) GetFunctionStatusOperation() (cloud.FunctionStatusOperation, error) {
 	if p.profile == "" || p.region == "" {
 		return nil, ErrNotAuthenticated
 	}
-	return lambda.NewFunctionStatusOperation(p.profile, p.region), nil
+	return lambda.NewFunctionStatusOperation(p.profile, p.region, p.clients), nil
 }
 
 // GetCodePipelineManualApprovalOperation returns the CodePipeline manual approval operation
@@ -75,7 +142,7 @@ func (p *Provider) GetCodePipelineManualApprovalOperation() (cloud.CodePipelineM
 	if p.profile == "" || p.region == "" {
 		return nil, ErrNotAuthenticated
 	}
-	return codepipeline.NewCloudManualApprovalOperation(p.profile, p.region), nil
+	return codepipeline.NewCloudManualApprovalOperation(p.profile, p.region, p.clients), nil
 }
 
 // GetPipelineStatusOperation returns the pipeline status operation
@@ -83,7 +150,7 @@ func (p *Provider) GetPipelineStatusOperation() (cloud.PipelineStatusOperation,
 	if p.profile == "" || p.region == "" {
 		return nil, ErrNotAuthenticated
 	}
-	return codepipeline.NewCloudPipelineStatusOperation(p.profile, p.region), nil
+	return codepipeline.NewCloudPipelineStatusOperation(p.profile, p.region, p.clients), nil
 }
 
 // GetStartPipelineOperation returns the start pipeline operation
@@ -91,22 +158,120 @@ func (p *Provider) GetStartPipelineOperation() (cloud.StartPipelineOperation, er
 	if p.profile == "" || p.region == "" {
 		return nil, ErrNotAuthenticated
 	}
-	return codepipeline.NewCloudStartPipelineOperation(p.profile, p.region), nil
+	return codepipeline.NewCloudStartPipelineOperation(p.profile, p.region, p.clients), nil
+}
+
+// GetExecutionHistoryOperation returns the pipeline execution history operation
+func (p *Provider) GetExecutionHistoryOperation() (cloud.ExecutionHistoryOperation, error) {
+	if p.profile == "" || p.region == "" {
+		return nil, ErrNotAuthenticated
+	}
+	return codepipeline.NewCloudExecutionHistoryOperation(p.profile, p.region, p.clients), nil
+}
+
+// GetStopPipelineExecutionOperation returns the stop pipeline execution operation
+func (p *Provider) GetStopPipelineExecutionOperation() (cloud.StopPipelineExecutionOperation, error) {
+	if p.profile == "" || p.region == "" {
+		return nil, ErrNotAuthenticated
+	}
+	return codepipeline.NewCloudStopPipelineExecutionOperation(p.profile, p.region, p.clients), nil
+}
+
+// GetRetryStageExecutionOperation returns the retry stage execution operation
+func (p *Provider) GetRetryStageExecutionOperation() (cloud.RetryStageExecutionOperation, error) {
+	if p.profile == "" || p.region == "" {
+		return nil, ErrNotAuthenticated
+	}
+	return codepipeline.NewCloudRetryStageExecutionOperation(p.profile, p.region, p.clients), nil
+}
+
+// GetApprovalsInboxOperation returns the cross-account approvals inbox operation
+func (p *Provider) GetApprovalsInboxOperation() (cloud.ApprovalsInboxOperation, error) {
+	if p.profile == "" || p.region == "" {
+		return nil, ErrNotAuthenticated
+	}
+	return codepipeline.NewCloudApprovalsInboxOperation(p.profile, p.region, p.clients), nil
+}
+
+// GetStageTransitionOperation returns the stage transition operation
+func (p *Provider) GetStageTransitionOperation() (cloud.StageTransitionOperation, error) {
+	if p.profile == "" || p.region == "" {
+		return nil, ErrNotAuthenticated
+	}
+	return codepipeline.NewCloudStageTransitionOperation(p.profile, p.region, p.clients), nil
+}
+
+// GetRollbackStageOperation returns the rollback stage operation
+func (p *Provider) GetRollbackStageOperation() (cloud.RollbackStageOperation, error) {
+	if p.profile == "" || p.region == "" {
+		return nil, ErrNotAuthenticated
+	}
+	return codepipeline.NewCloudRollbackStageOperation(p.profile, p.region, p.clients), nil
+}
+
+// GetOverrideStageConditionOperation returns the override stage condition operation
+func (p *Provider) GetOverrideStageConditionOperation() (cloud.OverrideStageConditionOperation, error) {
+	if p.profile == "" || p.region == "" {
+		return nil, ErrNotAuthenticated
+	}
+	return codepipeline.NewCloudOverrideStageConditionOperation(p.profile, p.region, p.clients), nil
 }
 
-// GetAuthenticationMethods returns the available authentication methods
+// GetPipelineDefinitionOperation returns the pipeline definition operation
+func (p *Provider) GetPipelineDefinitionOperation() (cloud.PipelineDefinitionOperation, error) {
+	if p.profile == "" || p.region == "" {
+		return nil, ErrNotAuthenticated
+	}
+	return codepipeline.NewCloudPipelineDefinitionOperation(p.profile, p.region, p.clients), nil
+}
+
+// GetExecutionWatchOperation returns the pipeline execution watch operation
+func (p *Provider) GetExecutionWatchOperation() (cloud.ExecutionWatchOperation, error) {
+	if p.profile == "" || p.region == "" {
+		return nil, ErrNotAuthenticated
+	}
+	return codepipeline.NewCloudExecutionWatchOperation(p.profile, p.region, p.clients), nil
+}
+
+// GetAuthenticationMethods returns the available authentication methods. Profiles
+// signing in with SSO or MFA use the method of their profile, so they are not listed.
 func (p *Provider) GetAuthenticationMethods() []string {
-	// AWS only supports profile-based authentication for now
-	return []string{"profile"}
+	return []string{
+		AuthMethodProfile,
+		AuthMethodEnvironment,
+		AuthMethodStaticKeys,
+		AuthMethodCredentialProcess,
+		AuthMethodWebIdentity,
+	}
 }
 
-// GetAuthConfigKeys returns the configuration keys required for an authentication method
+// GetAuthConfigKeys returns the configuration keys required for an authentication method.
+// The credential sources also take the profile their credentials are cached under,
+// which is not looked up in the shared config.
 func (p *Provider) GetAuthConfigKeys(method string) []string {
-	return []string{"profile", "region"}
+	switch method {
+	case AuthMethodMFA:
+		return []string{"profile", "region", authConfigTokenCode}
+	case AuthMethodEnvironment:
+		return []string{"region"}
+	case AuthMethodStaticKeys:
+		return []string{authConfigAccessKeyID, authConfigSecretAccessKey, authConfigSessionToken, "region"}
+	case AuthMethodCredentialProcess:
+		return []string{authConfigCredentialProcess, "region"}
+	case AuthMethodWebIdentity:
+		return []string{authConfigRoleARN, authConfigWebIdentityTokenFile, "region"}
+	default:
+		return []string{"profile", "region"}
+	}
 }
 
-// Authenticate authenticates with the provider using the given method and configuration
-func (p *Provider) Authenticate(method string, authConfig map[string]string) error {
+// Authenticate authenticates with the provider using the given method and configuration.
+// Profiles that sign in with SSO run the device authorization flow when their cached
+// token is missing or expired, reporting the authorization to the context. Profiles
+// assuming a role with MFA assume it with the mfa_code of the config unless their
+// session credentials are still valid, and fail with cloud.ErrTokenCodeRequired when
+// there is no code. Credential sources check that their credentials can be retrieved.
+func (p *Provider) Authenticate(ctx context.Context, method string, authConfig map[string]string) error {
 	profile, ok := authConfig["profile"]
 	if !ok {
 		return fmt.Errorf("profile is required")
@@ -117,9 +282,103 @@ func (p *Provider) Authenticate(method string, authConfig map[string]string) err
 		return fmt.Errorf("region is required")
 	}
 
+	if isCredentialSource(method) {
+		return p.authenticateCredentialSource(ctx, method, profile, region, authConfig)
+	}
+	switch method {
+	case AuthMethodProfile, AuthMethodSSO, AuthMethodMFA:
+	default:
+		return fmt.Errorf("unknown authentication method: %s", method)
+	}
+
+	// The profile signs with the credentials of the shared config again if a credential
+	// source was cached under its name
+	p.clients.SetCredentials(profile, nil)
+
+	config, err := LoadSharedConfig()
+	if err != nil {
+		return err
+	}
+
+	sharedProfile, found := config.Profile(profile)
+	switch {
+	case found && sharedProfile.AuthType() == AuthTypeSSO:
+		signedIn, err := signInSSO(ctx, p.clients, config, sharedProfile)
+		if err != nil {
+			return err
+		}
+		if signedIn {
+			// Clients loaded before signing in hold the credentials of the old token
+			p.clients.Invalidate(profile)
+		}
+	case found && requiresTokenCode(sharedProfile):
+		if err := p.assumeRoleWithMFA(ctx, profile, region, authConfig[authConfigTokenCode]); err != nil {
+			return err
+		}
+	case method == AuthMethodSSO:
+		return fmt.Errorf("%w: %s", ErrNotSSOProfile, profile)
+	case method == AuthMethodMFA:
+		return fmt.Errorf("%w: %s", ErrNotMFAProfile, profile)
+	}
+
 	return p.LoadConfig(profile, region)
 }
 
+// authenticateCredentialSource signs the configs of the profile with the credentials of
+// the credential source, checking that they can be retrieved.
+func (p *Provider) authenticateCredentialSource(ctx context.Context, method, profile, region string, authConfig map[string]string) error {
+	if region == "" {
+		return fmt.Errorf("region is required")
+	}
+
+	credentials, err := getCredentialSource(method, authConfig)
+	if err != nil {
+		return err
+	}
+	p.clients.SetCredentials(profile, credentials)
+
+	cfg, err := p.clients.Config(ctx, profile, region)
+	if err != nil {
+		return err
+	}
+	if _, err := cfg.Credentials.Retrieve(ctx); err != nil {
+		return fmt.Errorf("failed to get the %s credentials: %w", method, err)
+	}
+
+	return p.LoadConfig(profile, region)
+}
+
+// requiresTokenCode returns whether the profile assumes its role with the code of an
+// MFA device
+func requiresTokenCode(profile SharedProfile) bool {
+	return profile.AuthType() == AuthTypeAssumeRole && profile.MFASerial != ""
+}
+
+// assumeRoleWithMFA assumes the role of the profile with the given MFA code, or checks
+// that the session credentials of the last role assumption are still valid when the
+// code is empty. The credentials are cached with the config of the profile until they
+// expire.
+func (p *Provider) assumeRoleWithMFA(ctx context.Context, profile, region, code string) error {
+	if code != "" {
+		// A new code assumes the role again rather than lingering for a later assumption
+		p.clients.Invalidate(profile)
+		p.clients.SetTokenCode(profile, code)
+	}
+
+	cfg, err := p.clients.Config(ctx, profile, region)
+	if err != nil {
+		return err
+	}
+	if cfg.Credentials == nil {
+		return nil
+	}
+
+	if _, err := cfg.Credentials.Retrieve(ctx); err != nil {
+		return fmt.Errorf("failed to assume the role of profile %s: %w", profile, err)
+	}
+	return nil
+}
+
 // IsAuthenticated returns whether the provider is authenticated
 func (p *Provider) IsAuthenticated() bool {
 	return p.profile != "" && p.region != ""
@@ -210,7 +469,7 @@ func (p *Provider) GetStatus(ctx context.Context) ([]cloud.PipelineStatus, error
 }
 
 // StartPipeline starts a pipeline execution
-func (p *Provider) StartPipeline(ctx context.Context, pipelineName string, commitID string) error {
+func (p *Provider) StartPipeline(ctx context.Context, pipelineName string, sourceRevisions []cloud.SourceRevision, variables []cloud.PipelineVariable) error {
 	if p.profile == "" || p.region == "" {
 		return ErrNotAuthenticated
 	}
@@ -220,5 +479,6 @@ func (p *Provider) StartPipeline(ctx context.Context, pipelineName string, commi
 		return err
 	}
 
-	return startOp.StartPipelineExecution(ctx, pipelineName, commitID)
+	_, err = startOp.StartPipelineExecution(ctx, pipelineName, sourceRevisions, variables)
+	return err
 }
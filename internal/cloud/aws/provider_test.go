@@ -0,0 +1,183 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+)
+
+// TestAuthenticateMFA tests assuming the role of a profile with the code of its MFA device
+func TestAuthenticateMFA(t *testing.T) {
+	writeSharedFiles(t, testConfigFile, testCredentialsFile)
+	factory := &fakeClients{}
+	factory.credentials = aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+		if len(factory.tokenCodes) == 0 {
+			return aws.Credentials{}, cloud.ErrTokenCodeRequired
+		}
+		return aws.Credentials{AccessKeyID: "ASIAEXAMPLE"}, nil
+	})
+	provider := NewWithClients(factory)
+	ctx := context.Background()
+
+	details, err := provider.GetProfileDetails()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for _, profile := range details {
+		if profile.Name == "deploy" && (profile.AuthMethod != AuthMethodMFA || profile.MFADevice != "arn:aws:iam::333333333333:mfa/jane") {
+			t.Errorf("Expected the deploy profile to sign in with its MFA device, got %+v", profile)
+		}
+	}
+
+	authConfig := map[string]string{"profile": "deploy", "region": "us-east-1"}
+	if err := provider.Authenticate(ctx, AuthMethodMFA, authConfig); !errors.Is(err, cloud.ErrTokenCodeRequired) {
+		t.Fatalf("Expected ErrTokenCodeRequired, got %v", err)
+	}
+	if provider.IsAuthenticated() {
+		t.Error("Expected the provider not to be authenticated without a code")
+	}
+
+	authConfig[authConfigTokenCode] = "123456"
+	if err := provider.Authenticate(ctx, AuthMethodMFA, authConfig); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(factory.tokenCodes) != 1 || factory.tokenCodes[0] != "123456" {
+		t.Errorf("Expected the code to be used, got %v", factory.tokenCodes)
+	}
+	if !provider.IsAuthenticated() {
+		t.Error("Expected the provider to be authenticated")
+	}
+
+	authConfig = map[string]string{"profile": "tools", "region": "us-east-1"}
+	if err := provider.Authenticate(ctx, AuthMethodMFA, authConfig); !errors.Is(err, ErrNotMFAProfile) {
+		t.Errorf("Expected ErrNotMFAProfile, got %v", err)
+	}
+}
+
+// TestAuthenticateCredentialSource tests signing with the credentials of the
+// environment and of static keys, without a shared config
+func TestAuthenticateCredentialSource(t *testing.T) {
+	t.Setenv("AWS_CONFIG_FILE", "/nonexistent/config")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", "/nonexistent/credentials")
+	t.Setenv(envAccessKeyID, "")
+	t.Setenv(envSecretAccessKey, "")
+	t.Setenv(envRoleARN, "")
+	t.Setenv(envWebIdentityTokenFile, "")
+	factory := &fakeClients{}
+	provider := NewWithClients(factory)
+	ctx := context.Background()
+
+	retrieve := func(profile string) aws.Credentials {
+		cfg, _ := factory.Config(ctx, profile, "eu-west-1")
+		credentials, err := cfg.Credentials.Retrieve(ctx)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		return credentials
+	}
+
+	authConfig := map[string]string{"profile": AuthMethodEnvironment, "region": "eu-west-1"}
+	if err := provider.Authenticate(ctx, AuthMethodEnvironment, authConfig); !errors.Is(err, ErrNoEnvironmentCredentials) {
+		t.Fatalf("Expected ErrNoEnvironmentCredentials, got %v", err)
+	}
+
+	t.Setenv(envAccessKeyID, "AKIAENVIRONMENT")
+	t.Setenv(envSecretAccessKey, "secret")
+	if err := provider.Authenticate(ctx, AuthMethodEnvironment, authConfig); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if credentials := retrieve(AuthMethodEnvironment); credentials.AccessKeyID != "AKIAENVIRONMENT" {
+		t.Errorf("Expected the credentials of the environment, got %q", credentials.AccessKeyID)
+	}
+	if !provider.IsAuthenticated() {
+		t.Error("Expected the provider to be authenticated")
+	}
+
+	authConfig = map[string]string{"profile": AuthMethodStaticKeys, "region": "eu-west-1", authConfigAccessKeyID: "AKIASTATIC"}
+	if err := provider.Authenticate(ctx, AuthMethodStaticKeys, authConfig); !errors.Is(err, ErrMissingAuthConfig) {
+		t.Fatalf("Expected ErrMissingAuthConfig, got %v", err)
+	}
+
+	authConfig[authConfigSecretAccessKey] = "secret"
+	authConfig[authConfigSessionToken] = "token"
+	if err := provider.Authenticate(ctx, AuthMethodStaticKeys, authConfig); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if credentials := retrieve(AuthMethodStaticKeys); credentials.AccessKeyID != "AKIASTATIC" || credentials.SessionToken != "token" {
+		t.Errorf("Expected the static keys, got %+v", credentials)
+	}
+
+	authConfig = map[string]string{"profile": AuthMethodWebIdentity, "region": "eu-west-1"}
+	if err := provider.Authenticate(ctx, AuthMethodWebIdentity, authConfig); !errors.Is(err, ErrMissingAuthConfig) {
+		t.Errorf("Expected ErrMissingAuthConfig, got %v", err)
+	}
+}
+
+// fakeSTS is an STS service identifying the caller as an assumed role
+type fakeSTS struct{}
+
+func (f *fakeSTS) GetCallerIdentity(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error) {
+	return &sts.GetCallerIdentityOutput{
+		Account: aws.String("222222222222"),
+		Arn:     aws.String("arn:aws:sts::222222222222:assumed-role/Deployer/session"),
+		UserId:  aws.String("AROAEXAMPLE:session"),
+	}, nil
+}
+
+// fakeIAM is an IAM service listing the aliases of the account, or denying it
+type fakeIAM struct {
+	aliases []string
+	err     error
+}
+
+func (f *fakeIAM) ListAccountAliases(ctx context.Context, params *iam.ListAccountAliasesInput, optFns ...func(*iam.Options)) (*iam.ListAccountAliasesOutput, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &iam.ListAccountAliasesOutput{AccountAliases: f.aliases}, nil
+}
+
+// TestGetCallerIdentity tests identifying the caller, with the account alias only when
+// it may be listed
+func TestGetCallerIdentity(t *testing.T) {
+	aliases := &fakeIAM{aliases: []string{"acme-prod"}}
+	factory := &fakeClients{sts: &fakeSTS{}, iam: aliases}
+	provider := NewWithClients(factory)
+	ctx := context.Background()
+
+	if _, err := provider.GetCallerIdentity(ctx); !errors.Is(err, ErrNotAuthenticated) {
+		t.Fatalf("Expected ErrNotAuthenticated, got %v", err)
+	}
+
+	if err := provider.LoadConfig("prod", "us-east-1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	identity, err := provider.GetCallerIdentity(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := cloud.CallerIdentity{
+		Account:      "222222222222",
+		AccountAlias: "acme-prod",
+		ARN:          "arn:aws:sts::222222222222:assumed-role/Deployer/session",
+		UserID:       "AROAEXAMPLE:session",
+	}
+	if *identity != expected {
+		t.Errorf("Expected %+v, got %+v", expected, *identity)
+	}
+
+	aliases.err = errors.New("AccessDenied")
+	identity, err = provider.GetCallerIdentity(ctx)
+	if err != nil {
+		t.Fatalf("Expected the identity without its alias, got %v", err)
+	}
+	if identity.Account != "222222222222" || identity.AccountAlias != "" {
+		t.Errorf("Expected the identity without its alias, got %+v", *identity)
+	}
+}
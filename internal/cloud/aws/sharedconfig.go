@@ -0,0 +1,334 @@
+package aws
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Environment variables that move the shared config and credentials files.
+const (
+	envConfigFile      = "AWS_CONFIG_FILE"
+	envCredentialsFile = "AWS_SHARED_CREDENTIALS_FILE"
+)
+
+// Authentication types of a shared config profile.
+const (
+	AuthTypeSSO               = "SSO"
+	AuthTypeAssumeRole        = "Assume role"
+	AuthTypeWebIdentity       = "Web identity"
+	AuthTypeCredentialProcess = "Credential process"
+	AuthTypeAccessKeys        = "Access keys"
+)
+
+// SharedProfile is a profile of the AWS shared config and credentials files.
+type SharedProfile struct {
+	Name                 string
+	Region               string
+	Output               string
+	SSOSession           string
+	SSOStartURL          string
+	SSORegion            string
+	SSOAccountID         string
+	SSORoleName          string
+	RoleARN              string
+	RoleSessionName      string
+	SourceProfile        string
+	CredentialSource     string
+	MFASerial            string
+	CredentialProcess    string
+	WebIdentityTokenFile string
+	HasAccessKeys        bool
+}
+
+// SSOSession is an sso-session section of the AWS shared config file.
+type SSOSession struct {
+	Name               string
+	StartURL           string
+	Region             string
+	RegistrationScopes string
+}
+
+// SharedConfig holds the profiles and SSO sessions of the AWS shared config and
+// credentials files. SkippedLines lists the lines of the files that could not be
+// parsed, as file:line, which are skipped.
+type SharedConfig struct {
+	Profiles     []SharedProfile
+	SSOSessions  map[string]SSOSession
+	SkippedLines []string
+}
+
+// iniSection is a section of an INI file with its keys in lower case.
+type iniSection struct {
+	name   string
+	values map[string]string
+}
+
+// LoadSharedConfig loads the AWS shared config and credentials files, honoring
+// AWS_CONFIG_FILE and AWS_SHARED_CREDENTIALS_FILE. Missing files are skipped.
+func LoadSharedConfig() (*SharedConfig, error) {
+	configSections, configSkipped, err := parseINIFile(getSharedFilename(envConfigFile, "config"))
+	if err != nil {
+		return nil, err
+	}
+
+	credentialsSections, credentialsSkipped, err := parseINIFile(getSharedFilename(envCredentialsFile, "credentials"))
+	if err != nil {
+		return nil, err
+	}
+
+	config := newSharedConfig(configSections, credentialsSections)
+	config.SkippedLines = append(configSkipped, credentialsSkipped...)
+	return config, nil
+}
+
+// Profile returns the profile with the given name.
+func (c *SharedConfig) Profile(name string) (SharedProfile, bool) {
+	for _, profile := range c.Profiles {
+		if profile.Name == name {
+			return profile, true
+		}
+	}
+	return SharedProfile{}, false
+}
+
+// AuthType returns how the SDK gets the credentials of the profile, in the order it
+// looks for them, or an empty string when the profile has no credentials of its own.
+func (p SharedProfile) AuthType() string {
+	switch {
+	case p.RoleARN != "" && p.WebIdentityTokenFile != "":
+		return AuthTypeWebIdentity
+	case p.RoleARN != "" && (p.SourceProfile != "" || p.CredentialSource != ""):
+		return AuthTypeAssumeRole
+	case p.SSOSession != "" || p.SSOStartURL != "" || p.SSOAccountID != "":
+		return AuthTypeSSO
+	case p.CredentialProcess != "":
+		return AuthTypeCredentialProcess
+	case p.HasAccessKeys:
+		return AuthTypeAccessKeys
+	default:
+		return ""
+	}
+}
+
+// AccountID returns the account the profile signs in to, when the config names it.
+func (p SharedProfile) AccountID() string {
+	if p.SSOAccountID != "" {
+		return p.SSOAccountID
+	}
+
+	// arn:partition:iam::account:role/name
+	parts := strings.SplitN(p.RoleARN, ":", 6)
+	if len(parts) == 6 {
+		return parts[4]
+	}
+	return ""
+}
+
+// RoleName returns the role the profile signs in with, when the config names it.
+func (p SharedProfile) RoleName() string {
+	if p.SSORoleName != "" {
+		return p.SSORoleName
+	}
+
+	if i := strings.LastIndex(p.RoleARN, "/"); i >= 0 {
+		return p.RoleARN[i+1:]
+	}
+	return ""
+}
+
+// getSharedFilename returns the path of a shared file, read from the environment
+// when set there.
+func getSharedFilename(envVar, name string) string {
+	if path := os.Getenv(envVar); path != "" {
+		if strings.HasPrefix(path, "~/") {
+			if homeDir, err := os.UserHomeDir(); err == nil {
+				return filepath.Join(homeDir, path[2:])
+			}
+		}
+		return path
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".aws", name)
+}
+
+// newSharedConfig builds the profiles and SSO sessions of the parsed files. Keys set in
+// the credentials file take precedence over those in the config file.
+func newSharedConfig(configSections, credentialsSections []iniSection) *SharedConfig {
+	config := &SharedConfig{SSOSessions: make(map[string]SSOSession)}
+	profileValues := make(map[string]map[string]string)
+
+	mergeValues := func(name string, values map[string]string) {
+		if profileValues[name] == nil {
+			profileValues[name] = make(map[string]string)
+		}
+		for key, value := range values {
+			profileValues[name][key] = value
+		}
+	}
+
+	for _, section := range configSections {
+		kind, name, _ := strings.Cut(section.name, " ")
+		switch {
+		case section.name == "default":
+			mergeValues("default", section.values)
+		case kind == "profile" && name != "":
+			mergeValues(name, section.values)
+		case kind == "sso-session" && name != "":
+			config.SSOSessions[name] = SSOSession{
+				Name:               name,
+				StartURL:           section.values["sso_start_url"],
+				Region:             section.values["sso_region"],
+				RegistrationScopes: section.values["sso_registration_scopes"],
+			}
+		}
+		// Other sections, such as services, and profiles without the profile prefix
+		// are not profiles
+	}
+
+	// The credentials file names its profiles without a prefix
+	for _, section := range credentialsSections {
+		mergeValues(section.name, section.values)
+	}
+
+	for name, values := range profileValues {
+		profile := SharedProfile{
+			Name:                 name,
+			Region:               values["region"],
+			Output:               values["output"],
+			SSOSession:           values["sso_session"],
+			SSOStartURL:          values["sso_start_url"],
+			SSORegion:            values["sso_region"],
+			SSOAccountID:         values["sso_account_id"],
+			SSORoleName:          values["sso_role_name"],
+			RoleARN:              values["role_arn"],
+			RoleSessionName:      values["role_session_name"],
+			SourceProfile:        values["source_profile"],
+			CredentialSource:     values["credential_source"],
+			MFASerial:            values["mfa_serial"],
+			CredentialProcess:    values["credential_process"],
+			WebIdentityTokenFile: values["web_identity_token_file"],
+			HasAccessKeys:        values["aws_access_key_id"] != "",
+		}
+
+		// Profiles of an SSO session sign in through the session's portal
+		if session, ok := config.SSOSessions[profile.SSOSession]; ok {
+			if profile.SSOStartURL == "" {
+				profile.SSOStartURL = session.StartURL
+			}
+			if profile.SSORegion == "" {
+				profile.SSORegion = session.Region
+			}
+		}
+
+		config.Profiles = append(config.Profiles, profile)
+	}
+
+	sort.Slice(config.Profiles, func(i, j int) bool {
+		return config.Profiles[i].Name < config.Profiles[j].Name
+	})
+
+	return config
+}
+
+// parseINIFile parses an INI file, returning no sections when the file does not exist.
+// The lines that could not be parsed are returned as file:line.
+func parseINIFile(filePath string) ([]iniSection, []string, error) {
+	if filePath == "" {
+		return nil, nil, nil
+	}
+
+	file, err := os.Open(filePath)
+	if os.IsNotExist(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open AWS config file: %w", err)
+	}
+	defer file.Close()
+
+	sections, skippedLines, err := parseINI(file)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", filePath, err)
+	}
+
+	var skipped []string
+	for _, lineNumber := range skippedLines {
+		skipped = append(skipped, fmt.Sprintf("%s:%d", filePath, lineNumber))
+	}
+	return sections, skipped, nil
+}
+
+// parseINI parses the sections of an AWS shared config or credentials file. Indented
+// lines below a key without a value are nested settings of that key, such as those
+// of s3, and are skipped. Lines that are neither a section nor a setting, and the
+// settings of a section whose header cannot be read, are skipped and their line
+// numbers returned, so one bad line does not hide the other profiles.
+func parseINI(r io.Reader) ([]iniSection, []int, error) {
+	var sections []iniSection
+	var skipped []int
+	nested := false
+	inSection := false
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		rawLine := scanner.Text()
+		line := strings.TrimSpace(rawLine)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			nested = false
+			inSection = strings.HasSuffix(line, "]")
+			if !inSection {
+				skipped = append(skipped, lineNumber)
+				continue
+			}
+			name := strings.Join(strings.Fields(line[1:len(line)-1]), " ")
+			sections = append(sections, iniSection{name: name, values: make(map[string]string)})
+			continue
+		}
+
+		if nested && (strings.HasPrefix(rawLine, " ") || strings.HasPrefix(rawLine, "\t")) {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || !inSection {
+			skipped = append(skipped, lineNumber)
+			nested = false
+			continue
+		}
+
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = stripINIComment(strings.TrimSpace(value))
+		sections[len(sections)-1].values[key] = value
+		nested = value == ""
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read AWS config file: %w", err)
+	}
+
+	return sections, skipped, nil
+}
+
+// stripINIComment removes a comment following a value, which starts with # or ;
+// after whitespace.
+func stripINIComment(value string) string {
+	for i := 1; i < len(value); i++ {
+		if (value[i] == '#' || value[i] == ';') && (value[i-1] == ' ' || value[i-1] == '\t') {
+			return strings.TrimSpace(value[:i])
+		}
+	}
+	return value
+}
@@ -0,0 +1,185 @@
+package aws
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testConfigFile = `# Shared config
+[default]
+region = us-east-1
+output = json
+
+[profile dev]
+sso_session = corp
+sso_account_id = 111111111111
+sso_role_name = Developer
+region = eu-west-1 # Ireland
+
+[profile deploy]
+role_arn = arn:aws:iam::222222222222:role/Deployer
+source_profile = default
+mfa_serial = arn:aws:iam::333333333333:mfa/jane
+s3 =
+  max_concurrent_requests = 20
+
+[profile tools]
+credential_process = /usr/local/bin/get-credentials --profile tools
+
+[profile ci]
+role_arn = arn:aws:iam::444444444444:role/CI
+web_identity_token_file = /var/run/token
+
+[sso-session corp]
+sso_start_url = https://corp.awsapps.com/start
+sso_region = us-east-1
+
+[services local]
+s3 =
+  endpoint_url = http://localhost:4566
+
+[legacy]
+region = us-west-2
+`
+
+const testCredentialsFile = `[default]
+aws_access_key_id = AKIDEXAMPLE
+aws_secret_access_key = secret
+
+[ci-keys]
+aws_access_key_id = AKIDCI
+aws_secret_access_key = secret
+`
+
+// writeSharedFiles writes the shared config and credentials files and points the
+// environment at them
+func writeSharedFiles(t *testing.T, config, credentials string) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config")
+	credentialsPath := filepath.Join(dir, "credentials")
+	if err := os.WriteFile(configPath, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(credentialsPath, []byte(credentials), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(envConfigFile, configPath)
+	t.Setenv(envCredentialsFile, credentialsPath)
+}
+
+// TestLoadSharedConfig tests reading the profiles of the shared files
+func TestLoadSharedConfig(t *testing.T) {
+	writeSharedFiles(t, testConfigFile, testCredentialsFile)
+
+	config, err := LoadSharedConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var names []string
+	for _, profile := range config.Profiles {
+		names = append(names, profile.Name)
+	}
+	if got := strings.Join(names, ","); got != "ci,ci-keys,default,deploy,dev,tools" {
+		t.Fatalf("Expected only the profiles in order, got %s", got)
+	}
+
+	tests := []struct {
+		name     string
+		region   string
+		account  string
+		role     string
+		authType string
+	}{
+		{name: "default", region: "us-east-1", authType: AuthTypeAccessKeys},
+		{name: "dev", region: "eu-west-1", account: "111111111111", role: "Developer", authType: AuthTypeSSO},
+		{name: "deploy", account: "222222222222", role: "Deployer", authType: AuthTypeAssumeRole},
+		{name: "tools", authType: AuthTypeCredentialProcess},
+		{name: "ci", account: "444444444444", role: "CI", authType: AuthTypeWebIdentity},
+		{name: "ci-keys", authType: AuthTypeAccessKeys},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile, ok := config.Profile(tt.name)
+			if !ok {
+				t.Fatalf("Expected profile %s", tt.name)
+			}
+			if profile.Region != tt.region {
+				t.Errorf("Expected region %q, got %q", tt.region, profile.Region)
+			}
+			if profile.AccountID() != tt.account {
+				t.Errorf("Expected account %q, got %q", tt.account, profile.AccountID())
+			}
+			if profile.RoleName() != tt.role {
+				t.Errorf("Expected role %q, got %q", tt.role, profile.RoleName())
+			}
+			if profile.AuthType() != tt.authType {
+				t.Errorf("Expected auth type %q, got %q", tt.authType, profile.AuthType())
+			}
+		})
+	}
+
+	// Profiles of an SSO session take its portal
+	dev, _ := config.Profile("dev")
+	if dev.SSOStartURL != "https://corp.awsapps.com/start" || dev.SSORegion != "us-east-1" {
+		t.Errorf("Expected the SSO session portal, got %q in %q", dev.SSOStartURL, dev.SSORegion)
+	}
+	deploy, _ := config.Profile("deploy")
+	if deploy.MFASerial != "arn:aws:iam::333333333333:mfa/jane" {
+		t.Errorf("Expected the MFA device, got %q", deploy.MFASerial)
+	}
+	tools, _ := config.Profile("tools")
+	if tools.CredentialProcess != "/usr/local/bin/get-credentials --profile tools" {
+		t.Errorf("Expected the whole credential process, got %q", tools.CredentialProcess)
+	}
+}
+
+// TestLoadSharedConfigMissingFiles tests skipping shared files that do not exist
+func TestLoadSharedConfigMissingFiles(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(envConfigFile, filepath.Join(dir, "config"))
+	t.Setenv(envCredentialsFile, filepath.Join(dir, "credentials"))
+
+	config, err := LoadSharedConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(config.Profiles) != 0 {
+		t.Errorf("Expected no profiles, got %d", len(config.Profiles))
+	}
+	if _, err := getAWSProfiles(); !errors.Is(err, ErrNoProfiles) {
+		t.Errorf("Expected ErrNoProfiles, got %v", err)
+	}
+}
+
+// TestLoadSharedConfigInvalidLines tests skipping the lines that are not settings
+// without losing the other profiles
+func TestLoadSharedConfigInvalidLines(t *testing.T) {
+	writeSharedFiles(t, "region = us-west-2\n[profile dev]\nregion us-east-1\noutput = json\n"+
+		"[profile broken\nregion = eu-west-1\n[profile prod]\nregion = eu-central-1\n", "")
+
+	config, err := LoadSharedConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(config.Profiles) != 2 {
+		t.Fatalf("Expected the valid profiles, got %+v", config.Profiles)
+	}
+	if dev, _ := config.Profile("dev"); dev.Region != "" || dev.Output != "json" {
+		t.Errorf("Expected the settings of dev around the invalid line, got %+v", dev)
+	}
+	if prod, _ := config.Profile("prod"); prod.Region != "eu-central-1" {
+		t.Errorf("Expected the profile after the invalid section to be read, got %+v", prod)
+	}
+
+	var lines []string
+	for _, skipped := range config.SkippedLines {
+		lines = append(lines, skipped[strings.LastIndex(skipped, ":")+1:])
+	}
+	if strings.Join(lines, ",") != "1,3,5,6" || !strings.HasSuffix(config.SkippedLines[0], "config:1") {
+		t.Errorf("Expected the skipped lines of the config file, got %v", config.SkippedLines)
+	}
+}
@@ -0,0 +1,336 @@
+package aws
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssooidc"
+	"github.com/aws/aws-sdk-go-v2/service/ssooidc/types"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/cloud/aws/clients"
+)
+
+// Settings of the client registered with IAM Identity Center to sign in.
+const (
+	ssoClientName            = "cloudgate"
+	ssoClientType            = "public"
+	ssoDefaultScope          = "sso:account:access"
+	ssoDeviceCodeGrantType   = "urn:ietf:params:oauth:grant-type:device_code"
+	ssoRefreshTokenGrantType = "refresh_token"
+)
+
+// ssoTokenRefreshWindow is how long before it expires a cached token is refreshed by
+// the SDK, and so no longer treated as valid.
+const ssoTokenRefreshWindow = 5 * time.Minute
+
+// ssoSlowDownInterval is added to the polling interval each time the service asks to
+// slow down.
+const ssoSlowDownInterval = 5 * time.Second
+
+// SSO sign in errors
+var (
+	ErrNotSSOProfile   = errors.New("profile does not sign in with SSO")
+	ErrSSOConfig       = errors.New("SSO profile is missing its start URL or region")
+	ErrSSOLoginExpired = errors.New("SSO sign in expired before it was approved")
+	ErrSSOLoginDenied  = errors.New("SSO sign in was denied")
+)
+
+// ssoSettings are the settings an SSO profile signs in with.
+type ssoSettings struct {
+	// session is the sso-session of the profile, empty for legacy profiles that name
+	// their start URL directly
+	session  string
+	startURL string
+	region   string
+	scopes   []string
+}
+
+// ssoToken is a cached SSO token, in the format of the AWS CLI token cache.
+type ssoToken struct {
+	StartURL              string `json:"startUrl"`
+	Region                string `json:"region"`
+	AccessToken           string `json:"accessToken"`
+	ExpiresAt             string `json:"expiresAt"`
+	ClientID              string `json:"clientId,omitempty"`
+	ClientSecret          string `json:"clientSecret,omitempty"`
+	RegistrationExpiresAt string `json:"registrationExpiresAt,omitempty"`
+	RefreshToken          string `json:"refreshToken,omitempty"`
+}
+
+// ssoDeviceLogin is a device authorization waiting for the user to approve it.
+type ssoDeviceLogin struct {
+	clientID              string
+	clientSecret          string
+	registrationExpiresAt time.Time
+	deviceCode            string
+	interval              time.Duration
+	expiresAt             time.Time
+}
+
+// getSSOSettings returns the SSO settings of the profile.
+func getSSOSettings(config *SharedConfig, profile SharedProfile) (ssoSettings, error) {
+	if profile.AuthType() != AuthTypeSSO {
+		return ssoSettings{}, fmt.Errorf("%w: %s", ErrNotSSOProfile, profile.Name)
+	}
+	if profile.SSOStartURL == "" || profile.SSORegion == "" {
+		return ssoSettings{}, fmt.Errorf("%w: %s", ErrSSOConfig, profile.Name)
+	}
+
+	settings := ssoSettings{
+		session:  profile.SSOSession,
+		startURL: profile.SSOStartURL,
+		region:   profile.SSORegion,
+	}
+
+	// Sessions are registered with their scopes so the SDK can refresh their tokens
+	if settings.session != "" {
+		settings.scopes = []string{ssoDefaultScope}
+		if scopes := config.SSOSessions[settings.session].RegistrationScopes; scopes != "" {
+			settings.scopes = nil
+			for _, scope := range strings.Split(scopes, ",") {
+				if scope = strings.TrimSpace(scope); scope != "" {
+					settings.scopes = append(settings.scopes, scope)
+				}
+			}
+		}
+	}
+
+	return settings, nil
+}
+
+// getSSOTokenPath returns the path of the cached token of the settings, named by the
+// SHA-1 hash of the session name or, for legacy profiles, of the start URL, as the
+// AWS CLI and SDK name it.
+func getSSOTokenPath(settings ssoSettings) (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the SSO token cache: %w", err)
+	}
+
+	key := settings.startURL
+	if settings.session != "" {
+		key = settings.session
+	}
+	hash := sha1.Sum([]byte(key))
+
+	return filepath.Join(homeDir, ".aws", "sso", "cache", hex.EncodeToString(hash[:])+".json"), nil
+}
+
+// loadSSOToken loads a cached token, returning nil when there is none.
+func loadSSOToken(path string) (*ssoToken, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the SSO token cache: %w", err)
+	}
+
+	var token ssoToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		// A corrupt token is replaced by signing in again
+		return nil, nil
+	}
+	return &token, nil
+}
+
+// saveSSOToken writes a token to the cache, readable only by the user.
+func saveSSOToken(path string, token *ssoToken) error {
+	data, err := json.MarshalIndent(token, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode the SSO token: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create the SSO token cache: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write the SSO token cache: %w", err)
+	}
+	return nil
+}
+
+// isValid returns whether the SDK can sign in with the token at the given time,
+// either with its access token or by refreshing it.
+func (t *ssoToken) isValid(settings ssoSettings, now time.Time) bool {
+	if t == nil || t.StartURL != settings.startURL {
+		return false
+	}
+
+	if expiresAt, err := time.Parse(time.RFC3339, t.ExpiresAt); err == nil && t.AccessToken != "" {
+		if now.Add(ssoTokenRefreshWindow).Before(expiresAt) {
+			return true
+		}
+	}
+
+	// Only the tokens of sessions are refreshed by the SDK
+	if settings.session == "" || t.RefreshToken == "" || t.ClientID == "" || t.ClientSecret == "" {
+		return false
+	}
+	registrationExpiresAt, err := time.Parse(time.RFC3339, t.RegistrationExpiresAt)
+	return err == nil && now.Before(registrationExpiresAt)
+}
+
+// signInSSO signs in to the SSO profile through a device authorization unless its
+// cached token is still valid. It reports whether it signed in.
+func signInSSO(ctx context.Context, factory clients.Factory, config *SharedConfig, profile SharedProfile) (bool, error) {
+	settings, err := getSSOSettings(config, profile)
+	if err != nil {
+		return false, err
+	}
+
+	path, err := getSSOTokenPath(settings)
+	if err != nil {
+		return false, err
+	}
+
+	token, err := loadSSOToken(path)
+	if err != nil {
+		return false, err
+	}
+	if token.isValid(settings, time.Now()) {
+		return false, nil
+	}
+
+	client, err := factory.SSOOIDC(ctx, settings.region)
+	if err != nil {
+		return false, err
+	}
+
+	login, err := startSSODeviceLogin(ctx, client, settings)
+	if err != nil {
+		return false, err
+	}
+
+	token, err = completeSSODeviceLogin(ctx, client, settings, login)
+	if err != nil {
+		return false, err
+	}
+
+	if err := saveSSOToken(path, token); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// startSSODeviceLogin registers a client and starts a device authorization, reporting
+// it to the context so the user can approve it.
+func startSSODeviceLogin(ctx context.Context, client clients.SSOOIDCAPI, settings ssoSettings) (*ssoDeviceLogin, error) {
+	registerInput := &ssooidc.RegisterClientInput{
+		ClientName: aws.String(ssoClientName),
+		ClientType: aws.String(ssoClientType),
+	}
+	if settings.session != "" {
+		registerInput.Scopes = settings.scopes
+		registerInput.GrantTypes = []string{ssoDeviceCodeGrantType, ssoRefreshTokenGrantType}
+	}
+
+	registration, err := client.RegisterClient(ctx, registerInput)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register the SSO client: %w", err)
+	}
+
+	authorization, err := client.StartDeviceAuthorization(ctx, &ssooidc.StartDeviceAuthorizationInput{
+		ClientId:     registration.ClientId,
+		ClientSecret: registration.ClientSecret,
+		StartUrl:     aws.String(settings.startURL),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start the SSO sign in: %w", err)
+	}
+
+	now := time.Now()
+	login := &ssoDeviceLogin{
+		clientID:              aws.ToString(registration.ClientId),
+		clientSecret:          aws.ToString(registration.ClientSecret),
+		registrationExpiresAt: time.Unix(registration.ClientSecretExpiresAt, 0),
+		deviceCode:            aws.ToString(authorization.DeviceCode),
+		interval:              time.Duration(authorization.Interval) * time.Second,
+		expiresAt:             now.Add(time.Duration(authorization.ExpiresIn) * time.Second),
+	}
+	if login.interval <= 0 {
+		login.interval = ssoSlowDownInterval
+	}
+
+	cloud.ReportDeviceAuthorization(ctx, cloud.DeviceAuthorization{
+		VerificationURI:         aws.ToString(authorization.VerificationUri),
+		VerificationURIComplete: aws.ToString(authorization.VerificationUriComplete),
+		UserCode:                aws.ToString(authorization.UserCode),
+		ExpiresAt:               login.expiresAt,
+	})
+
+	return login, nil
+}
+
+// completeSSODeviceLogin polls for the token of a device authorization until the user
+// approves or denies it, or it expires.
+func completeSSODeviceLogin(ctx context.Context, client clients.SSOOIDCAPI, settings ssoSettings, login *ssoDeviceLogin) (*ssoToken, error) {
+	interval := login.interval
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		output, err := client.CreateToken(ctx, &ssooidc.CreateTokenInput{
+			ClientId:     aws.String(login.clientID),
+			ClientSecret: aws.String(login.clientSecret),
+			DeviceCode:   aws.String(login.deviceCode),
+			GrantType:    aws.String(ssoDeviceCodeGrantType),
+		})
+
+		var pending *types.AuthorizationPendingException
+		var slowDown *types.SlowDownException
+		var expired *types.ExpiredTokenException
+		var denied *types.AccessDeniedException
+		switch {
+		case err == nil:
+			return newSSOToken(settings, login, output, time.Now()), nil
+		case errors.As(err, &pending):
+		case errors.As(err, &slowDown):
+			interval += ssoSlowDownInterval
+		case errors.As(err, &expired):
+			return nil, ErrSSOLoginExpired
+		case errors.As(err, &denied):
+			return nil, ErrSSOLoginDenied
+		default:
+			return nil, fmt.Errorf("failed to complete the SSO sign in: %w", err)
+		}
+
+		if time.Now().After(login.expiresAt) {
+			return nil, ErrSSOLoginExpired
+		}
+	}
+}
+
+// newSSOToken returns the cached token of an approved device authorization. Legacy
+// profiles cannot refresh their tokens, so only sessions keep the client registration.
+func newSSOToken(settings ssoSettings, login *ssoDeviceLogin, output *ssooidc.CreateTokenOutput, now time.Time) *ssoToken {
+	token := &ssoToken{
+		StartURL:    settings.startURL,
+		Region:      settings.region,
+		AccessToken: aws.ToString(output.AccessToken),
+		ExpiresAt:   now.Add(time.Duration(output.ExpiresIn) * time.Second).UTC().Format(time.RFC3339),
+	}
+
+	if settings.session != "" {
+		token.ClientID = login.clientID
+		token.ClientSecret = login.clientSecret
+		token.RegistrationExpiresAt = login.registrationExpiresAt.UTC().Format(time.RFC3339)
+		token.RefreshToken = aws.ToString(output.RefreshToken)
+	}
+
+	return token
+}
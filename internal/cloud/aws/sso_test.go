@@ -0,0 +1,276 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssooidc"
+	"github.com/aws/aws-sdk-go-v2/service/ssooidc/types"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/cloud/aws/clients"
+)
+
+// fakeSSOOIDC is an IAM Identity Center OIDC service approving each sign in after it
+// was polled while pending a number of times
+type fakeSSOOIDC struct {
+	pending    int
+	tokenErr   error
+	registered *ssooidc.RegisterClientInput
+	tokenCalls int
+}
+
+func (f *fakeSSOOIDC) RegisterClient(ctx context.Context, params *ssooidc.RegisterClientInput, optFns ...func(*ssooidc.Options)) (*ssooidc.RegisterClientOutput, error) {
+	f.registered = params
+	return &ssooidc.RegisterClientOutput{
+		ClientId:              aws.String("client-id"),
+		ClientSecret:          aws.String("client-secret"),
+		ClientSecretExpiresAt: time.Now().Add(90 * 24 * time.Hour).Unix(),
+	}, nil
+}
+
+func (f *fakeSSOOIDC) StartDeviceAuthorization(ctx context.Context, params *ssooidc.StartDeviceAuthorizationInput, optFns ...func(*ssooidc.Options)) (*ssooidc.StartDeviceAuthorizationOutput, error) {
+	return &ssooidc.StartDeviceAuthorizationOutput{
+		DeviceCode:              aws.String("device-code"),
+		UserCode:                aws.String("ABCD-EFGH"),
+		VerificationUri:         aws.String("https://device.sso.us-east-1.amazonaws.com/"),
+		VerificationUriComplete: aws.String("https://device.sso.us-east-1.amazonaws.com/?user_code=ABCD-EFGH"),
+		ExpiresIn:               600,
+		Interval:                1,
+	}, nil
+}
+
+func (f *fakeSSOOIDC) CreateToken(ctx context.Context, params *ssooidc.CreateTokenInput, optFns ...func(*ssooidc.Options)) (*ssooidc.CreateTokenOutput, error) {
+	f.tokenCalls++
+	if f.tokenErr != nil {
+		return nil, f.tokenErr
+	}
+	if f.tokenCalls <= f.pending {
+		return nil, &types.AuthorizationPendingException{}
+	}
+	return &ssooidc.CreateTokenOutput{
+		AccessToken:  aws.String("access-token"),
+		RefreshToken: aws.String("refresh-token"),
+		ExpiresIn:    3600,
+	}, nil
+}
+
+// fakeClients is a client factory returning the fake OIDC, STS and IAM services and
+// configs with the given credentials, or those set for their profile
+type fakeClients struct {
+	clients.Factory
+	oidc        *fakeSSOOIDC
+	sts         clients.STSAPI
+	iam         clients.IAMAPI
+	credentials aws.CredentialsProvider
+	sources     map[string]clients.CredentialsFunc
+	invalidated []string
+	tokenCodes  []string
+}
+
+func (f *fakeClients) Config(ctx context.Context, profile, region string) (aws.Config, error) {
+	cfg := aws.Config{Region: region, Credentials: f.credentials}
+	if credentials, ok := f.sources[profile]; ok {
+		cfg.Credentials = credentials(cfg)
+	}
+	return cfg, nil
+}
+
+func (f *fakeClients) SetCredentials(profile string, credentials clients.CredentialsFunc) {
+	if f.sources == nil {
+		f.sources = make(map[string]clients.CredentialsFunc)
+	}
+	if credentials == nil {
+		delete(f.sources, profile)
+		return
+	}
+	f.sources[profile] = credentials
+}
+
+func (f *fakeClients) SetTokenCode(profile, code string) {
+	f.tokenCodes = append(f.tokenCodes, code)
+}
+
+func (f *fakeClients) SSOOIDC(ctx context.Context, region string) (clients.SSOOIDCAPI, error) {
+	return f.oidc, nil
+}
+
+func (f *fakeClients) STS(ctx context.Context, profile, region string) (clients.STSAPI, error) {
+	return f.sts, nil
+}
+
+func (f *fakeClients) IAM(ctx context.Context, profile, region string) (clients.IAMAPI, error) {
+	return f.iam, nil
+}
+
+func (f *fakeClients) Invalidate(profile string) {
+	f.invalidated = append(f.invalidated, profile)
+}
+
+// TestAuthenticateSSO tests signing in to an SSO profile and caching its token
+func TestAuthenticateSSO(t *testing.T) {
+	writeSharedFiles(t, testConfigFile, testCredentialsFile)
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+
+	factory := &fakeClients{oidc: &fakeSSOOIDC{}}
+	provider := NewWithClients(factory)
+
+	var reported []cloud.DeviceAuthorization
+	ctx := cloud.WithDeviceAuthorization(context.Background(), func(authorization cloud.DeviceAuthorization) {
+		reported = append(reported, authorization)
+	})
+	authConfig := map[string]string{"profile": "dev", "region": "eu-west-1"}
+
+	if err := provider.Authenticate(ctx, AuthMethodSSO, authConfig); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !provider.IsAuthenticated() {
+		t.Error("Expected the provider to be authenticated")
+	}
+	if len(reported) != 1 || reported[0].UserCode != "ABCD-EFGH" {
+		t.Fatalf("Expected the device authorization to be reported, got %v", reported)
+	}
+	if len(factory.invalidated) != 1 || factory.invalidated[0] != "dev" {
+		t.Errorf("Expected the clients of the profile to be invalidated, got %v", factory.invalidated)
+	}
+	if scopes := factory.oidc.registered.Scopes; len(scopes) != 1 || scopes[0] != ssoDefaultScope {
+		t.Errorf("Expected the session to register the default scope, got %v", scopes)
+	}
+
+	// The token is cached where the AWS CLI looks for the tokens of the corp session
+	path := filepath.Join(homeDir, ".aws", "sso", "cache", "ee0bfd2552fbd840c02cc48b6e823320543c450f.json")
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Expected the token to be cached, got %v", err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("Expected the token cache to be private, got %v", info.Mode().Perm())
+	}
+
+	data, _ := os.ReadFile(path)
+	var token map[string]string
+	if err := json.Unmarshal(data, &token); err != nil {
+		t.Fatalf("Expected a JSON token, got %v", err)
+	}
+	if token["accessToken"] != "access-token" || token["refreshToken"] != "refresh-token" ||
+		token["startUrl"] != "https://corp.awsapps.com/start" || token["region"] != "us-east-1" ||
+		token["clientId"] != "client-id" {
+		t.Errorf("Expected the token in the AWS CLI format, got %v", token)
+	}
+	if _, err := time.Parse(time.RFC3339, token["expiresAt"]); err != nil {
+		t.Errorf("Expected an RFC 3339 expiry, got %q", token["expiresAt"])
+	}
+
+	// A valid cached token needs no sign in
+	if err := provider.Authenticate(ctx, AuthMethodProfile, authConfig); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if factory.oidc.tokenCalls != 1 || len(reported) != 1 {
+		t.Errorf("Expected the cached token to be used, got %d token calls", factory.oidc.tokenCalls)
+	}
+}
+
+// TestAuthenticateWithoutSSO tests authenticating profiles that do not sign in with SSO
+func TestAuthenticateWithoutSSO(t *testing.T) {
+	writeSharedFiles(t, testConfigFile, testCredentialsFile)
+	factory := &fakeClients{oidc: &fakeSSOOIDC{}}
+	provider := NewWithClients(factory)
+	authConfig := map[string]string{"profile": "tools", "region": "us-east-1"}
+
+	if err := provider.Authenticate(context.Background(), AuthMethodProfile, authConfig); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if factory.oidc.registered != nil {
+		t.Error("Expected no SSO sign in")
+	}
+
+	err := provider.Authenticate(context.Background(), AuthMethodSSO, authConfig)
+	if !errors.Is(err, ErrNotSSOProfile) {
+		t.Errorf("Expected ErrNotSSOProfile, got %v", err)
+	}
+}
+
+// TestCompleteSSODeviceLogin tests polling for the token of a device authorization
+func TestCompleteSSODeviceLogin(t *testing.T) {
+	settings := ssoSettings{startURL: "https://corp.awsapps.com/start", region: "us-east-1"}
+	newLogin := func() *ssoDeviceLogin {
+		return &ssoDeviceLogin{interval: time.Millisecond, expiresAt: time.Now().Add(time.Minute)}
+	}
+
+	oidc := &fakeSSOOIDC{pending: 2}
+	token, err := completeSSODeviceLogin(context.Background(), oidc, settings, newLogin())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if oidc.tokenCalls != 3 {
+		t.Errorf("Expected to poll until approved, got %d calls", oidc.tokenCalls)
+	}
+	if token.RefreshToken != "" || token.ClientID != "" {
+		t.Errorf("Expected legacy profiles not to cache the client registration, got %+v", token)
+	}
+
+	tests := []struct {
+		name     string
+		tokenErr error
+		expected error
+	}{
+		{"denied", &types.AccessDeniedException{}, ErrSSOLoginDenied},
+		{"expired", &types.ExpiredTokenException{}, ErrSSOLoginExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := completeSSODeviceLogin(context.Background(), &fakeSSOOIDC{tokenErr: tt.tokenErr}, settings, newLogin())
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+
+	// Cancelling stops polling
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := completeSSODeviceLogin(ctx, &fakeSSOOIDC{pending: 100}, settings, newLogin()); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the sign in to be cancelled, got %v", err)
+	}
+}
+
+// TestSSOTokenIsValid tests when a cached token can still be used
+func TestSSOTokenIsValid(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	later := now.Add(time.Hour).Format(time.RFC3339)
+	soon := now.Add(time.Minute).Format(time.RFC3339)
+	earlier := now.Add(-time.Hour).Format(time.RFC3339)
+	session := ssoSettings{session: "corp", startURL: "https://corp.awsapps.com/start"}
+	legacy := ssoSettings{startURL: "https://corp.awsapps.com/start"}
+
+	tests := []struct {
+		name     string
+		token    *ssoToken
+		settings ssoSettings
+		expected bool
+	}{
+		{"missing", nil, session, false},
+		{"valid", &ssoToken{StartURL: session.startURL, AccessToken: "a", ExpiresAt: later}, session, true},
+		{"expiring", &ssoToken{StartURL: session.startURL, AccessToken: "a", ExpiresAt: soon}, legacy, false},
+		{"other start URL", &ssoToken{StartURL: "https://other.awsapps.com/start", AccessToken: "a", ExpiresAt: later}, session, false},
+		{"refreshable", &ssoToken{StartURL: session.startURL, AccessToken: "a", ExpiresAt: soon,
+			ClientID: "c", ClientSecret: "s", RefreshToken: "r", RegistrationExpiresAt: later}, session, true},
+		{"registration expired", &ssoToken{StartURL: session.startURL, AccessToken: "a", ExpiresAt: soon,
+			ClientID: "c", ClientSecret: "s", RefreshToken: "r", RegistrationExpiresAt: earlier}, session, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if valid := tt.token.isValid(tt.settings, now); valid != tt.expected {
+				t.Errorf("Expected valid to be %v, got %v", tt.expected, valid)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package cloud
+
+import (
+	"sync"
+	"time"
+)
+
+// CacheKey identifies the result of a provider read
+type CacheKey struct {
+	Provider  string
+	Profile   string
+	Region    string
+	Operation string
+}
+
+// cacheEntry is a cached read result with its expiry time
+type cacheEntry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+// ReadCache is an in-memory cache of provider reads whose entries expire after a TTL
+type ReadCache struct {
+	entries map[CacheKey]cacheEntry
+	now     func() time.Time
+	mu      sync.Mutex
+}
+
+// NewReadCache creates a new read cache
+func NewReadCache() *ReadCache {
+	return &ReadCache{
+		entries: make(map[CacheKey]cacheEntry),
+		now:     time.Now,
+	}
+}
+
+// Get returns the cached value of a key, or false when it is missing or expired
+func (c *ReadCache) Get(key CacheKey) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// Set caches the value of a key for the given TTL
+func (c *ReadCache) Set(key CacheKey, value interface{}, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = cacheEntry{value: value, expiresAt: c.now().Add(ttl)}
+}
+
+// Invalidate removes the cached values of an operation for every provider, profile
+// and region, so that the next read fetches them again
+func (c *ReadCache) Invalidate(operation string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.entries {
+		if key.Operation == operation {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// Clear removes every cached value, so that reads made with new credentials never
+// return those of another account
+func (c *ReadCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[CacheKey]cacheEntry)
+}
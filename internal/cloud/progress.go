@@ -0,0 +1,28 @@
+package cloud
+
+import "context"
+
+// Progress represents how many items of a long-running read have loaded. Total is
+// zero while the number of items is not known yet.
+type Progress struct {
+	Loaded int
+	Total  int
+}
+
+// ProgressFunc receives the progress of a long-running read
+type ProgressFunc func(progress Progress)
+
+// progressKey is the context key of the ProgressFunc of a read
+type progressKey struct{}
+
+// WithProgress returns a context whose reads report their progress to fn
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+// ReportProgress reports the progress of a read to the ProgressFunc of the context, if any
+func ReportProgress(ctx context.Context, progress Progress) {
+	if fn, ok := ctx.Value(progressKey{}).(ProgressFunc); ok && fn != nil {
+		fn(progress)
+	}
+}
@@ -2,6 +2,8 @@ package cloud
 
 import (
 	"context"
+	"fmt"
+	"time"
 )
 
 // Provider represents a cloud provider.
@@ -18,6 +20,9 @@ type Provider interface {
 	// GetProfiles returns all available profiles for this provider.
 	GetProfiles() ([]string, error)
 
+	// GetProfileDetails returns the configured details of all available profiles.
+	GetProfileDetails() ([]ProfileDetails, error)
+
 	// LoadConfig loads the provider configuration with the given profile and region.
 	LoadConfig(profile, region string) error
 
@@ -33,18 +38,49 @@ type Provider interface {
 	// GetStartPipelineOperation returns the start pipeline operation
 	GetStartPipelineOperation() (StartPipelineOperation, error)
 
+	// GetExecutionHistoryOperation returns the pipeline execution history operation
+	GetExecutionHistoryOperation() (ExecutionHistoryOperation, error)
+
+	// GetStopPipelineExecutionOperation returns the stop pipeline execution operation
+	GetStopPipelineExecutionOperation() (StopPipelineExecutionOperation, error)
+
+	// GetRetryStageExecutionOperation returns the retry stage execution operation
+	GetRetryStageExecutionOperation() (RetryStageExecutionOperation, error)
+
+	// GetApprovalsInboxOperation returns the cross-account approvals inbox operation
+	GetApprovalsInboxOperation() (ApprovalsInboxOperation, error)
+
+	// GetStageTransitionOperation returns the stage transition operation
+	GetStageTransitionOperation() (StageTransitionOperation, error)
+
+	// GetRollbackStageOperation returns the rollback stage operation
+	GetRollbackStageOperation() (RollbackStageOperation, error)
+
+	// GetOverrideStageConditionOperation returns the override stage condition operation
+	GetOverrideStageConditionOperation() (OverrideStageConditionOperation, error)
+
+	// GetPipelineDefinitionOperation returns the pipeline definition operation
+	GetPipelineDefinitionOperation() (PipelineDefinitionOperation, error)
+
+	// GetExecutionWatchOperation returns the pipeline execution watch operation
+	GetExecutionWatchOperation() (ExecutionWatchOperation, error)
+
 	// GetAuthenticationMethods returns available authentication methods
 	GetAuthenticationMethods() []string
 
 	// GetAuthConfigKeys returns configuration keys for the specified authentication method
 	GetAuthConfigKeys(method string) []string
 
-	// Authenticate authenticates using the provided method and configuration
-	Authenticate(method string, authConfig map[string]string) error
+	// Authenticate authenticates using the provided method and configuration, signing
+	// in first when the method needs it
+	Authenticate(ctx context.Context, method string, authConfig map[string]string) error
 
 	// IsAuthenticated checks if the provider is authenticated
 	IsAuthenticated() bool
 
+	// GetCallerIdentity returns the identity the provider signs its requests as
+	GetCallerIdentity(ctx context.Context) (*CallerIdentity, error)
+
 	// GetConfigKeys returns required configuration keys
 	GetConfigKeys() []string
 
@@ -64,7 +100,7 @@ type Provider interface {
 	GetStatus(ctx context.Context) ([]PipelineStatus, error)
 
 	// StartPipeline starts a pipeline execution
-	StartPipeline(ctx context.Context, pipelineName string, commitID string) error
+	StartPipeline(ctx context.Context, pipelineName string, sourceRevisions []SourceRevision, variables []PipelineVariable) error
 }
 
 // Service represents a cloud service.
@@ -127,6 +163,81 @@ type ApprovalAction struct {
 	StageName    string
 	ActionName   string
 	Token        string
+	// Profile and Region are where the approval was found, AccountID is the
+	// account that owns the pipeline
+	Profile   string
+	Region    string
+	AccountID string
+	// ExecutionID is the ID of the pipeline execution waiting for the approval
+	ExecutionID        string
+	CustomData         string
+	ExternalEntityLink string
+	// RequestedAt is when the approval started waiting and ExpiresAt is when its
+	// token expires; both are zero when CodePipeline does not report them
+	RequestedAt     time.Time
+	ExpiresAt       time.Time
+	SourceRevisions []ExecutionSourceRevision
+}
+
+// ApprovalTarget represents a profile and region to look for pending approvals in
+type ApprovalTarget struct {
+	Profile string
+	Region  string
+}
+
+// ApprovalTargetError reports a target whose pending approvals could not be loaded
+type ApprovalTargetError struct {
+	Target ApprovalTarget
+	Err    error
+}
+
+// Error returns the error message
+func (e *ApprovalTargetError) Error() string {
+	return fmt.Sprintf("%s/%s: %v", e.Target.Profile, e.Target.Region, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *ApprovalTargetError) Unwrap() error {
+	return e.Err
+}
+
+// ProfileDetails represents the configured details of a provider profile
+type ProfileDetails struct {
+	Name     string
+	Region   string
+	Account  string
+	Role     string
+	AuthType string
+	// AuthMethod is the authentication method of the provider the profile signs in with
+	AuthMethod string
+	// MFADevice is the MFA device whose code the profile signs in with, if any
+	MFADevice string
+}
+
+// CallerIdentity represents the account and principal a provider signs its requests as
+type CallerIdentity struct {
+	Account string
+	// AccountAlias is the alias of the account, if it has one the caller may list
+	AccountAlias string
+	// ARN is the ARN of the principal, such as the assumed role of the session
+	ARN    string
+	UserID string
+}
+
+// PipelineError reports a pipeline whose details could not be loaded
+type PipelineError struct {
+	Pipeline string
+	Err      error
+}
+
+// Error returns the error message
+func (e *PipelineError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Pipeline, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *PipelineError) Unwrap() error {
+	return e.Err
 }
 
 // StageStatus represents the status of a pipeline stage
@@ -134,14 +245,315 @@ type StageStatus struct {
 	Name        string
 	Status      string
 	LastUpdated string
+	// ExecutionID is the ID of the pipeline execution the stage last ran in
+	ExecutionID string
+	Actions     []ActionStatus
+	// InboundTransition is nil for the first stage, which has no inbound transition
+	InboundTransition *TransitionState
+	Conditions        []ConditionStatus
 }
 
+// Stage condition types. Only failed before-entry and on-success conditions can be overridden.
+const (
+	ConditionTypeBeforeEntry = "BEFORE_ENTRY"
+	ConditionTypeOnSuccess   = "ON_SUCCESS"
+	ConditionTypeOnFailure   = "ON_FAILURE"
+)
+
+// ConditionStatus represents the latest result of a stage's conditions of one type
+type ConditionStatus struct {
+	Type    string
+	Status  string
+	Summary string
+}
+
+// TransitionState represents the state of the transition into a pipeline stage
+type TransitionState struct {
+	Enabled        bool
+	DisabledReason string
+	LastChangedBy  string
+	LastChangedAt  string
+}
+
+// ActionStatus represents the status of an action in a pipeline stage
+type ActionStatus struct {
+	Name                 string
+	Status               string
+	Category             string
+	Provider             string
+	LastStatusChange     string
+	ExternalExecutionID  string
+	ExternalExecutionURL string
+	EntityURL            string
+	ErrorCode            string
+	ErrorMessage         string
+	Summary              string
+	// RunOrder is the declared run order; actions with the same run order run in parallel
+	RunOrder int
+}
+
+// Stage retry modes that select which actions of a failed stage are retried
+const (
+	RetryModeFailedActions = "FAILED_ACTIONS"
+	RetryModeAllActions    = "ALL_ACTIONS"
+)
+
 // PipelineStatus represents the status of a pipeline and its stages
 type PipelineStatus struct {
 	Name   string
 	Stages []StageStatus
 }
 
+// Source revision types that can be used to override a source action's revision
+const (
+	RevisionTypeCommitID          = "COMMIT_ID"
+	RevisionTypeImageDigest       = "IMAGE_DIGEST"
+	RevisionTypeS3ObjectVersionID = "S3_OBJECT_VERSION_ID"
+)
+
+// SourceAction represents a source action declared in a pipeline
+type SourceAction struct {
+	Name     string
+	Provider string
+	// RevisionType is the revision type accepted by the action's provider,
+	// or empty if the provider does not support revision overrides
+	RevisionType string
+}
+
+// SourceRevision represents a source revision override for a pipeline execution
+type SourceRevision struct {
+	ActionName    string
+	RevisionType  string
+	RevisionValue string
+}
+
+// PipelineVariable represents a pipeline-level variable declared in a V2 pipeline
+type PipelineVariable struct {
+	Name         string
+	Value        string
+	DefaultValue string
+	Description  string
+}
+
+// ExecutionStatusPending is the status of a stage or action that has not run yet in
+// the execution being watched
+const ExecutionStatusPending = "Pending"
+
+// ExecutionProgress represents the progress of a pipeline execution
+type ExecutionProgress struct {
+	ExecutionID   string
+	Status        string
+	StatusSummary string
+	Stages        []StageProgress
+	// Definition is the structure of the pipeline version the execution runs with
+	Definition *PipelineDefinition
+}
+
+// StageProgress represents the progress of a stage in a pipeline execution
+type StageProgress struct {
+	Name   string
+	Status string
+	// StartTime is zero until an action of the stage starts, and EndTime is zero
+	// until the stage stops running
+	StartTime time.Time
+	EndTime   time.Time
+	Actions   []ActionStatus
+}
+
+// PipelineExecution represents an execution in a pipeline's execution history
+type PipelineExecution struct {
+	ExecutionID     string
+	Status          string
+	StatusSummary   string
+	TriggerType     string
+	TriggerDetail   string
+	SourceRevisions []ExecutionSourceRevision
+	StartTime       string
+	// EndTime and Duration are empty while the execution is still running
+	EndTime  string
+	Duration string
+}
+
+// ExecutionSourceRevision represents the source revision a pipeline execution ran with
+type ExecutionSourceRevision struct {
+	ActionName      string
+	RevisionID      string
+	RevisionSummary string
+	RevisionURL     string
+}
+
+// PipelineDefinition represents the declared structure of a pipeline. The JSON field
+// names follow the pipeline structure returned by the AWS CLI, so exported definitions
+// can be compared with definitions kept alongside the code and passed back to
+// update-pipeline.
+type PipelineDefinition struct {
+	Name           string                             `json:"name"`
+	RoleArn        string                             `json:"roleArn"`
+	ArtifactStore  *ArtifactStoreDefinition           `json:"artifactStore,omitempty"`
+	ArtifactStores map[string]ArtifactStoreDefinition `json:"artifactStores,omitempty"`
+	Stages         []StageDefinition                  `json:"stages"`
+	Version        int32                              `json:"version"`
+	ExecutionMode  string                             `json:"executionMode,omitempty"`
+	PipelineType   string                             `json:"pipelineType,omitempty"`
+	Triggers       []TriggerDefinition                `json:"triggers,omitempty"`
+	Variables      []VariableDefinition               `json:"variables,omitempty"`
+}
+
+// ArtifactStoreDefinition represents where a pipeline stores its artifacts. Pipelines
+// with cross-region actions keep an artifact store per region.
+type ArtifactStoreDefinition struct {
+	Type          string                   `json:"type"`
+	Location      string                   `json:"location"`
+	EncryptionKey *EncryptionKeyDefinition `json:"encryptionKey,omitempty"`
+}
+
+// EncryptionKeyDefinition represents the key encrypting the artifacts of an artifact store
+type EncryptionKeyDefinition struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// TriggerDefinition represents a trigger starting a pipeline on changes to its source
+type TriggerDefinition struct {
+	ProviderType     string                      `json:"providerType"`
+	GitConfiguration *GitConfigurationDefinition `json:"gitConfiguration,omitempty"`
+}
+
+// GitConfigurationDefinition represents the Git events a trigger starts the pipeline on
+type GitConfigurationDefinition struct {
+	SourceActionName string                           `json:"sourceActionName"`
+	Push             []GitPushFilterDefinition        `json:"push,omitempty"`
+	PullRequest      []GitPullRequestFilterDefinition `json:"pullRequest,omitempty"`
+}
+
+// GitPushFilterDefinition represents the pushes a trigger starts the pipeline on
+type GitPushFilterDefinition struct {
+	Tags      *GitFilterCriteria `json:"tags,omitempty"`
+	Branches  *GitFilterCriteria `json:"branches,omitempty"`
+	FilePaths *GitFilterCriteria `json:"filePaths,omitempty"`
+}
+
+// GitPullRequestFilterDefinition represents the pull request events a trigger starts
+// the pipeline on
+type GitPullRequestFilterDefinition struct {
+	Events    []string           `json:"events,omitempty"`
+	Branches  *GitFilterCriteria `json:"branches,omitempty"`
+	FilePaths *GitFilterCriteria `json:"filePaths,omitempty"`
+}
+
+// GitFilterCriteria represents the branch, tag or file path patterns a Git filter
+// includes and excludes
+type GitFilterCriteria struct {
+	Includes []string `json:"includes,omitempty"`
+	Excludes []string `json:"excludes,omitempty"`
+}
+
+// VariableDefinition represents a pipeline-level variable declared in a pipeline definition
+type VariableDefinition struct {
+	Name         string `json:"name"`
+	DefaultValue string `json:"defaultValue,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+// StageDefinition represents a stage declared in a pipeline definition
+type StageDefinition struct {
+	Name        string                       `json:"name"`
+	Blockers    []BlockerDefinition          `json:"blockers,omitempty"`
+	Actions     []ActionDefinition           `json:"actions"`
+	OnFailure   *FailureConditionsDefinition `json:"onFailure,omitempty"`
+	OnSuccess   *ConditionsDefinition        `json:"onSuccess,omitempty"`
+	BeforeEntry *ConditionsDefinition        `json:"beforeEntry,omitempty"`
+}
+
+// BlockerDefinition represents a gate blocking the transition into a stage
+type BlockerDefinition struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// FailureConditionsDefinition represents what a stage does when it fails
+type FailureConditionsDefinition struct {
+	Result             string                        `json:"result,omitempty"`
+	RetryConfiguration *RetryConfigurationDefinition `json:"retryConfiguration,omitempty"`
+	Conditions         []ConditionDefinition         `json:"conditions,omitempty"`
+}
+
+// RetryConfigurationDefinition represents how a failed stage is retried
+type RetryConfigurationDefinition struct {
+	RetryMode string `json:"retryMode"`
+}
+
+// ConditionsDefinition represents the conditions checked before entering a stage or
+// after it succeeds
+type ConditionsDefinition struct {
+	Conditions []ConditionDefinition `json:"conditions"`
+}
+
+// ConditionDefinition represents the rules of a stage condition and the result of the
+// stage when they are not met
+type ConditionDefinition struct {
+	Result string           `json:"result,omitempty"`
+	Rules  []RuleDefinition `json:"rules,omitempty"`
+}
+
+// RuleDefinition represents a rule checked by a stage condition
+type RuleDefinition struct {
+	Name             string               `json:"name"`
+	RuleTypeID       RuleTypeID           `json:"ruleTypeId"`
+	Configuration    map[string]string    `json:"configuration,omitempty"`
+	Commands         []string             `json:"commands,omitempty"`
+	InputArtifacts   []ArtifactDefinition `json:"inputArtifacts,omitempty"`
+	Region           string               `json:"region,omitempty"`
+	RoleArn          string               `json:"roleArn,omitempty"`
+	TimeoutInMinutes int32                `json:"timeoutInMinutes,omitempty"`
+}
+
+// RuleTypeID identifies the kind of a rule
+type RuleTypeID struct {
+	Category string `json:"category"`
+	Owner    string `json:"owner,omitempty"`
+	Provider string `json:"provider"`
+	Version  string `json:"version,omitempty"`
+}
+
+// ActionDefinition represents an action declared in a pipeline stage
+type ActionDefinition struct {
+	Name                 string                          `json:"name"`
+	ActionTypeID         ActionTypeID                    `json:"actionTypeId"`
+	RunOrder             int32                           `json:"runOrder"`
+	Configuration        map[string]string               `json:"configuration,omitempty"`
+	Commands             []string                        `json:"commands,omitempty"`
+	OutputArtifacts      []ArtifactDefinition            `json:"outputArtifacts"`
+	InputArtifacts       []ArtifactDefinition            `json:"inputArtifacts"`
+	OutputVariables      []string                        `json:"outputVariables,omitempty"`
+	Region               string                          `json:"region,omitempty"`
+	Namespace            string                          `json:"namespace,omitempty"`
+	RoleArn              string                          `json:"roleArn,omitempty"`
+	TimeoutInMinutes     int32                           `json:"timeoutInMinutes,omitempty"`
+	EnvironmentVariables []EnvironmentVariableDefinition `json:"environmentVariables,omitempty"`
+}
+
+// ActionTypeID identifies the kind of an action
+type ActionTypeID struct {
+	Category string `json:"category"`
+	Owner    string `json:"owner"`
+	Provider string `json:"provider"`
+	Version  string `json:"version"`
+}
+
+// ArtifactDefinition represents an artifact an action consumes or produces. Files
+// lists the files of an output artifact of a commands action.
+type ArtifactDefinition struct {
+	Name  string   `json:"name"`
+	Files []string `json:"files,omitempty"`
+}
+
+// EnvironmentVariableDefinition represents an environment variable of a commands action
+type EnvironmentVariableDefinition struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
 // FunctionStatus represents the status of a Lambda function
 type FunctionStatus struct {
 	Name         string
@@ -164,18 +576,36 @@ type FunctionStatus struct {
 type CodePipelineManualApprovalOperation interface {
 	UIOperation
 
-	// GetPendingApprovals returns all pending manual approval actions
+	// GetPendingApprovals returns all pending manual approval actions. When some pipelines
+	// fail to load, the approvals of the others are returned together with an error
+	// joining a *PipelineError for each failed pipeline.
 	GetPendingApprovals(ctx context.Context) ([]ApprovalAction, error)
 
 	// ApproveAction approves or rejects an approval action
 	ApproveAction(ctx context.Context, action ApprovalAction, approved bool, comment string) error
 }
 
+// ApprovalsInboxOperation represents an operation to manage pending approvals across profiles and regions
+type ApprovalsInboxOperation interface {
+	UIOperation
+
+	// GetPendingApprovals returns the pending approvals of all targets. When some targets or
+	// pipelines fail, the approvals of the others are returned together with an error joining
+	// an *ApprovalTargetError for each failure, wrapping a *PipelineError when only a
+	// pipeline of the target failed.
+	GetPendingApprovals(ctx context.Context, targets []ApprovalTarget) ([]ApprovalAction, error)
+
+	// ApproveAction approves or rejects an approval action in the profile and region it was found in
+	ApproveAction(ctx context.Context, action ApprovalAction, approved bool, comment string) error
+}
+
 // PipelineStatusOperation represents an operation to view pipeline status
 type PipelineStatusOperation interface {
 	UIOperation
 
-	// GetPipelineStatus returns the status of all pipelines
+	// GetPipelineStatus returns the status of all pipelines. When some pipelines fail to
+	// load, the status of the others is returned together with an error joining a
+	// *PipelineError for each failed pipeline.
 	GetPipelineStatus(ctx context.Context) ([]PipelineStatus, error)
 }
 
@@ -183,8 +613,16 @@ type PipelineStatusOperation interface {
 type StartPipelineOperation interface {
 	UIOperation
 
-	// StartPipelineExecution starts a pipeline execution
-	StartPipelineExecution(ctx context.Context, pipelineName string, commitID string) error
+	// GetSourceActions returns the source actions declared in a pipeline
+	GetSourceActions(ctx context.Context, pipelineName string) ([]SourceAction, error)
+
+	// GetPipelineVariables returns the pipeline-level variables declared in a pipeline,
+	// with their values pre-filled from the declared defaults
+	GetPipelineVariables(ctx context.Context, pipelineName string) ([]PipelineVariable, error)
+
+	// StartPipelineExecution starts a pipeline execution, overriding the given source revisions
+	// and pipeline variables, and returns the ID of the new execution
+	StartPipelineExecution(ctx context.Context, pipelineName string, sourceRevisions []SourceRevision, variables []PipelineVariable) (string, error)
 }
 
 // FunctionStatusOperation represents an operation to view Lambda function status
@@ -194,3 +632,78 @@ type FunctionStatusOperation interface {
 	// GetFunctionStatus returns the status of all Lambda functions
 	GetFunctionStatus(ctx context.Context) ([]FunctionStatus, error)
 }
+
+// ExecutionHistoryOperation represents an operation to view the execution history of a pipeline
+type ExecutionHistoryOperation interface {
+	UIOperation
+
+	// GetPipelineExecutions returns a page of a pipeline's executions, most recent first,
+	// and the token of the next page, which is empty on the last page
+	GetPipelineExecutions(ctx context.Context, pipelineName, nextToken string) ([]PipelineExecution, string, error)
+}
+
+// ExecutionWatchOperation represents an operation to follow the progress of a pipeline execution
+type ExecutionWatchOperation interface {
+	UIOperation
+
+	// GetExecutionProgress returns the status of a pipeline execution and of each stage
+	// and action in it. The previous progress of the same execution, when given, saves
+	// loading the pipeline structure and the stages that had already finished again.
+	GetExecutionProgress(ctx context.Context, pipelineName, executionID string, previous *ExecutionProgress) (*ExecutionProgress, error)
+}
+
+// StopPipelineExecutionOperation represents an operation to stop a running pipeline execution
+type StopPipelineExecutionOperation interface {
+	UIOperation
+
+	// StopPipelineExecution stops a pipeline execution, either waiting for in-progress
+	// actions to finish or abandoning them
+	StopPipelineExecution(ctx context.Context, pipelineName, executionID string, abandon bool, reason string) error
+}
+
+// RetryStageExecutionOperation represents an operation to retry a failed stage
+type RetryStageExecutionOperation interface {
+	UIOperation
+
+	// RetryStageExecution retries the failed or all actions of a stage in a pipeline execution
+	RetryStageExecution(ctx context.Context, pipelineName, stageName, executionID, retryMode string) error
+}
+
+// RollbackStageOperation represents an operation to roll a stage back to a previous execution
+type RollbackStageOperation interface {
+	UIOperation
+
+	// GetRollbackTargets returns a page of the executions that succeeded in the stage, most
+	// recent first, and the token of the next page
+	GetRollbackTargets(ctx context.Context, pipelineName, stageName, nextToken string) ([]PipelineExecution, string, error)
+
+	// RollbackStage rolls the stage back to the target execution and returns the ID of the rollback execution
+	RollbackStage(ctx context.Context, pipelineName, stageName, targetExecutionID string) (string, error)
+}
+
+// OverrideStageConditionOperation represents an operation to override a failed stage condition
+type OverrideStageConditionOperation interface {
+	UIOperation
+
+	// OverrideStageCondition skips the failed conditions of a type in a pipeline execution
+	OverrideStageCondition(ctx context.Context, pipelineName, stageName, executionID, conditionType string) error
+}
+
+// PipelineDefinitionOperation represents an operation to view the definition of a pipeline
+type PipelineDefinitionOperation interface {
+	UIOperation
+
+	// GetPipelineDefinition returns the declared structure of a pipeline
+	GetPipelineDefinition(ctx context.Context, pipelineName string) (*PipelineDefinition, error)
+}
+
+// StageTransitionOperation represents an operation to enable or disable the transition into a stage
+type StageTransitionOperation interface {
+	UIOperation
+
+	// EnableStageTransition lets pipeline executions move into the stage again
+	EnableStageTransition(ctx context.Context, pipelineName, stageName string) error
+
+	// DisableStageTransition keeps pipeline executions from moving into the stage
+	DisableStageTransition(ctx context.Context, pipelineName, stageName, reason string) error
+}
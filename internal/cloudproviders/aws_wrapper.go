@@ -45,6 +45,11 @@ func (w *AWSProviderWrapper) GetProfiles() ([]string, error) {
 	return w.provider.GetProfiles()
 }
 
+// GetProfileDetails returns the configured details of all available profiles
+func (w *AWSProviderWrapper) GetProfileDetails() ([]cloud.ProfileDetails, error) {
+	return w.provider.GetProfileDetails()
+}
+
 // LoadConfig loads the provider configuration with the given profile and region
 func (w *AWSProviderWrapper) LoadConfig(profile, region string) error {
 	// Load the config in the wrapped provider
@@ -60,11 +65,11 @@ func (w *AWSProviderWrapper) LoadConfig(profile, region string) error {
 	// Let the provider load its services
 	// Then we'll create our own service wrappers
 	lambdaService := &LambdaServiceWrapper{
-		service: lambda.NewService(profile, region),
+		service: lambda.NewService(profile, region, w.provider.Clients()),
 	}
 
 	codePipelineService := &CodePipelineServiceWrapper{
-		service: codepipeline.NewService(profile, region),
+		service: codepipeline.NewService(profile, region, w.provider.Clients()),
 	}
 
 	// Set the services
@@ -152,6 +157,51 @@ func (w *AWSProviderWrapper) GetStartPipelineOperation() (cloud.StartPipelineOpe
 	return w.provider.GetStartPipelineOperation()
 }
 
+// GetExecutionHistoryOperation returns the pipeline execution history operation
+func (w *AWSProviderWrapper) GetExecutionHistoryOperation() (cloud.ExecutionHistoryOperation, error) {
+	return w.provider.GetExecutionHistoryOperation()
+}
+
+// GetStopPipelineExecutionOperation returns the stop pipeline execution operation
+func (w *AWSProviderWrapper) GetStopPipelineExecutionOperation() (cloud.StopPipelineExecutionOperation, error) {
+	return w.provider.GetStopPipelineExecutionOperation()
+}
+
+// GetRetryStageExecutionOperation returns the retry stage execution operation
+func (w *AWSProviderWrapper) GetRetryStageExecutionOperation() (cloud.RetryStageExecutionOperation, error) {
+	return w.provider.GetRetryStageExecutionOperation()
+}
+
+// GetApprovalsInboxOperation returns the cross-account approvals inbox operation
+func (w *AWSProviderWrapper) GetApprovalsInboxOperation() (cloud.ApprovalsInboxOperation, error) {
+	return w.provider.GetApprovalsInboxOperation()
+}
+
+// GetStageTransitionOperation returns the stage transition operation
+func (w *AWSProviderWrapper) GetStageTransitionOperation() (cloud.StageTransitionOperation, error) {
+	return w.provider.GetStageTransitionOperation()
+}
+
+// GetRollbackStageOperation returns the rollback stage operation
+func (w *AWSProviderWrapper) GetRollbackStageOperation() (cloud.RollbackStageOperation, error) {
+	return w.provider.GetRollbackStageOperation()
+}
+
+// GetOverrideStageConditionOperation returns the override stage condition operation
+func (w *AWSProviderWrapper) GetOverrideStageConditionOperation() (cloud.OverrideStageConditionOperation, error) {
+	return w.provider.GetOverrideStageConditionOperation()
+}
+
+// GetPipelineDefinitionOperation returns the pipeline definition operation
+func (w *AWSProviderWrapper) GetPipelineDefinitionOperation() (cloud.PipelineDefinitionOperation, error) {
+	return w.provider.GetPipelineDefinitionOperation()
+}
+
+// GetExecutionWatchOperation returns the pipeline execution watch operation
+func (w *AWSProviderWrapper) GetExecutionWatchOperation() (cloud.ExecutionWatchOperation, error) {
+	return w.provider.GetExecutionWatchOperation()
+}
+
 // GetAuthenticationMethods returns the available authentication methods
 func (w *AWSProviderWrapper) GetAuthenticationMethods() []string {
 	return w.provider.GetAuthenticationMethods()
@@ -162,9 +212,13 @@ func (w *AWSProviderWrapper) GetAuthConfigKeys(method string) []string {
 	return w.provider.GetAuthConfigKeys(method)
 }
 
-// Authenticate authenticates with the provider using the given method and configuration
-func (w *AWSProviderWrapper) Authenticate(method string, authConfig map[string]string) error {
-	return w.provider.Authenticate(method, authConfig)
+// Authenticate authenticates with the provider using the given method and configuration,
+// then loads the services of the authenticated profile and region
+func (w *AWSProviderWrapper) Authenticate(ctx context.Context, method string, authConfig map[string]string) error {
+	if err := w.provider.Authenticate(ctx, method, authConfig); err != nil {
+		return err
+	}
+	return w.LoadConfig(authConfig["profile"], authConfig["region"])
 }
 
 // IsAuthenticated returns whether the provider is authenticated
@@ -172,6 +226,11 @@ func (w *AWSProviderWrapper) IsAuthenticated() bool {
 	return w.provider.IsAuthenticated()
 }
 
+// GetCallerIdentity returns the identity the provider signs its requests as
+func (w *AWSProviderWrapper) GetCallerIdentity(ctx context.Context) (*cloud.CallerIdentity, error) {
+	return w.provider.GetCallerIdentity(ctx)
+}
+
 // GetConfigKeys returns the configuration keys required by this provider
 func (w *AWSProviderWrapper) GetConfigKeys() []string {
 	return w.provider.GetConfigKeys()
@@ -217,6 +276,6 @@ func (w *AWSProviderWrapper) GetStatus(ctx context.Context) ([]cloud.PipelineSta
 }
 
 // StartPipeline starts a pipeline execution
-func (w *AWSProviderWrapper) StartPipeline(ctx context.Context, pipelineName string, commitID string) error {
-	return w.provider.StartPipeline(ctx, pipelineName, commitID)
+func (w *AWSProviderWrapper) StartPipeline(ctx context.Context, pipelineName string, sourceRevisions []cloud.SourceRevision, variables []cloud.PipelineVariable) error {
+	return w.provider.StartPipeline(ctx, pipelineName, sourceRevisions, variables)
 }
@@ -0,0 +1,21 @@
+package constants
+
+import "time"
+
+// Cache operation constants name the provider reads kept in the read cache
+const (
+	CachePipelineStatus = "PipelineStatus"
+	CacheApprovals      = "Approvals"
+	CacheInboxApprovals = "InboxApprovals"
+	CacheFunctionStatus = "FunctionStatus"
+)
+
+// Cache TTL constants
+const (
+	// PipelineStatusCacheTTL is how long the pipeline status of an account is reused
+	PipelineStatusCacheTTL = 30 * time.Second
+	// ApprovalsCacheTTL is how long the pending approvals of an account are reused
+	ApprovalsCacheTTL = 15 * time.Second
+	// FunctionStatusCacheTTL is how long the function status of an account is reused
+	FunctionStatusCacheTTL = 2 * time.Minute
+)
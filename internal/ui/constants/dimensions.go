@@ -8,7 +8,9 @@ const (
 	TableDefaultWidth = 30
 	TableWideWidth    = 40
 	TableNarrowWidth  = 20
+	TableShortWidth   = 12
 	TableDescWidth    = 50
+	TableMarkWidth    = 3
 
 	// Text input dimensions
 	TextInputWidth     = 50
@@ -0,0 +1,25 @@
+package constants
+
+import "time"
+
+// Polling interval and timeout constants
+const (
+	// ExecutionWatchInterval is how often a watched pipeline execution is polled
+	ExecutionWatchInterval = 5 * time.Second
+
+	// DefaultRefreshInterval is how often the status tables are refreshed
+	DefaultRefreshInterval = 30 * time.Second
+	// MinRefreshInterval is the shortest refresh interval accepted from the environment
+	MinRefreshInterval = 5 * time.Second
+	// EnvRefreshInterval overrides the refresh interval of the status tables with a
+	// duration such as "1m", or turns the refresh off with "0" or "off"
+	EnvRefreshInterval = "CLOUDGATE_REFRESH_INTERVAL"
+
+	// DefaultRequestTimeout is how long a provider request may take before it is given up
+	DefaultRequestTimeout = time.Minute
+	// MinRequestTimeout is the shortest request timeout accepted from the environment
+	MinRequestTimeout = time.Second
+	// EnvRequestTimeout overrides the request timeout with a duration such as "2m", or
+	// turns the timeout off with "0" or "off"
+	EnvRequestTimeout = "CLOUDGATE_REQUEST_TIMEOUT"
+)
@@ -27,12 +27,30 @@ const (
 	KeyAltPageUp       = "b"
 	KeyAltPageDown     = "f"
 	KeySpace           = " "
+
+	// Approval selection keys
+	KeyMark    = "x"
+	KeyMarkAll = "a"
+	KeyFilter  = "/"
+
+	// Pipeline stages keys
+	KeyGraph = "v"
+
+	// Refresh keys
+	KeyRefresh      = "r"
+	KeyPauseRefresh = "p"
 )
 
 // Authentication method constants
 const (
 	// AWS authentication methods
-	AWSProfileAuth = "profile"
+	AWSProfileAuth           = "profile"
+	AWSSSOAuth               = "sso"
+	AWSMFAAuth               = "mfa"
+	AWSEnvironmentAuth       = "environment"
+	AWSStaticKeysAuth        = "static_keys"
+	AWSCredentialProcessAuth = "credential_process"
+	AWSWebIdentityAuth       = "web_identity"
 
 	// Azure authentication methods (future)
 	AzureCliAuth       = "cli"
@@ -46,8 +64,11 @@ const (
 // Configuration key constants
 const (
 	// AWS configuration keys
-	AWSProfileKey = "profile"
-	AWSRegionKey  = "region"
+	AWSProfileKey         = "profile"
+	AWSRegionKey          = "region"
+	AWSMFACodeKey         = "mfa_code"
+	AWSSecretAccessKeyKey = "secret_access_key"
+	AWSSessionTokenKey    = "session_token"
 
 	// Azure configuration keys (future)
 	AzureSubscriptionKey = "subscription"
@@ -61,3 +82,14 @@ const (
 	GCPRegionKey         = "region"
 	GCPServiceAccountKey = "service-account-path"
 )
+
+// AuthConfigSignInRow is the row of the auth config view that authenticates with the
+// entered configuration
+const AuthConfigSignInRow = "Sign In"
+
+// SecretAuthConfigKeys are the auth config keys whose values are masked when entered
+// and shown
+var SecretAuthConfigKeys = map[string]bool{
+	AWSSecretAccessKeyKey: true,
+	AWSSessionTokenKey:    true,
+}
@@ -12,19 +12,62 @@ const (
 	MsgStartingPipeline  = "Starting pipeline..."
 	MsgExecutingApproval = "Executing approval action..."
 
+	MsgLoadingSourceActions     = "Loading source actions..."
+	MsgLoadingPipelineVariables = "Loading pipeline variables..."
+	MsgLoadingExecutions        = "Loading pipeline executions..."
+	MsgStoppingExecution        = "Stopping pipeline execution..."
+	MsgRetryingStage            = "Retrying stage..."
+	MsgEnablingTransition       = "Enabling stage transition..."
+	MsgDisablingTransition      = "Disabling stage transition..."
+	MsgLoadingRollbackTargets   = "Loading rollback targets..."
+	MsgRollingBackStage         = "Rolling back stage..."
+	MsgOverridingCondition      = "Overriding stage condition..."
+	MsgLoadingDefinition        = "Loading pipeline definition..."
+	MsgLoadingInboxApprovals    = "Loading approvals across accounts..."
+	MsgExecutingBulkApproval    = "Executing bulk approval..."
+	MsgRefreshing               = "Refreshing..."
+	MsgListedPipelines          = "Listed %d pipelines..."
+	MsgLoadedPipelines          = "Loaded %d of %d pipelines..."
+	MsgRequestCancelled         = "Request cancelled"
+	MsgSigningIn                = "Signing in with SSO..."
+	MsgAssumingRole             = "Assuming role with MFA..."
+	MsgAuthenticating           = "Authenticating..."
+	MsgWaitingForSignIn         = "Waiting for the sign in to be approved..."
+	MsgSignInInstructions       = "Open %s in your browser and confirm the code %s"
+	MsgAccountUnverified        = "could not be verified (%v)"
+
 	// Input placeholders
 	MsgEnterProfile          = "Enter AWS profile name..."
 	MsgEnterRegion           = "Enter AWS region..."
+	MsgEnterMFACode          = "Enter MFA code..."
 	MsgEnterComment          = "Enter comment..."
 	MsgEnterApprovalComment  = "Enter approval comment..."
 	MsgEnterRejectionComment = "Enter rejection comment..."
 	MsgEnterCommitID         = "Enter commit ID..."
+	MsgEnterImageDigest      = "Enter image digest (sha256:...)..."
+	MsgEnterS3ObjectVersion  = "Enter S3 object version ID..."
+	MsgEnterVariableValue    = "Enter value for %s..."
+	MsgEnterApprovalFilter   = "Filter by pipeline, stage or action..."
+	MsgEnterStopReason       = "Enter reason for stopping the execution..."
+	MsgEnterDisableReason    = "Enter reason for disabling the transition..."
+	MsgEnterStageNameConfirm = "Type %s to confirm..."
+	MsgEnterExportPath       = "Enter file to export the definition to..."
+	MsgEnterDiffPath         = "Enter local definition file to compare..."
 
 	// Success messages
 	MsgApprovalSuccess      = "Successfully approved pipeline: %s, stage: %s, action: %s"
 	MsgRejectionSuccess     = "Successfully rejected pipeline: %s, stage: %s, action: %s"
 	MsgPipelineStartSuccess = "Successfully started pipeline: %s"
 
+	MsgStopExecutionSuccess     = "Successfully stopped pipeline: %s, execution: %s"
+	MsgAbandonExecutionSuccess  = "Successfully abandoned pipeline: %s, execution: %s"
+	MsgRetryStageSuccess        = "Successfully retried pipeline: %s, stage: %s"
+	MsgEnableTransitionSuccess  = "Successfully enabled transition into pipeline: %s, stage: %s"
+	MsgDisableTransitionSuccess = "Successfully disabled transition into pipeline: %s, stage: %s"
+	MsgRollbackStageSuccess     = "Successfully started rollback of pipeline: %s, stage: %s, execution: %s"
+	MsgOverrideConditionSuccess = "Successfully overrode condition of pipeline: %s, stage: %s"
+	MsgDefinitionExportSuccess  = "Exported pipeline definition to %s"
+
 	// Error messages
 	MsgErrorGeneric       = "Error: %s"
 	MsgErrorNoApproval    = "No approval selected"
@@ -32,4 +75,22 @@ const (
 	MsgErrorNoFunction    = "No function selected"
 	MsgErrorEmptyCommitID = "Commit ID cannot be empty"
 	MsgErrorEmptyComment  = "Comment cannot be empty"
+	MsgErrorEmptyMFACode  = "MFA code cannot be empty"
+
+	MsgErrorNoSourceActions         = "Pipeline %s has no source actions"
+	MsgErrorUnsupportedSourceAction = "Source action %s (%s) does not support revision overrides"
+	MsgErrorNoPipelineVariables     = "Pipeline %s declares no pipeline variables"
+	MsgErrorNoExecution             = "No execution selected"
+	MsgErrorNoStage                 = "No stage selected"
+	MsgErrorEmptyStopReason         = "Stop reason cannot be empty"
+	MsgErrorEmptyDisableReason      = "Disable reason cannot be empty"
+	MsgErrorNoRollbackTargets       = "Stage %s has no earlier successful executions to roll back to"
+	MsgErrorStageNameMismatch       = "Entered name does not match stage %s"
+	MsgErrorEmptyFilePath           = "File path cannot be empty"
+	MsgErrorRequestTimedOut         = "Request timed out after %s"
+	MsgErrorUnsupportedFormat       = "Unsupported definition file %s, use a .json, .yaml or .yml file"
+	MsgErrorFileExists              = "File %s already exists, enter another path"
+	MsgErrorNoInboxProfiles         = "Select at least one profile for the approvals inbox"
+	MsgErrorNoInboxRegions          = "Select at least one region for the approvals inbox"
+	MsgErrorNoMarkedApprovals       = "No approvals marked"
 )
@@ -6,6 +6,9 @@ const (
 	TitleAWSConfig       = "AWS Configuration"
 	TitleSelectProfile   = "Select AWS Profile"
 	TitleSelectRegion    = "Select AWS Region"
+	TitleMFACode         = "Enter MFA Code"
+	TitleAuthMethod      = "Select Authentication Method"
+	TitleAuthConfig      = "Configure Authentication"
 	TitleSelectService   = "Select AWS Service"
 	TitleSelectCategory  = "Select Category"
 	TitleSelectOperation = "Select Operation"
@@ -20,4 +23,28 @@ const (
 	TitleHelp            = "Help"
 	TitleFunctionStatus  = "Lambda Functions"
 	TitleFunctionDetails = "Function Details"
+
+	TitleSelectSourceAction = "Select Source Action"
+	TitleSourceRevision     = "Enter Source Revision"
+	TitlePipelineVariables  = "Pipeline Variables"
+	TitleExecutionHistory   = "Execution History"
+	TitleExecutionDetails   = "Execution Details"
+	TitleExecutionWatch     = "Watch Execution"
+	TitlePipelineGraph      = "Pipeline Graph"
+	TitleStageOperations    = "Stage Operations"
+	TitleStopExecution      = "Stop Pipeline Execution"
+	TitleRetryStage         = "Retry Stage"
+	TitleStageTransition    = "Stage Transition"
+	TitleRollbackTargets    = "Select Rollback Target"
+	TitleRollbackStage      = "Rollback Stage"
+	TitleOverrideCondition  = "Override Stage Condition"
+	TitlePipelineDefinition = "Pipeline Definition"
+	TitleDefinitionDiff     = "Definition Diff"
+	TitleStageActions       = "Stage Actions"
+	TitleActionDetails      = "Action Details"
+	TitleInboxProfiles      = "Select Inbox Profiles"
+	TitleInboxRegions       = "Select Inbox Regions"
+	TitleApprovalsInbox     = "Approvals Inbox"
+	TitleBulkConfirmation   = "Bulk Approval"
+	TitleBulkResults        = "Bulk Approval Results"
 )
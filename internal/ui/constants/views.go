@@ -28,4 +28,37 @@ const (
 	// Lambda function views
 	ViewFunctionStatus
 	ViewFunctionDetails
+
+	// Pipeline start views
+	ViewSelectSourceAction
+	ViewPipelineVariables
+
+	// Pipeline execution history views
+	ViewExecutionHistory
+	ViewExecutionDetails
+	ViewExecutionWatch
+
+	// Pipeline stage views
+	ViewPipelineGraph
+	ViewStageOperations
+	ViewStopExecution
+	ViewRetryStage
+	ViewStageTransition
+	ViewRollbackTargets
+	ViewRollbackStage
+	ViewOverrideCondition
+	ViewStageActions
+	ViewActionDetails
+
+	// Pipeline definition views
+	ViewPipelineDefinition
+	ViewDefinitionDiff
+
+	// Approvals inbox views
+	ViewInboxProfiles
+	ViewInboxRegions
+
+	// Bulk approval views
+	ViewBulkConfirmation
+	ViewBulkResults
 )
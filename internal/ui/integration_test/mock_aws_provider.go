@@ -37,6 +37,8 @@ func (p *MockAWSProvider) Services() []cloud.Service {
 						&MockPipelineStatusOperation{},
 						&MockStartPipelineOperation{},
 						&MockCodePipelineManualApprovalOperation{},
+						&MockExecutionHistoryOperation{},
+						&MockPipelineDefinitionOperation{},
 					},
 				},
 			},
@@ -62,6 +64,15 @@ func (p *MockAWSProvider) GetProfiles() ([]string, error) {
 	return []string{"default", "dev", "prod"}, nil
 }
 
+// GetProfileDetails returns the details of the available profiles
+func (p *MockAWSProvider) GetProfileDetails() ([]cloud.ProfileDetails, error) {
+	return []cloud.ProfileDetails{
+		{Name: "default", Region: "us-east-1", AuthType: "Access keys"},
+		{Name: "dev", Region: "us-west-2", Account: "111111111111", Role: "Developer", AuthType: "SSO"},
+		{Name: "prod", Account: "222222222222", Role: "Deployer", AuthType: "Assume role"},
+	}, nil
+}
+
 // LoadConfig loads the provider configuration
 func (p *MockAWSProvider) LoadConfig(profile, region string) error {
 	p.profile = profile
@@ -89,6 +100,51 @@ func (p *MockAWSProvider) GetStartPipelineOperation() (cloud.StartPipelineOperat
 	return &MockStartPipelineOperation{}, nil
 }
 
+// GetExecutionHistoryOperation returns an operation for viewing pipeline execution history
+func (p *MockAWSProvider) GetExecutionHistoryOperation() (cloud.ExecutionHistoryOperation, error) {
+	return &MockExecutionHistoryOperation{}, nil
+}
+
+// GetStopPipelineExecutionOperation returns an operation for stopping pipeline executions
+func (p *MockAWSProvider) GetStopPipelineExecutionOperation() (cloud.StopPipelineExecutionOperation, error) {
+	return &MockStopPipelineExecutionOperation{}, nil
+}
+
+// GetRetryStageExecutionOperation returns an operation for retrying failed stages
+func (p *MockAWSProvider) GetRetryStageExecutionOperation() (cloud.RetryStageExecutionOperation, error) {
+	return &MockRetryStageExecutionOperation{}, nil
+}
+
+// GetApprovalsInboxOperation returns an operation for managing approvals across accounts
+func (p *MockAWSProvider) GetApprovalsInboxOperation() (cloud.ApprovalsInboxOperation, error) {
+	return &MockApprovalsInboxOperation{}, nil
+}
+
+// GetStageTransitionOperation returns an operation for enabling and disabling stage transitions
+func (p *MockAWSProvider) GetStageTransitionOperation() (cloud.StageTransitionOperation, error) {
+	return &MockStageTransitionOperation{}, nil
+}
+
+// GetRollbackStageOperation returns an operation for rolling back stages
+func (p *MockAWSProvider) GetRollbackStageOperation() (cloud.RollbackStageOperation, error) {
+	return &MockRollbackStageOperation{}, nil
+}
+
+// GetOverrideStageConditionOperation returns an operation for overriding stage conditions
+func (p *MockAWSProvider) GetOverrideStageConditionOperation() (cloud.OverrideStageConditionOperation, error) {
+	return &MockOverrideStageConditionOperation{}, nil
+}
+
+// GetPipelineDefinitionOperation returns an operation for viewing pipeline definitions
+func (p *MockAWSProvider) GetPipelineDefinitionOperation() (cloud.PipelineDefinitionOperation, error) {
+	return &MockPipelineDefinitionOperation{}, nil
+}
+
+// GetExecutionWatchOperation returns an operation for following pipeline executions
+func (p *MockAWSProvider) GetExecutionWatchOperation() (cloud.ExecutionWatchOperation, error) {
+	return &MockExecutionWatchOperation{}, nil
+}
+
 // GetAuthenticationMethods returns available authentication methods
 func (p *MockAWSProvider) GetAuthenticationMethods() []string {
 	return []string{"profile", "access_key"}
@@ -107,7 +163,7 @@ func (p *MockAWSProvider) GetAuthConfigKeys(method string) []string {
 }
 
 // Authenticate authenticates using the provided method and configuration
-func (p *MockAWSProvider) Authenticate(method string, authConfig map[string]string) error {
+func (p *MockAWSProvider) Authenticate(ctx context.Context, method string, authConfig map[string]string) error {
 	switch method {
 	case "profile":
 		if _, ok := authConfig["profile_name"]; !ok {
@@ -131,6 +187,16 @@ func (p *MockAWSProvider) IsAuthenticated() bool {
 	return true
 }
 
+// GetCallerIdentity returns the identity the provider signs its requests as
+func (p *MockAWSProvider) GetCallerIdentity(ctx context.Context) (*cloud.CallerIdentity, error) {
+	return &cloud.CallerIdentity{
+		Account:      "123456789012",
+		AccountAlias: "mock-account",
+		ARN:          "arn:aws:sts::123456789012:assumed-role/Deployer/mock-session",
+		UserID:       "AROAEXAMPLE:mock-session",
+	}, nil
+}
+
 // GetConfigKeys returns required configuration keys
 func (p *MockAWSProvider) GetConfigKeys() []string {
 	return []string{"region"}
@@ -199,7 +265,7 @@ func (p *MockAWSProvider) GetStatus(ctx context.Context) ([]cloud.PipelineStatus
 }
 
 // StartPipeline starts a pipeline execution
-func (p *MockAWSProvider) StartPipeline(ctx context.Context, pipelineName string, commitID string) error {
+func (p *MockAWSProvider) StartPipeline(ctx context.Context, pipelineName string, sourceRevisions []cloud.SourceRevision, variables []cloud.PipelineVariable) error {
 	// Mock implementation
 	return nil
 }
@@ -353,14 +419,327 @@ func (o *MockStartPipelineOperation) IsUIVisible() bool {
 
 func (o *MockStartPipelineOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
 	pipelineName, _ := params["pipeline_name"].(string)
-	commitID, _ := params["commit_id"].(string)
-	return nil, o.StartPipelineExecution(ctx, pipelineName, commitID)
+	sourceRevisions, _ := params["source_revisions"].([]cloud.SourceRevision)
+	variables, _ := params["variables"].([]cloud.PipelineVariable)
+	return o.StartPipelineExecution(ctx, pipelineName, sourceRevisions, variables)
+}
+
+func (o *MockStartPipelineOperation) GetSourceActions(ctx context.Context, pipelineName string) ([]cloud.SourceAction, error) {
+	return []cloud.SourceAction{
+		{
+			Name:         "Source",
+			Provider:     "CodeStarSourceConnection",
+			RevisionType: cloud.RevisionTypeCommitID,
+		},
+		{
+			Name:         "Artifacts",
+			Provider:     "S3",
+			RevisionType: cloud.RevisionTypeS3ObjectVersionID,
+		},
+	}, nil
+}
+
+func (o *MockStartPipelineOperation) GetPipelineVariables(ctx context.Context, pipelineName string) ([]cloud.PipelineVariable, error) {
+	return []cloud.PipelineVariable{
+		{
+			Name:         "DeployTarget",
+			Value:        "staging",
+			DefaultValue: "staging",
+			Description:  "Environment to deploy to",
+		},
+	}, nil
+}
+
+func (o *MockStartPipelineOperation) StartPipelineExecution(ctx context.Context, pipelineName string, sourceRevisions []cloud.SourceRevision, variables []cloud.PipelineVariable) (string, error) {
+	return "mock-execution-id", nil
+}
+
+// MockExecutionHistoryOperation implements cloud.ExecutionHistoryOperation for testing
+type MockExecutionHistoryOperation struct{}
+
+func (o *MockExecutionHistoryOperation) Name() string {
+	return "Execution History"
+}
+
+func (o *MockExecutionHistoryOperation) Description() string {
+	return "View Pipeline Execution History (Mock)"
+}
+
+func (o *MockExecutionHistoryOperation) IsUIVisible() bool {
+	return true
+}
+
+func (o *MockExecutionHistoryOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	pipelineName, _ := params["pipeline_name"].(string)
+	nextToken, _ := params["next_token"].(string)
+	executions, _, err := o.GetPipelineExecutions(ctx, pipelineName, nextToken)
+	return executions, err
+}
+
+func (o *MockExecutionHistoryOperation) GetPipelineExecutions(ctx context.Context, pipelineName, nextToken string) ([]cloud.PipelineExecution, string, error) {
+	return []cloud.PipelineExecution{
+		{
+			ExecutionID: "mock-execution-2",
+			Status:      "InProgress",
+			TriggerType: "StartPipelineExecution",
+			StartTime:   "Jan 02 15:04:05 UTC",
+		},
+		{
+			ExecutionID: "mock-execution-1",
+			Status:      "Succeeded",
+			TriggerType: "Webhook",
+			SourceRevisions: []cloud.ExecutionSourceRevision{
+				{
+					ActionName:      "Source",
+					RevisionID:      "abc123",
+					RevisionSummary: "Initial commit",
+				},
+			},
+			StartTime: "Jan 01 15:04:05 UTC",
+			EndTime:   "Jan 01 15:09:05 UTC",
+			Duration:  "5m0s",
+		},
+	}, "", nil
+}
+
+// MockStopPipelineExecutionOperation implements cloud.StopPipelineExecutionOperation for testing
+type MockStopPipelineExecutionOperation struct{}
+
+func (o *MockStopPipelineExecutionOperation) Name() string {
+	return "Stop Pipeline Execution"
+}
+
+func (o *MockStopPipelineExecutionOperation) Description() string {
+	return "Stop a Running Pipeline Execution (Mock)"
+}
+
+func (o *MockStopPipelineExecutionOperation) IsUIVisible() bool {
+	return false
+}
+
+func (o *MockStopPipelineExecutionOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func (o *MockStopPipelineExecutionOperation) StopPipelineExecution(ctx context.Context, pipelineName, executionID string, abandon bool, reason string) error {
+	return nil
+}
+
+// MockRetryStageExecutionOperation implements cloud.RetryStageExecutionOperation for testing
+type MockRetryStageExecutionOperation struct{}
+
+func (o *MockRetryStageExecutionOperation) Name() string {
+	return "Retry Stage Execution"
+}
+
+func (o *MockRetryStageExecutionOperation) Description() string {
+	return "Retry a Failed Pipeline Stage (Mock)"
+}
+
+func (o *MockRetryStageExecutionOperation) IsUIVisible() bool {
+	return false
+}
+
+func (o *MockRetryStageExecutionOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func (o *MockRetryStageExecutionOperation) RetryStageExecution(ctx context.Context, pipelineName, stageName, executionID, retryMode string) error {
+	return nil
+}
+
+// MockApprovalsInboxOperation implements cloud.ApprovalsInboxOperation for testing
+type MockApprovalsInboxOperation struct{}
+
+func (o *MockApprovalsInboxOperation) Name() string {
+	return "Approvals Inbox"
+}
+
+func (o *MockApprovalsInboxOperation) Description() string {
+	return "Manage Approvals Across Accounts (Mock)"
+}
+
+func (o *MockApprovalsInboxOperation) IsUIVisible() bool {
+	return true
+}
+
+func (o *MockApprovalsInboxOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	targets, _ := params["targets"].([]cloud.ApprovalTarget)
+	return o.GetPendingApprovals(ctx, targets)
+}
+
+func (o *MockApprovalsInboxOperation) GetPendingApprovals(ctx context.Context, targets []cloud.ApprovalTarget) ([]cloud.ApprovalAction, error) {
+	approvals := make([]cloud.ApprovalAction, 0, len(targets))
+	for _, target := range targets {
+		approvals = append(approvals, cloud.ApprovalAction{
+			PipelineName: "mock-pipeline",
+			StageName:    "mock-stage",
+			ActionName:   "mock-action",
+			Token:        "mock-token",
+			Profile:      target.Profile,
+			Region:       target.Region,
+			AccountID:    "123456789012",
+		})
+	}
+	return approvals, nil
+}
+
+func (o *MockApprovalsInboxOperation) ApproveAction(ctx context.Context, action cloud.ApprovalAction, approved bool, comment string) error {
+	return nil
+}
+
+// MockStageTransitionOperation implements cloud.StageTransitionOperation for testing
+type MockStageTransitionOperation struct{}
+
+func (o *MockStageTransitionOperation) Name() string {
+	return "Stage Transition"
+}
+
+func (o *MockStageTransitionOperation) Description() string {
+	return "Enable or Disable a Stage Transition (Mock)"
+}
+
+func (o *MockStageTransitionOperation) IsUIVisible() bool {
+	return false
 }
 
-func (o *MockStartPipelineOperation) StartPipelineExecution(ctx context.Context, pipelineName, commitID string) error {
+func (o *MockStageTransitionOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func (o *MockStageTransitionOperation) EnableStageTransition(ctx context.Context, pipelineName, stageName string) error {
 	return nil
 }
 
+func (o *MockStageTransitionOperation) DisableStageTransition(ctx context.Context, pipelineName, stageName, reason string) error {
+	return nil
+}
+
+// MockRollbackStageOperation implements cloud.RollbackStageOperation for testing
+type MockRollbackStageOperation struct{}
+
+func (o *MockRollbackStageOperation) Name() string {
+	return "Rollback Stage"
+}
+
+func (o *MockRollbackStageOperation) Description() string {
+	return "Roll a Stage Back to a Previous Execution (Mock)"
+}
+
+func (o *MockRollbackStageOperation) IsUIVisible() bool {
+	return false
+}
+
+func (o *MockRollbackStageOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func (o *MockRollbackStageOperation) GetRollbackTargets(ctx context.Context, pipelineName, stageName, nextToken string) ([]cloud.PipelineExecution, string, error) {
+	return []cloud.PipelineExecution{
+		{ExecutionID: "mock-execution-1", Status: "Succeeded"},
+	}, "", nil
+}
+
+func (o *MockRollbackStageOperation) RollbackStage(ctx context.Context, pipelineName, stageName, targetExecutionID string) (string, error) {
+	return "mock-rollback-execution", nil
+}
+
+// MockOverrideStageConditionOperation implements cloud.OverrideStageConditionOperation for testing
+type MockOverrideStageConditionOperation struct{}
+
+func (o *MockOverrideStageConditionOperation) Name() string {
+	return "Override Stage Condition"
+}
+
+func (o *MockOverrideStageConditionOperation) Description() string {
+	return "Override a Failed Stage Condition (Mock)"
+}
+
+func (o *MockOverrideStageConditionOperation) IsUIVisible() bool {
+	return false
+}
+
+func (o *MockOverrideStageConditionOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func (o *MockOverrideStageConditionOperation) OverrideStageCondition(ctx context.Context, pipelineName, stageName, executionID, conditionType string) error {
+	return nil
+}
+
+// MockPipelineDefinitionOperation implements cloud.PipelineDefinitionOperation for testing
+type MockPipelineDefinitionOperation struct{}
+
+func (o *MockPipelineDefinitionOperation) Name() string {
+	return "Pipeline Definition"
+}
+
+func (o *MockPipelineDefinitionOperation) Description() string {
+	return "View, Export and Diff a Pipeline Definition (Mock)"
+}
+
+func (o *MockPipelineDefinitionOperation) IsUIVisible() bool {
+	return true
+}
+
+func (o *MockPipelineDefinitionOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func (o *MockPipelineDefinitionOperation) GetPipelineDefinition(ctx context.Context, pipelineName string) (*cloud.PipelineDefinition, error) {
+	return &cloud.PipelineDefinition{
+		Name:    pipelineName,
+		Version: 1,
+		Stages: []cloud.StageDefinition{
+			{
+				Name: "Source",
+				Actions: []cloud.ActionDefinition{
+					{
+						Name:            "Source",
+						ActionTypeID:    cloud.ActionTypeID{Category: "Source", Owner: "AWS", Provider: "CodeStarSourceConnection", Version: "1"},
+						RunOrder:        1,
+						InputArtifacts:  []cloud.ArtifactDefinition{},
+						OutputArtifacts: []cloud.ArtifactDefinition{{Name: "SourceOutput"}},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// MockExecutionWatchOperation implements cloud.ExecutionWatchOperation for testing
+type MockExecutionWatchOperation struct{}
+
+func (o *MockExecutionWatchOperation) Name() string {
+	return "Execution Watch"
+}
+
+func (o *MockExecutionWatchOperation) Description() string {
+	return "Follow the Progress of a Pipeline Execution (Mock)"
+}
+
+func (o *MockExecutionWatchOperation) IsUIVisible() bool {
+	return false
+}
+
+func (o *MockExecutionWatchOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func (o *MockExecutionWatchOperation) GetExecutionProgress(ctx context.Context, pipelineName, executionID string, previous *cloud.ExecutionProgress) (*cloud.ExecutionProgress, error) {
+	return &cloud.ExecutionProgress{
+		ExecutionID: executionID,
+		Status:      "Succeeded",
+		Stages: []cloud.StageProgress{
+			{
+				Name:    "Source",
+				Status:  "Succeeded",
+				Actions: []cloud.ActionStatus{{Name: "Source", Status: "Succeeded", RunOrder: 1}},
+			},
+		},
+	}, nil
+}
+
 // MockService implements cloud.Service for testing
 type MockService struct {
 	name        string
@@ -1,7 +1,10 @@
 package model
 
 import (
+	"os"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
@@ -40,6 +43,9 @@ type Model struct {
 	// Provider Registry
 	Registry *cloud.ProviderRegistry
 
+	// Cache of provider reads, shared by every copy of the model
+	Cache *cloud.ReadCache
+
 	// Provider state
 	ProviderState ProviderState
 
@@ -69,7 +75,70 @@ type Model struct {
 	SelectedPipeline  *cloud.PipelineStatus
 	ManualCommitID    bool
 	CommitID          string
+	SourceActions     []cloud.SourceAction
+	SelectedSource    *cloud.SourceAction
+	PipelineVariables []cloud.PipelineVariable
+	EditingVariable   string
+	Executions        []cloud.PipelineExecution
+	ExecutionsToken   string
+	SelectedExecution *cloud.PipelineExecution
+	SelectedStage     *cloud.StageStatus
+	SelectedAction    *cloud.ActionStatus
+	AbandonExecution  bool
+	RetryMode         string
+	RollbackTargets   []cloud.PipelineExecution
+	RollbackToken     string
+	RollbackTarget    *cloud.PipelineExecution
+	OverrideCondition string
+	InboxProfiles     []string
+	InboxRegions      []string
+	InboxFailures     []string
+	PipelineFailures  []string
+	MarkedApprovals   []string
+	ApprovalsFilter   string
+	BulkResults       []BulkApprovalResult
 	ApprovalComment   string
+
+	// Pipeline definition state
+	PipelineDefinition *cloud.PipelineDefinition
+	DefinitionExpanded []string
+	DefinitionAction   string
+	DefinitionFile     string
+	DefinitionDiff     []DefinitionDiffLine
+
+	// Execution watch state. WatchSeq tells the polls of the current watch apart from
+	// those of a watch that was restarted, and WatchErr is the error of the last poll.
+	WatchExecutionID  string
+	ExecutionProgress *cloud.ExecutionProgress
+	WatchSeq          int
+	WatchErr          error
+
+	// Auto-refresh state of the status tables. RefreshSeq tells the ticks of the
+	// current refresh loop apart from those of a loop started in an earlier view.
+	RefreshInterval time.Duration
+	RefreshPaused   bool
+	RefreshActive   bool
+	RefreshView     constants.View
+	RefreshSeq      int
+	LastRefreshed   time.Time
+	RefreshErr      error
+
+	// Request state. Requests time out after RequestTimeout unless it is zero, and
+	// RequestNotice tells that the last load was cancelled until the next key press.
+	Requests       *Requests
+	RequestTimeout time.Duration
+	RequestNotice  string
+
+	// Configured details of the profiles, by profile name
+	ProfileDetails map[string]cloud.ProfileDetails
+
+	// Device authorization of the SSO sign in waiting for the user to approve it
+	DeviceAuthorization *cloud.DeviceAuthorization
+
+	// Identity the provider signs the requests of the selected profile as, or the
+	// error looking it up
+	CallerIdentity    *cloud.CallerIdentity
+	CallerIdentityErr error
 }
 
 // ProviderState represents the state of the selected provider, service, category, and operation
@@ -112,6 +181,9 @@ type AuthenticationState struct {
 	// Current authentication config key being set
 	CurrentAuthConfigKey string
 
+	// Configuration keys of the current authentication method
+	ConfigKeys []string
+
 	// Authentication status
 	IsAuthenticated bool
 
@@ -176,6 +248,7 @@ func New() *Model {
 		CurrentView: constants.ViewProviders,
 		Styles:      styles.DefaultStyles(),
 		Registry:    cloud.NewProviderRegistry(),
+		Cache:       cloud.NewReadCache(),
 
 		// Initialize new state structures
 		ProviderState: ProviderState{
@@ -202,11 +275,51 @@ func New() *Model {
 		Services:   []Service{},
 		Categories: []Category{},
 		Operations: []Operation{},
+
+		RefreshInterval: getRefreshInterval(),
+		Requests:        NewRequests(),
+		RequestTimeout:  getRequestTimeout(),
 	}
 
 	return m
 }
 
+// getRefreshInterval returns the refresh interval of the status tables, read from the
+// environment when set there. A zero interval turns the refresh off.
+func getRefreshInterval() time.Duration {
+	value := strings.TrimSpace(os.Getenv(constants.EnvRefreshInterval))
+	switch strings.ToLower(value) {
+	case "":
+		return constants.DefaultRefreshInterval
+	case "0", "off":
+		return 0
+	}
+
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval <= 0 {
+		return constants.DefaultRefreshInterval
+	}
+	return max(interval, constants.MinRefreshInterval)
+}
+
+// getRequestTimeout returns how long a provider request may take, read from the
+// environment when set there. A zero timeout lets requests run until they are cancelled.
+func getRequestTimeout() time.Duration {
+	value := strings.TrimSpace(os.Getenv(constants.EnvRequestTimeout))
+	switch strings.ToLower(value) {
+	case "":
+		return constants.DefaultRequestTimeout
+	case "0", "off":
+		return 0
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		return constants.DefaultRequestTimeout
+	}
+	return max(timeout, constants.MinRequestTimeout)
+}
+
 func (m *Model) Init() tea.Cmd {
 	m.Regions = constants.DefaultAWSRegions
 
@@ -219,6 +332,9 @@ func (m *Model) Init() tea.Cmd {
 			sort.Strings(profiles)
 			m.Profiles = profiles
 		}
+		if details, err := awsProvider.GetProfileDetails(); err == nil {
+			m.SetProfileDetails(details)
+		}
 	}
 
 	return m.Spinner.Tick
@@ -230,11 +346,17 @@ func (m *Model) ResetApprovalState() {
 	m.Provider = nil
 	m.SelectedApproval = nil
 	m.Summary = ""
+	m.InboxFailures = nil
+	m.PipelineFailures = nil
+	m.MarkedApprovals = nil
+	m.ApprovalsFilter = ""
+	m.BulkResults = nil
 }
 
 // ResetTextInput resets the text input
 func (m *Model) ResetTextInput() {
 	m.TextInput.SetValue("")
+	m.TextInput.EchoMode = textinput.EchoNormal
 	m.TextInput.Blur()
 }
 
@@ -278,6 +400,9 @@ func (m *Model) Clone() *Model {
 	newModel.ProviderState.AuthState.AvailableMethods = make([]string, len(m.ProviderState.AuthState.AvailableMethods))
 	copy(newModel.ProviderState.AuthState.AvailableMethods, m.ProviderState.AuthState.AvailableMethods)
 
+	newModel.ProviderState.AuthState.ConfigKeys = make([]string, len(m.ProviderState.AuthState.ConfigKeys))
+	copy(newModel.ProviderState.AuthState.ConfigKeys, m.ProviderState.AuthState.ConfigKeys)
+
 	// Deep copy maps in InputState
 	newModel.InputState.TextValues = make(map[string]string)
 	for k, v := range m.InputState.TextValues {
@@ -343,8 +468,21 @@ func (m *Model) GetAwsProfile() string {
 	return m.AwsProfile
 }
 
-// SetAwsProfile sets the AWS profile in the provider config
+// SetProfileDetails stores the configured details of the profiles
+func (m *Model) SetProfileDetails(details []cloud.ProfileDetails) {
+	m.ProfileDetails = make(map[string]cloud.ProfileDetails, len(details))
+	for _, profile := range details {
+		m.ProfileDetails[profile.Name] = profile
+	}
+}
+
+// SetAwsProfile sets the AWS profile in the provider config. The caller identity of
+// another profile is cleared.
 func (m *Model) SetAwsProfile(profile string) {
+	if profile != m.AwsProfile {
+		m.CallerIdentity = nil
+		m.CallerIdentityErr = nil
+	}
 	m.SetProviderConfig("profile", profile)
 	// Also set in legacy field for backward compatibility
 	m.AwsProfile = profile
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"context"
+	"sync"
+)
+
+// Requests tracks the provider requests started since they were last cancelled, so
+// that leaving a view while it loads stops its requests. It is shared by every copy
+// of the model.
+type Requests struct {
+	mu     sync.Mutex
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewRequests creates a new request tracker
+func NewRequests() *Requests {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Requests{ctx: ctx, cancel: cancel}
+}
+
+// Context returns the context new requests are started with. It is done once the
+// requests are cancelled.
+func (r *Requests) Context() context.Context {
+	if r == nil {
+		return context.Background()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.ctx
+}
+
+// Cancel cancels every request started since the last cancel
+func (r *Requests) Cancel() {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cancel()
+	r.ctx, r.cancel = context.WithCancel(context.Background())
+}
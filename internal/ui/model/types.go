@@ -1,6 +1,8 @@
 package model
 
 import (
+	tea "github.com/charmbracelet/bubbletea"
+
 	"github.com/HenryOwenz/cloudgate/internal/cloud"
 )
 
@@ -48,6 +50,23 @@ type FunctionStatus = cloud.FunctionStatus
 type ApprovalsMsg struct {
 	Approvals []ApprovalAction
 	Provider  cloud.Provider
+	// FailedTargets lists the profile/region pairs of the approvals inbox that failed to load
+	FailedTargets []string
+	// FailedPipelines lists the pipelines whose approvals failed to load
+	FailedPipelines []string
+}
+
+// BulkApprovalResult represents the outcome of one approval of a bulk approval.
+// Skipped approvals were not started because the bulk approval was cancelled.
+type BulkApprovalResult struct {
+	Approval ApprovalAction
+	Err      error
+	Skipped  bool
+}
+
+// BulkApprovalMsg represents the results of a bulk approval
+type BulkApprovalMsg struct {
+	Results []BulkApprovalResult
 }
 
 // ApprovalResultMsg represents the result of an approval action
@@ -59,15 +78,144 @@ type ApprovalResultMsg struct {
 type PipelineStatusMsg struct {
 	Pipelines []PipelineStatus
 	Provider  cloud.Provider
+	// FailedPipelines lists the pipelines whose status failed to load
+	FailedPipelines []string
 }
 
 // PipelineExecutionMsg represents the result of a pipeline execution
 type PipelineExecutionMsg struct {
+	ExecutionID string
+	Err         error
+}
+
+// ExecutionProgressMsg represents a message containing the progress of a watched pipeline
+// execution, or the error polling it. Seq tells the polls of the current watch apart
+// from those of a watch that was restarted.
+type ExecutionProgressMsg struct {
+	ExecutionID string
+	Seq         int
+	Progress    *cloud.ExecutionProgress
+	Err         error
+}
+
+// WatchTickMsg asks for the progress of a watched pipeline execution to be polled again
+type WatchTickMsg struct {
+	ExecutionID string
+	Seq         int
+}
+
+// LoadingProgressMsg represents the progress of a fetch shown by the loading spinner.
+// Updates delivers the next progress of the same fetch.
+type LoadingProgressMsg struct {
+	Progress cloud.Progress
+	Updates  <-chan cloud.Progress
+}
+
+// RequestCancelledMsg replaces the result of a request that was cancelled
+type RequestCancelledMsg struct{}
+
+// RefreshTickMsg asks for the table of the current view to be refreshed
+type RefreshTickMsg struct {
+	Seq int
+}
+
+// RefreshMsg wraps the result of refreshing the table of the current view. Manual
+// refreshes are requested by the user and do not schedule the next tick.
+type RefreshMsg struct {
+	Seq    int
+	Manual bool
+	Msg    tea.Msg
+}
+
+// SourceActionsMsg represents a message containing the source actions of a pipeline
+type SourceActionsMsg struct {
+	SourceActions []cloud.SourceAction
+}
+
+// ExecutionHistoryMsg represents a message containing a page of a pipeline's executions
+type ExecutionHistoryMsg struct {
+	Executions []cloud.PipelineExecution
+	NextToken  string
+	// Append is set when the page follows the executions already loaded
+	Append bool
+}
+
+// StopExecutionMsg represents the result of stopping a pipeline execution
+type StopExecutionMsg struct {
+	Err error
+}
+
+// RetryStageMsg represents the result of retrying a failed stage
+type RetryStageMsg struct {
+	Err error
+}
+
+// StageTransitionMsg represents the result of enabling or disabling a stage transition
+type StageTransitionMsg struct {
+	Enabled bool
+	Err     error
+}
+
+// RollbackTargetsMsg represents a message containing a page of the executions a stage can be rolled back to
+type RollbackTargetsMsg struct {
+	Executions []cloud.PipelineExecution
+	NextToken  string
+	// Append is set when the page follows the targets already loaded
+	Append bool
+}
+
+// RollbackStageMsg represents the result of rolling back a stage
+type RollbackStageMsg struct {
+	ExecutionID string
+	Err         error
+}
+
+// OverrideConditionMsg represents the result of overriding a stage condition
+type OverrideConditionMsg struct {
 	Err error
 }
 
+// PipelineDefinitionMsg represents a message containing the definition of a pipeline
+type PipelineDefinitionMsg struct {
+	Definition *cloud.PipelineDefinition
+}
+
+// DefinitionDiffLine represents a line of the diff between a local and the live pipeline definition
+type DefinitionDiffLine struct {
+	// Kind is "+" for lines only in the live definition, "-" for lines only in the
+	// local file, " " for unchanged lines and "~" for skipped unchanged lines
+	Kind string
+	Text string
+}
+
+// PipelineVariablesMsg represents a message containing the variables declared in a pipeline
+type PipelineVariablesMsg struct {
+	Variables []cloud.PipelineVariable
+}
+
 // FunctionStatusMsg represents a message containing function status
 type FunctionStatusMsg struct {
 	Functions []FunctionStatus
 	Provider  cloud.Provider
 }
+
+// DeviceAuthorizationMsg represents the device authorization of a sign in waiting for
+// the user to approve it
+type DeviceAuthorizationMsg struct {
+	Authorization cloud.DeviceAuthorization
+}
+
+// SignInMsg represents the result of signing in to a profile in a region
+type SignInMsg struct {
+	Provider cloud.Provider
+	Profile  string
+	Region   string
+	Err      error
+}
+
+// CallerIdentityMsg represents the identity a profile signs its requests as
+type CallerIdentityMsg struct {
+	Profile  string
+	Identity *cloud.CallerIdentity
+	Err      error
+}
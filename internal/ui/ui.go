@@ -1,8 +1,7 @@
 package ui
 
 import (
-	"sort"
-	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
@@ -35,8 +34,20 @@ func (m Model) Init() tea.Cmd {
 	return m.core.Init()
 }
 
-// Update handles messages and updates the model
+// Update handles messages and updates the model, then starts or stops the auto-refresh
+// of the view the message led to
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	newModel, cmd := m.handleMsg(msg)
+	if next, ok := newModel.(Model); ok {
+		if refreshCmd := update.ScheduleAutoRefresh(next.core); refreshCmd != nil {
+			return next, tea.Batch(cmd, refreshCmd)
+		}
+	}
+	return newModel, cmd
+}
+
+// handleMsg handles messages and updates the model
+func (m Model) handleMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
 	switch msg := msg.(type) {
@@ -55,10 +66,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		newModel := m.Clone()
 		newModel.core.Approvals = msg.Approvals
 		newModel.core.Provider = msg.Provider
+		newModel.core.InboxFailures = msg.FailedTargets
+		newModel.core.PipelineFailures = msg.FailedPipelines
 		newModel.core.CurrentView = constants.ViewApprovals
 		newModel.core.IsLoading = false
+		newModel.core.LastRefreshed = time.Now()
 		view.UpdateTableForView(newModel.core)
 		return newModel, nil
+	case model.BulkApprovalMsg:
+		newModel := m.Clone()
+		newModel.core.IsLoading = false
+		update.HandleBulkApprovalResult(newModel.core, msg.Results)
+		return newModel, nil
 	case model.ApprovalResultMsg:
 		newModel := m.Clone()
 		newModel.core.IsLoading = false // Ensure loading is turned off
@@ -68,23 +87,107 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case model.PipelineExecutionMsg:
 		newModel := m.Clone()
 		newModel.core.IsLoading = false // Ensure loading is turned off
-		update.HandlePipelineExecution(newModel.core, msg.Err)
+		cmd := update.HandlePipelineExecution(newModel.core, msg.ExecutionID, msg.Err)
+		view.UpdateTableForView(newModel.core)
+		return newModel, cmd
+	case model.ExecutionProgressMsg:
+		newModel := m.Clone()
+		cmd := update.HandleExecutionProgress(newModel.core, msg)
+		return newModel, cmd
+	case model.WatchTickMsg:
+		return m, update.HandleWatchTick(m.core, msg)
+	case model.LoadingProgressMsg:
+		newModel := m.Clone()
+		cmd := update.HandleLoadingProgress(newModel.core, msg)
+		return newModel, cmd
+	case model.DeviceAuthorizationMsg:
+		newModel := m.Clone()
+		update.HandleDeviceAuthorization(newModel.core, msg)
+		return newModel, nil
+	case model.SignInMsg:
+		newModel := m.Clone()
+		return newModel, update.HandleSignInResult(newModel.core, msg)
+	case model.CallerIdentityMsg:
+		newModel := m.Clone()
+		update.HandleCallerIdentity(newModel.core, msg)
+		return newModel, nil
+	case model.RequestCancelledMsg:
+		// The load was already stopped when it was cancelled
+		return m, nil
+	case model.RefreshTickMsg:
+		return m, update.HandleRefreshTick(m.core, msg.Seq)
+	case model.RefreshMsg:
+		newModel := m.Clone()
+		if msg.Manual {
+			newModel.core.IsLoading = false
+		}
+		cmd := update.HandleRefreshResult(newModel.core, msg)
+		return newModel, cmd
+	case model.StopExecutionMsg:
+		newModel := m.Clone()
+		newModel.core.IsLoading = false
+		update.HandleStopExecutionResult(newModel.core, msg.Err)
+		view.UpdateTableForView(newModel.core)
+		return newModel, nil
+	case model.RetryStageMsg:
+		newModel := m.Clone()
+		newModel.core.IsLoading = false
+		update.HandleRetryStageResult(newModel.core, msg.Err)
+		view.UpdateTableForView(newModel.core)
+		return newModel, nil
+	case model.StageTransitionMsg:
+		newModel := m.Clone()
+		newModel.core.IsLoading = false
+		update.HandleStageTransitionResult(newModel.core, msg.Enabled, msg.Err)
+		view.UpdateTableForView(newModel.core)
+		return newModel, nil
+	case model.PipelineDefinitionMsg:
+		newModel := m.Clone()
+		newModel.core.IsLoading = false
+		update.HandlePipelineDefinitionLoaded(newModel.core, msg.Definition)
+		return newModel, nil
+	case model.RollbackTargetsMsg:
+		newModel := m.Clone()
+		newModel.core.IsLoading = false
+		update.HandleRollbackTargetsLoaded(newModel.core, msg)
+		return newModel, nil
+	case model.RollbackStageMsg:
+		newModel := m.Clone()
+		newModel.core.IsLoading = false
+		update.HandleRollbackStageResult(newModel.core, msg.ExecutionID, msg.Err)
 		view.UpdateTableForView(newModel.core)
 		return newModel, nil
+	case model.OverrideConditionMsg:
+		newModel := m.Clone()
+		newModel.core.IsLoading = false
+		update.HandleOverrideConditionResult(newModel.core, msg.Err)
+		view.UpdateTableForView(newModel.core)
+		return newModel, nil
+	case model.SourceActionsMsg:
+		newModel := m.Clone()
+		newModel.core.IsLoading = false
+		update.HandleSourceActions(newModel.core, msg.SourceActions)
+		return newModel, nil
+	case model.ExecutionHistoryMsg:
+		newModel := m.Clone()
+		newModel.core.IsLoading = false
+		update.HandleExecutionHistoryLoaded(newModel.core, msg)
+		return newModel, nil
+	case model.PipelineVariablesMsg:
+		newModel := m.Clone()
+		newModel.core.IsLoading = false
+		update.HandlePipelineVariablesLoaded(newModel.core, msg.Variables)
+		return newModel, nil
 	case model.FunctionStatusMsg:
 		newModel := m.Clone()
 		newModel.core.Functions = msg.Functions
 		newModel.core.Provider = msg.Provider
 		newModel.core.CurrentView = constants.ViewFunctionStatus
 		newModel.core.IsLoading = false
+		newModel.core.LastRefreshed = time.Now()
 
 		// Sort functions by name in ascending order (case-insensitive)
-		// This preserves the original case of function names in the display
-		// while providing a consistent sorting order regardless of casing.
-		// The lowercase conversion is used only for comparison during sorting.
-		sort.Slice(newModel.core.Functions, func(i, j int) bool {
-			return strings.ToLower(newModel.core.Functions[i].Name) < strings.ToLower(newModel.core.Functions[j].Name)
-		})
+		update.SortFunctionsByName(newModel.core.Functions)
 
 		view.UpdateTableForView(newModel.core)
 		return newModel, nil
@@ -99,16 +202,27 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		// Ignore navigation key presses when loading
 		if m.core.IsLoading {
-			// Only allow quit commands during loading
+			// Only allow quit and cancel commands during loading
 			switch msg.String() {
 			case constants.KeyCtrlC, constants.KeyQ:
 				return m, tea.Quit
+			case constants.KeyEsc:
+				// Cancel the load and go back to the view it was started from
+				newModel := m.Clone()
+				update.CancelRequests(newModel.core)
+				return newModel, nil
 			default:
 				// Ignore all other key presses during loading
 				return m, nil
 			}
 		}
 
+		// The notice of a cancelled load is shown until the next key press
+		if m.core.RequestNotice != "" {
+			m = m.Clone()
+			m.core.RequestNotice = ""
+		}
+
 		// Handle key presses when not loading
 		switch msg.String() {
 		case constants.KeyCtrlC, constants.KeyQ:
@@ -149,8 +263,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return newModel, cmd
 			}
 
-			// Handle back navigation
-			if m.core.ManualInput {
+			// Handle back navigation. Cancelling the MFA code prompt leaves it.
+			if m.core.ManualInput && !update.IsTokenCodePrompt(m.core) {
 				newModel := m.Clone()
 				newModel.core.ManualInput = false
 				newModel.core.ResetTextInput()
@@ -159,6 +273,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			newCore := update.NavigateBack(m.core)
 			view.UpdateTableForView(newCore)
+			update.RestoreCursorAfterBack(newCore, m.core)
 			return Model{core: newCore}, nil
 		case constants.KeyUp, constants.KeyAltUp:
 			// If in text input mode, pass 'k' to the text input
@@ -273,13 +388,64 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// For AWS config view, the actual setting happens when Enter is pressed in HandleEnter
 				return newModel, cmd
 			}
+
+			// Refresh the status tables right away
+			if msg.String() == constants.KeyRefresh && view.IsRefreshableView(m.core.CurrentView) {
+				modelWrapper, cmd := update.HandleManualRefresh(m.core)
+				if wrapper, ok := modelWrapper.(update.ModelWrapper); ok {
+					newModel := Model{core: wrapper.Model}
+					if newModel.core.IsLoading {
+						return newModel, tea.Batch(cmd, newModel.core.Spinner.Tick)
+					}
+					return newModel, cmd
+				}
+				return modelWrapper, cmd
+			}
+
+			// Restart the polling of the watched execution
+			if msg.String() == constants.KeyRefresh && m.core.CurrentView == constants.ViewExecutionWatch {
+				modelWrapper, cmd := update.HandleRestartExecutionWatch(m.core)
+				if wrapper, ok := modelWrapper.(update.ModelWrapper); ok {
+					return Model{core: wrapper.Model}, cmd
+				}
+				return modelWrapper, cmd
+			}
+
+			// Pause or resume the auto-refresh of the status tables
+			if msg.String() == constants.KeyPauseRefresh && view.IsRefreshableView(m.core.CurrentView) {
+				modelWrapper, cmd := update.HandleToggleRefresh(m.core)
+				if wrapper, ok := modelWrapper.(update.ModelWrapper); ok {
+					return Model{core: wrapper.Model}, cmd
+				}
+				return modelWrapper, cmd
+			}
+
+			// Mark and filter approvals in the approvals view
+			if m.core.CurrentView == constants.ViewApprovals {
+				modelWrapper, cmd := update.HandleApprovalsKey(m.core, msg.String())
+				if wrapper, ok := modelWrapper.(update.ModelWrapper); ok {
+					return Model{core: wrapper.Model}, cmd
+				}
+				return modelWrapper, cmd
+			}
+
+			// Switch between the stages table and graph
+			if m.core.CurrentView == constants.ViewPipelineStages || m.core.CurrentView == constants.ViewPipelineGraph {
+				modelWrapper, cmd := update.HandlePipelineStagesKey(m.core, msg.String())
+				if wrapper, ok := modelWrapper.(update.ModelWrapper); ok {
+					return Model{core: wrapper.Model}, cmd
+				}
+				return modelWrapper, cmd
+			}
 		}
 	case model.PipelineStatusMsg:
 		newModel := m.Clone()
 		newModel.core.Pipelines = msg.Pipelines
 		newModel.core.Provider = msg.Provider
+		newModel.core.PipelineFailures = msg.FailedPipelines
 		newModel.core.CurrentView = constants.ViewPipelineStatus
 		newModel.core.IsLoading = false
+		newModel.core.LastRefreshed = time.Now()
 		view.UpdateTableForView(newModel.core)
 		return newModel, nil
 	}
@@ -6,6 +6,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
 	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
 	"github.com/HenryOwenz/cloudgate/internal/ui/model"
 	"github.com/HenryOwenz/cloudgate/internal/ui/view"
@@ -51,8 +52,9 @@ func HandleApprovalResult(m *model.Model, err error) {
 	// Navigate back to the operation selection view
 	m.CurrentView = constants.ViewSelectOperation
 
-	// Clear the approvals list to force a refresh next time
+	// Clear the approvals list and the cached reads to force a refresh next time
 	m.Approvals = nil
+	invalidateCache(m, constants.CacheApprovals, constants.CacheInboxApprovals, constants.CachePipelineStatus)
 
 	// Update the table for the current view
 	view.UpdateTableForView(m)
@@ -60,7 +62,7 @@ func HandleApprovalResult(m *model.Model, err error) {
 
 // FetchApprovals fetches pipeline approvals from the provider
 func FetchApprovals(m *model.Model) tea.Cmd {
-	return func() tea.Msg {
+	return fetchCached(m, constants.CacheApprovals, constants.ApprovalsCacheTTL, withRequest(m, func(ctx context.Context) tea.Msg {
 		// Get the provider from the registry
 		provider, err := m.Registry.Get("AWS")
 		if err != nil {
@@ -74,22 +76,23 @@ func FetchApprovals(m *model.Model) tea.Cmd {
 		}
 
 		// Get approvals using the operation
-		ctx := context.Background()
 		approvals, err := approvalOperation.GetPendingApprovals(ctx)
-		if err != nil {
+		failedPipelines := getFailedPipelines(err)
+		if err != nil && len(failedPipelines) == 0 {
 			return model.ErrMsg{Err: err}
 		}
 
 		return model.ApprovalsMsg{
-			Approvals: approvals,
-			Provider:  provider,
+			Approvals:       approvals,
+			Provider:        provider,
+			FailedPipelines: failedPipelines,
 		}
-	}
+	}))
 }
 
 // ExecuteApproval executes an approval action
 func ExecuteApproval(m *model.Model) tea.Cmd {
-	return func() tea.Msg {
+	return withRequest(m, func(ctx context.Context) tea.Msg {
 		if m.SelectedApproval == nil {
 			return model.ErrMsg{Err: fmt.Errorf("no approval selected")}
 		}
@@ -100,19 +103,32 @@ func ExecuteApproval(m *model.Model) tea.Cmd {
 			return model.ErrMsg{Err: err}
 		}
 
-		// Get the CodePipelineManualApprovalOperation from the provider
-		approvalOperation, err := provider.GetCodePipelineManualApprovalOperation()
+		// Get the approval operation from the provider
+		approvalOperation, err := getApprovalOperation(m, provider)
 		if err != nil {
 			return model.ErrMsg{Err: err}
 		}
 
 		// Execute the approval action using the operation
-		ctx := context.Background()
 		err = approvalOperation.ApproveAction(ctx, *m.SelectedApproval, m.ApproveAction, m.ApprovalComment)
 		if err != nil {
 			return model.ApprovalResultMsg{Err: err}
 		}
 
 		return model.ApprovalResultMsg{Err: nil}
+	})
+}
+
+// approvalOperation approves or rejects pending approvals
+type approvalOperation interface {
+	ApproveAction(ctx context.Context, action cloud.ApprovalAction, approved bool, comment string) error
+}
+
+// getApprovalOperation returns the operation approving the approvals of the current flow.
+// Approvals of the inbox are approved in the profile and region they were found in.
+func getApprovalOperation(m *model.Model, provider cloud.Provider) (approvalOperation, error) {
+	if m.SelectedOperation != nil && m.SelectedOperation.Name == "Approvals Inbox" {
+		return provider.GetApprovalsInboxOperation()
 	}
+	return provider.GetCodePipelineManualApprovalOperation()
 }
@@ -0,0 +1,136 @@
+package update
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+)
+
+// TestApprovalsInboxTargetSelection tests choosing the profiles and regions of the approvals inbox
+func TestApprovalsInboxTargetSelection(t *testing.T) {
+	m := model.New()
+	m.AwsProfile = "dev"
+	m.AwsRegion = "us-east-1"
+	m.Profiles = []string{"dev", "prod"}
+	m.Regions = []string{"us-east-1", "eu-west-1"}
+	m.SelectedOperation = &model.Operation{Name: "Approvals Inbox"}
+
+	result, _ := HandleApprovalsInbox(m)
+	wrapper := result.(ModelWrapper)
+	if wrapper.Model.CurrentView != constants.ViewInboxProfiles {
+		t.Fatalf("Expected to be at ViewInboxProfiles, got %v", wrapper.Model.CurrentView)
+	}
+	if len(wrapper.Model.InboxProfiles) != 1 || wrapper.Model.InboxProfiles[0] != "dev" {
+		t.Errorf("Expected the configured profile to be preselected, got %v", wrapper.Model.InboxProfiles)
+	}
+
+	// Selecting a profile toggles it and keeps the cursor on it
+	wrapper.Model.Table.SetCursor(2)
+	result, _ = HandleInboxProfileSelection(wrapper.Model)
+	wrapper = result.(ModelWrapper)
+	if len(wrapper.Model.InboxProfiles) != 2 || wrapper.Model.InboxProfiles[1] != "prod" {
+		t.Errorf("Expected prod to be selected, got %v", wrapper.Model.InboxProfiles)
+	}
+	if wrapper.Model.Table.Cursor() != 2 {
+		t.Errorf("Expected the cursor to stay on the toggled profile, got %d", wrapper.Model.Table.Cursor())
+	}
+	if rows := wrapper.Model.Table.Rows(); rows[0][1] != "2 selected" || rows[2][1] != "✓" {
+		t.Errorf("Expected the selection to be shown in the table, got %v", rows)
+	}
+
+	// Continuing moves on to the region selection
+	wrapper.Model.Table.SetCursor(0)
+	result, _ = HandleInboxProfileSelection(wrapper.Model)
+	wrapper = result.(ModelWrapper)
+	if wrapper.Model.CurrentView != constants.ViewInboxRegions {
+		t.Fatalf("Expected to be at ViewInboxRegions, got %v", wrapper.Model.CurrentView)
+	}
+
+	// Deselecting the only region keeps the inbox from loading
+	wrapper.Model.Table.SetCursor(1)
+	result, _ = HandleInboxRegionSelection(wrapper.Model)
+	wrapper = result.(ModelWrapper)
+	if len(wrapper.Model.InboxRegions) != 0 {
+		t.Errorf("Expected no regions to be selected, got %v", wrapper.Model.InboxRegions)
+	}
+	wrapper.Model.Table.SetCursor(0)
+	result, cmd := HandleInboxRegionSelection(wrapper.Model)
+	if cmd == nil {
+		t.Fatalf("Expected a command reporting the missing region")
+	}
+	if msg, ok := cmd().(model.ErrMsg); !ok || msg.Err.Error() != constants.MsgErrorNoInboxRegions {
+		t.Errorf("Expected an error for the missing region, got %v", msg)
+	}
+
+	// Continuing with a region loads the approvals of every profile and region pair
+	wrapper = result.(ModelWrapper)
+	wrapper.Model.Table.SetCursor(2)
+	result, _ = HandleInboxRegionSelection(wrapper.Model)
+	wrapper = result.(ModelWrapper)
+	wrapper.Model.Table.SetCursor(0)
+	result, cmd = HandleInboxRegionSelection(wrapper.Model)
+	if cmd == nil || !result.(ModelWrapper).Model.IsLoading {
+		t.Errorf("Expected the approvals to be loading")
+	}
+	targets := getInboxTargets(wrapper.Model)
+	if len(targets) != 2 || targets[1] != (cloud.ApprovalTarget{Profile: "prod", Region: "eu-west-1"}) {
+		t.Errorf("Expected a target per profile and region, got %v", targets)
+	}
+}
+
+// TestApprovalsInboxSelection tests selecting an approval among approvals of several accounts
+func TestApprovalsInboxSelection(t *testing.T) {
+	m := model.New()
+	m.SelectedOperation = &model.Operation{Name: "Approvals Inbox"}
+	m.CurrentView = constants.ViewApprovals
+	m.Approvals = []cloud.ApprovalAction{
+		{PipelineName: "Deploy", StageName: "Approve", ActionName: "Manual", Profile: "dev", Region: "us-east-1", AccountID: "111111111111"},
+		{PipelineName: "Deploy", StageName: "Approve", ActionName: "Manual", Profile: "prod", Region: "us-east-1", AccountID: "222222222222"},
+	}
+	view.UpdateTableForView(m)
+
+	if columns := m.Table.Columns(); len(columns) != 6 || columns[0].Title != "Account" || columns[1].Title != "Region" {
+		t.Fatalf("Expected the account and region columns, got %v", columns)
+	}
+
+	m.Table.SetCursor(1)
+	result, _ := SelectApproval(m)
+	wrapper := result.(ModelWrapper)
+	if wrapper.Model.SelectedApproval == nil || wrapper.Model.SelectedApproval.Profile != "prod" {
+		t.Fatalf("Expected the approval of the prod profile to be selected, got %v", wrapper.Model.SelectedApproval)
+	}
+
+	// Navigating back from the inbox returns to the region selection
+	m.InboxFailures = []string{"test/us-east-1"}
+	backResult := NavigateBack(m)
+	if backResult.CurrentView != constants.ViewInboxRegions || backResult.InboxFailures != nil {
+		t.Errorf("Expected to navigate back to ViewInboxRegions with the failures cleared, got %v", backResult.CurrentView)
+	}
+}
+
+// TestGetFailedInboxTargets tests reporting the targets and the pipelines that failed to load
+func TestGetFailedInboxTargets(t *testing.T) {
+	err := errors.Join(
+		&cloud.ApprovalTargetError{Target: cloud.ApprovalTarget{Profile: "dev", Region: "us-east-1"}, Err: errors.New("expired token")},
+		&cloud.ApprovalTargetError{Target: cloud.ApprovalTarget{Profile: "prod", Region: "eu-west-1"}, Err: errors.New("access denied")},
+		&cloud.ApprovalTargetError{
+			Target: cloud.ApprovalTarget{Profile: "prod", Region: "us-east-1"},
+			Err:    &cloud.PipelineError{Pipeline: "deploy", Err: errors.New("throttled")},
+		},
+	)
+
+	failedTargets, failedPipelines := getFailedInboxTargets(err)
+	if len(failedTargets) != 2 || failedTargets[0] != "dev/us-east-1" || failedTargets[1] != "prod/eu-west-1" {
+		t.Errorf("Expected both failed targets, got %v", failedTargets)
+	}
+	if len(failedPipelines) != 1 || failedPipelines[0] != "prod/us-east-1/deploy" {
+		t.Errorf("Expected the failed pipeline under its target, got %v", failedPipelines)
+	}
+	if failedTargets, failedPipelines := getFailedInboxTargets(nil); failedTargets != nil || failedPipelines != nil {
+		t.Errorf("Expected no failures, got %v and %v", failedTargets, failedPipelines)
+	}
+}
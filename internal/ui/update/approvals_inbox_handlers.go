@@ -0,0 +1,166 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HandleApprovalsInbox starts the approvals inbox by letting the user choose the
+// profiles and regions to load approvals from
+func HandleApprovalsInbox(m *model.Model) (tea.Model, tea.Cmd) {
+	newModel := m.Clone()
+
+	// Start from the configured profile and region the first time
+	if len(newModel.InboxProfiles) == 0 && newModel.AwsProfile != "" {
+		newModel.InboxProfiles = []string{newModel.AwsProfile}
+	}
+	if len(newModel.InboxRegions) == 0 && newModel.AwsRegion != "" {
+		newModel.InboxRegions = []string{newModel.AwsRegion}
+	}
+
+	newModel.CurrentView = constants.ViewInboxProfiles
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
+
+// HandleInboxProfileSelection toggles the selected profile, or continues to the
+// region selection
+func HandleInboxProfileSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	if selected := m.Table.SelectedRow(); len(selected) > 0 {
+		newModel := m.Clone()
+		if selected[0] == "Continue" {
+			if len(m.InboxProfiles) == 0 {
+				return WrapModel(m), func() tea.Msg {
+					return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoInboxProfiles)}
+				}
+			}
+			newModel.CurrentView = constants.ViewInboxRegions
+			view.UpdateTableForView(newModel)
+			return WrapModel(newModel), nil
+		}
+
+		newModel.InboxProfiles = toggleSelectedValue(m.InboxProfiles, selected[0])
+		view.UpdateTableForView(newModel)
+		newModel.Table.SetCursor(m.Table.Cursor())
+		return WrapModel(newModel), nil
+	}
+	return WrapModel(m), nil
+}
+
+// HandleInboxRegionSelection toggles the selected region, or loads the approvals
+// of the selected profiles and regions
+func HandleInboxRegionSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	if selected := m.Table.SelectedRow(); len(selected) > 0 {
+		newModel := m.Clone()
+		if selected[0] == "Continue" {
+			if len(m.InboxRegions) == 0 {
+				return WrapModel(m), func() tea.Msg {
+					return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoInboxRegions)}
+				}
+			}
+			newModel.IsLoading = true
+			newModel.LoadingMsg = constants.MsgLoadingInboxApprovals
+			return WrapModel(newModel), FetchInboxApprovals(m)
+		}
+
+		newModel.InboxRegions = toggleSelectedValue(m.InboxRegions, selected[0])
+		view.UpdateTableForView(newModel)
+		newModel.Table.SetCursor(m.Table.Cursor())
+		return WrapModel(newModel), nil
+	}
+	return WrapModel(m), nil
+}
+
+// FetchInboxApprovals fetches the pending approvals of every selected profile and region
+func FetchInboxApprovals(m *model.Model) tea.Cmd {
+	targets := getInboxTargets(m)
+	return fetchCached(m, constants.CacheInboxApprovals, constants.ApprovalsCacheTTL, withRequest(m, func(ctx context.Context) tea.Msg {
+		// Get the provider from the registry
+		provider, err := m.Registry.Get("AWS")
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Get the ApprovalsInboxOperation from the provider
+		inboxOperation, err := provider.GetApprovalsInboxOperation()
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Get approvals using the operation, keeping those of the targets that loaded
+		approvals, err := inboxOperation.GetPendingApprovals(ctx, targets)
+		failedTargets, failedPipelines := getFailedInboxTargets(err)
+		if err != nil && len(failedTargets) == len(targets) {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.ApprovalsMsg{
+			Approvals:       approvals,
+			Provider:        provider,
+			FailedTargets:   failedTargets,
+			FailedPipelines: failedPipelines,
+		}
+	}))
+}
+
+// getInboxTargets returns every combination of the selected profiles and regions
+func getInboxTargets(m *model.Model) []cloud.ApprovalTarget {
+	targets := make([]cloud.ApprovalTarget, 0, len(m.InboxProfiles)*len(m.InboxRegions))
+	for _, profile := range m.InboxProfiles {
+		for _, region := range m.InboxRegions {
+			targets = append(targets, cloud.ApprovalTarget{Profile: profile, Region: region})
+		}
+	}
+	return targets
+}
+
+// getFailedInboxTargets returns the profile/region pairs reported as failed by the inbox
+// operation, and the profile/region/pipeline of the pipelines that failed on their own
+func getFailedInboxTargets(err error) ([]string, []string) {
+	if err == nil {
+		return nil, nil
+	}
+
+	errs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	}
+
+	var failedTargets, failedPipelines []string
+	for _, err := range errs {
+		var targetErr *cloud.ApprovalTargetError
+		if !errors.As(err, &targetErr) {
+			continue
+		}
+		target := fmt.Sprintf("%s/%s", targetErr.Target.Profile, targetErr.Target.Region)
+
+		var pipelineErr *cloud.PipelineError
+		if errors.As(targetErr.Err, &pipelineErr) {
+			failedPipelines = append(failedPipelines, fmt.Sprintf("%s/%s", target, pipelineErr.Pipeline))
+			continue
+		}
+		failedTargets = append(failedTargets, target)
+	}
+	return failedTargets, failedPipelines
+}
+
+// toggleSelectedValue adds the value to the selection, or removes it when already selected
+func toggleSelectedValue(values []string, value string) []string {
+	toggled := make([]string, 0, len(values)+1)
+	for _, v := range values {
+		if v != value {
+			toggled = append(toggled, v)
+		}
+	}
+	if len(toggled) == len(values) {
+		toggled = append(toggled, value)
+	}
+	return toggled
+}
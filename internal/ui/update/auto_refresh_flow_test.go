@@ -0,0 +1,103 @@
+package update
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+)
+
+// TestAutoRefreshFlow tests refreshing the pipeline stages while keeping the cursor on the same stage
+func TestAutoRefreshFlow(t *testing.T) {
+	m := model.New()
+	m.RefreshInterval = time.Millisecond
+	m.Pipelines = []cloud.PipelineStatus{{Name: "TestPipeline", Stages: []cloud.StageStatus{
+		{Name: "Source", Status: "Succeeded"},
+		{Name: "Build", Status: "InProgress"},
+	}}}
+	m.SelectedPipeline = &m.Pipelines[0]
+	m.CurrentView = constants.ViewPipelineStages
+	view.UpdateTableForView(m)
+	m.Table.SetCursor(1)
+
+	// Entering a status table starts its refresh loop
+	if cmd := ScheduleAutoRefresh(m); cmd == nil || !m.RefreshActive {
+		t.Fatalf("Expected the refresh loop to start")
+	}
+	if cmd := ScheduleAutoRefresh(m); cmd != nil {
+		t.Errorf("Expected a single refresh loop per view")
+	}
+	seq := m.RefreshSeq
+
+	// The refreshed pipeline replaces the selected one and the cursor follows the stage
+	refreshed := []cloud.PipelineStatus{{Name: "TestPipeline", Stages: []cloud.StageStatus{
+		{Name: "Approval", Status: "InProgress"},
+		{Name: "Source", Status: "Succeeded"},
+		{Name: "Build", Status: "Succeeded"},
+	}}}
+	if cmd := HandleRefreshResult(m, model.RefreshMsg{Seq: seq, Msg: model.PipelineStatusMsg{Pipelines: refreshed}}); cmd == nil {
+		t.Errorf("Expected the next refresh to be scheduled")
+	}
+	if m.SelectedPipeline.Stages[2].Status != "Succeeded" || m.LastRefreshed.IsZero() {
+		t.Errorf("Expected the selected pipeline to be refreshed")
+	}
+	if row := m.Table.SelectedRow(); m.Table.Cursor() != 2 || row[0] != "Build" {
+		t.Errorf("Expected the cursor to stay on the Build stage, got %d", m.Table.Cursor())
+	}
+
+	// A failed refresh keeps the data and is reported
+	HandleRefreshResult(m, model.RefreshMsg{Seq: seq, Msg: model.ErrMsg{Err: errors.New("throttled")}})
+	if m.RefreshErr == nil || m.Err != nil || len(m.SelectedPipeline.Stages) != 3 {
+		t.Errorf("Expected the refresh error to be kept apart from the view")
+	}
+
+	// Pausing skips the fetch until resumed
+	result, _ := HandleToggleRefresh(m)
+	paused := result.(ModelWrapper).Model
+	if !paused.RefreshPaused {
+		t.Fatalf("Expected the refresh to be paused")
+	}
+	if msg := HandleRefreshTick(paused, seq)(); msg != (model.RefreshTickMsg{Seq: seq}) {
+		t.Errorf("Expected a paused refresh to only tick, got %T", msg)
+	}
+
+	// Leaving the status tables stops the loop and drops late results
+	m.CurrentView = constants.ViewSelectOperation
+	if cmd := ScheduleAutoRefresh(m); cmd != nil || m.RefreshActive {
+		t.Errorf("Expected the refresh loop to stop")
+	}
+	if cmd := HandleRefreshTick(m, seq); cmd != nil {
+		t.Errorf("Expected no refresh after leaving the view")
+	}
+	if cmd := HandleRefreshResult(m, model.RefreshMsg{Seq: seq, Msg: model.PipelineStatusMsg{}}); cmd != nil || m.Pipelines == nil {
+		t.Errorf("Expected a late refresh result to be dropped")
+	}
+}
+
+// TestAutoRefreshApprovals tests that refreshed approvals keep the marks of the approvals still pending
+func TestAutoRefreshApprovals(t *testing.T) {
+	m := model.New()
+	m.RefreshInterval = constants.DefaultRefreshInterval
+	m.Approvals = []cloud.ApprovalAction{
+		{PipelineName: "A", StageName: "Approve", ActionName: "Manual", Token: "token-a"},
+		{PipelineName: "B", StageName: "Approve", ActionName: "Manual", Token: "token-b"},
+	}
+	m.MarkedApprovals = []string{"token-a", "token-b"}
+	m.CurrentView = constants.ViewApprovals
+	view.UpdateTableForView(m)
+	m.Table.SetCursor(1)
+	ScheduleAutoRefresh(m)
+
+	approvals := []cloud.ApprovalAction{{PipelineName: "B", StageName: "Approve", ActionName: "Manual", Token: "token-b"}}
+	HandleRefreshResult(m, model.RefreshMsg{Seq: m.RefreshSeq, Msg: model.ApprovalsMsg{Approvals: approvals}})
+	if len(m.MarkedApprovals) != 1 || m.MarkedApprovals[0] != "token-b" {
+		t.Errorf("Expected only the mark of the pending approval to be kept, got %v", m.MarkedApprovals)
+	}
+	if row := m.Table.SelectedRow(); row[0] != "B" || row[3] != "✓" {
+		t.Errorf("Expected the cursor on the marked approval of B, got %v", row)
+	}
+}
@@ -0,0 +1,231 @@
+package update
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+)
+
+// ScheduleAutoRefresh starts the refresh loop of the current view when it shows a
+// refreshable status table, and stops it once the view is left
+func ScheduleAutoRefresh(m *model.Model) tea.Cmd {
+	if !view.IsRefreshableView(m.CurrentView) {
+		m.RefreshActive = false
+		return nil
+	}
+	if m.RefreshActive && m.RefreshView == m.CurrentView {
+		return nil
+	}
+
+	m.RefreshActive = true
+	m.RefreshView = m.CurrentView
+	m.RefreshSeq++
+	m.RefreshErr = nil
+	return scheduleRefreshTick(m)
+}
+
+// HandleRefreshTick re-issues the fetch of the current view, unless the refresh loop
+// was stopped or moved on to another view. While the refresh is paused or the user is
+// busy with the view, the fetch is skipped until the next tick.
+func HandleRefreshTick(m *model.Model, seq int) tea.Cmd {
+	if !m.RefreshActive || seq != m.RefreshSeq {
+		return nil
+	}
+	if m.RefreshPaused || m.IsLoading || m.ManualInput || m.Err != nil {
+		return scheduleRefreshTick(m)
+	}
+
+	if cmd := fetchRefresh(m, false); cmd != nil {
+		return cmd
+	}
+	return scheduleRefreshTick(m)
+}
+
+// HandleManualRefresh fetches the data of the current view again right away,
+// bypassing the cached reads
+func HandleManualRefresh(m *model.Model) (tea.Model, tea.Cmd) {
+	if !m.RefreshActive || m.RefreshView != m.CurrentView {
+		return WrapModel(m), nil
+	}
+
+	newModel := m.Clone()
+	cmd := fetchRefresh(newModel, true)
+	if cmd == nil {
+		return WrapModel(newModel), nil
+	}
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgRefreshing
+	return WrapModel(newModel), cmd
+}
+
+// HandleRefreshResult merges the refreshed data into the current view, keeping the
+// cursor on the same row, and schedules the next refresh. Failed refreshes are shown
+// next to the last refresh time rather than replacing the view.
+func HandleRefreshResult(m *model.Model, msg model.RefreshMsg) tea.Cmd {
+	if !m.RefreshActive || msg.Seq != m.RefreshSeq {
+		return nil
+	}
+
+	// Manual refreshes leave the loop of the automatic ones as it is
+	next := scheduleRefreshTick(m)
+	if msg.Manual {
+		next = nil
+	}
+
+	selectedKey := getRowKey(m, m.Table.SelectedRow())
+	cursor := m.Table.Cursor()
+
+	switch result := msg.Msg.(type) {
+	case model.ErrMsg:
+		m.RefreshErr = result.Err
+		return next
+	case model.PipelineStatusMsg:
+		mergePipelines(m, result.Pipelines)
+		m.PipelineFailures = result.FailedPipelines
+	case model.ApprovalsMsg:
+		mergeApprovals(m, result.Approvals)
+		m.InboxFailures = result.FailedTargets
+		m.PipelineFailures = result.FailedPipelines
+	case model.FunctionStatusMsg:
+		m.Functions = result.Functions
+		SortFunctionsByName(m.Functions)
+	default:
+		return next
+	}
+	m.RefreshErr = nil
+	m.LastRefreshed = time.Now()
+
+	view.UpdateTableForView(m)
+	restoreCursor(m, selectedKey, cursor)
+	return next
+}
+
+// HandleToggleRefresh pauses or resumes the auto-refresh of the current view
+func HandleToggleRefresh(m *model.Model) (tea.Model, tea.Cmd) {
+	if !view.IsRefreshableView(m.CurrentView) || m.RefreshInterval <= 0 {
+		return WrapModel(m), nil
+	}
+
+	newModel := m.Clone()
+	newModel.RefreshPaused = !newModel.RefreshPaused
+	return WrapModel(newModel), nil
+}
+
+// SortFunctionsByName sorts functions by name in ascending order (case-insensitive).
+// This preserves the original case of function names in the display while providing
+// a consistent sorting order regardless of casing.
+func SortFunctionsByName(functions []model.FunctionStatus) {
+	sort.Slice(functions, func(i, j int) bool {
+		return strings.ToLower(functions[i].Name) < strings.ToLower(functions[j].Name)
+	})
+}
+
+// scheduleRefreshTick returns the command that ticks the current refresh loop, or nil
+// when the auto-refresh is turned off
+func scheduleRefreshTick(m *model.Model) tea.Cmd {
+	if m.RefreshInterval <= 0 {
+		return nil
+	}
+	seq := m.RefreshSeq
+	return tea.Tick(m.RefreshInterval, func(time.Time) tea.Msg {
+		return model.RefreshTickMsg{Seq: seq}
+	})
+}
+
+// fetchRefresh returns the command that fetches the data of the current view past
+// the cached reads, wrapped for the current refresh loop
+func fetchRefresh(m *model.Model, manual bool) tea.Cmd {
+	fetch := getRefreshCommand(m)
+	if fetch == nil {
+		return nil
+	}
+
+	invalidateCache(m, getViewCacheOperation(m))
+	seq := m.RefreshSeq
+	return func() tea.Msg {
+		return model.RefreshMsg{Seq: seq, Manual: manual, Msg: fetch()}
+	}
+}
+
+// getRefreshCommand returns the command that fetches the data of the current view
+func getRefreshCommand(m *model.Model) tea.Cmd {
+	switch m.CurrentView {
+	case constants.ViewPipelineStatus, constants.ViewPipelineStages, constants.ViewPipelineGraph:
+		return FetchPipelineStatus(m)
+	case constants.ViewApprovals:
+		if m.SelectedOperation != nil && m.SelectedOperation.Name == "Approvals Inbox" {
+			return FetchInboxApprovals(m)
+		}
+		return FetchApprovals(m)
+	case constants.ViewFunctionStatus:
+		return FetchFunctionStatus(m)
+	default:
+		return nil
+	}
+}
+
+// mergePipelines replaces the pipelines with the refreshed ones, and the selected
+// pipeline with its refreshed state when it still exists
+func mergePipelines(m *model.Model, pipelines []cloud.PipelineStatus) {
+	m.Pipelines = pipelines
+	if m.SelectedPipeline == nil {
+		return
+	}
+	for i := range pipelines {
+		if pipelines[i].Name == m.SelectedPipeline.Name {
+			pipeline := pipelines[i]
+			m.SelectedPipeline = &pipeline
+			return
+		}
+	}
+}
+
+// mergeApprovals replaces the approvals with the refreshed ones, keeping the marks of
+// the approvals that are still pending
+func mergeApprovals(m *model.Model, approvals []cloud.ApprovalAction) {
+	m.Approvals = approvals
+	marked := make([]string, 0, len(m.MarkedApprovals))
+	for _, token := range m.MarkedApprovals {
+		if containsApprovalToken(approvals, token) {
+			marked = append(marked, token)
+		}
+	}
+	m.MarkedApprovals = marked
+}
+
+// getRowKey returns the columns that identify a row of the current view across
+// refreshes, leaving out the columns that change with the data or the marks
+func getRowKey(m *model.Model, row table.Row) string {
+	if len(row) == 0 {
+		return ""
+	}
+	if m.CurrentView == constants.ViewApprovals {
+		return strings.Join(row[:len(row)-1], "\x00")
+	}
+	return row[0]
+}
+
+// restoreCursor moves the cursor back to the row with the given key, or keeps it at
+// the same position when that row is gone
+func restoreCursor(m *model.Model, key string, cursor int) {
+	rows := m.Table.Rows()
+	if key != "" {
+		for i, row := range rows {
+			if getRowKey(m, row) == key {
+				m.Table.SetCursor(i)
+				return
+			}
+		}
+	}
+	if len(rows) > 0 {
+		m.Table.SetCursor(min(cursor, len(rows)-1))
+	}
+}
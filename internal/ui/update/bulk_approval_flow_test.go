@@ -0,0 +1,220 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+)
+
+// bulkApprovalProvider is an AWS provider approving each approval with approve
+type bulkApprovalProvider struct {
+	cloud.Provider
+	approve func(ctx context.Context, action cloud.ApprovalAction) error
+}
+
+func (p *bulkApprovalProvider) Name() string {
+	return "AWS"
+}
+
+func (p *bulkApprovalProvider) GetCodePipelineManualApprovalOperation() (cloud.CodePipelineManualApprovalOperation, error) {
+	return &bulkApprovalOperation{approve: p.approve}, nil
+}
+
+// bulkApprovalOperation is the approval operation of a bulkApprovalProvider
+type bulkApprovalOperation struct {
+	cloud.CodePipelineManualApprovalOperation
+	approve func(ctx context.Context, action cloud.ApprovalAction) error
+}
+
+func (o *bulkApprovalOperation) ApproveAction(ctx context.Context, action cloud.ApprovalAction, approved bool, comment string) error {
+	return o.approve(ctx, action)
+}
+
+// newApprovalsModel creates a model showing the given approvals
+func newApprovalsModel(approvals ...cloud.ApprovalAction) *model.Model {
+	m := model.New()
+	m.SelectedOperation = &model.Operation{Name: "Pipeline Approvals"}
+	m.CurrentView = constants.ViewApprovals
+	m.Approvals = approvals
+	view.UpdateTableForView(m)
+	return m
+}
+
+// TestApprovalMarking tests marking approvals one by one and by filter
+func TestApprovalMarking(t *testing.T) {
+	m := newApprovalsModel(
+		cloud.ApprovalAction{PipelineName: "api-release", StageName: "Prod", ActionName: "Approve", Token: "token-1"},
+		cloud.ApprovalAction{PipelineName: "web-release", StageName: "Prod", ActionName: "Approve", Token: "token-2"},
+		cloud.ApprovalAction{PipelineName: "api-hotfix", StageName: "Prod", ActionName: "Approve", Token: "token-3"},
+	)
+
+	// Marking toggles the approval under the cursor
+	m.Table.SetCursor(1)
+	result, _ := HandleApprovalsKey(m, constants.KeyMark)
+	wrapper := result.(ModelWrapper)
+	if len(wrapper.Model.MarkedApprovals) != 1 || wrapper.Model.MarkedApprovals[0] != "token-2" {
+		t.Fatalf("Expected the second approval to be marked, got %v", wrapper.Model.MarkedApprovals)
+	}
+	if rows := wrapper.Model.Table.Rows(); rows[1][3] != "✓" || rows[0][3] != "" {
+		t.Errorf("Expected only the marked approval to show a mark, got %v", rows)
+	}
+	result, _ = HandleApprovalsKey(wrapper.Model, constants.KeyMark)
+	if marked := result.(ModelWrapper).Model.MarkedApprovals; len(marked) != 0 {
+		t.Errorf("Expected marking again to unmark the approval, got %v", marked)
+	}
+
+	// Filtering limits the approvals marked by mark all
+	result, _ = HandleApprovalsKey(wrapper.Model, constants.KeyFilter)
+	wrapper = result.(ModelWrapper)
+	if !wrapper.Model.ManualInput {
+		t.Fatalf("Expected the filter input to be shown")
+	}
+	result, _ = HandleTextInputSubmission(wrapper.Model)
+	wrapper = result.(ModelWrapper)
+	result, _ = HandleApprovalFilterSubmission(wrapper.Model, " api ")
+	wrapper = result.(ModelWrapper)
+	if wrapper.Model.ApprovalsFilter != "api" || len(wrapper.Model.Table.Rows()) != 2 {
+		t.Fatalf("Expected the approvals to be filtered, got %v", wrapper.Model.Table.Rows())
+	}
+	result, _ = HandleApprovalsKey(wrapper.Model, constants.KeyMarkAll)
+	wrapper = result.(ModelWrapper)
+	marked := getMarkedApprovals(wrapper.Model)
+	if len(marked) != 3 || marked[0].Token != "token-1" || marked[2].Token != "token-3" {
+		t.Errorf("Expected the filtered approvals to be marked as well, got %v", marked)
+	}
+
+	// Marking all again unmarks the filtered approvals only
+	result, _ = HandleApprovalsKey(wrapper.Model, constants.KeyMarkAll)
+	if marked := result.(ModelWrapper).Model.MarkedApprovals; len(marked) != 1 || marked[0] != "token-2" {
+		t.Errorf("Expected only the unfiltered approval to stay marked, got %v", marked)
+	}
+
+	// Navigating back clears the filter before leaving the approvals
+	backResult := NavigateBack(wrapper.Model)
+	if backResult.CurrentView != constants.ViewApprovals || backResult.ApprovalsFilter != "" {
+		t.Errorf("Expected to stay at ViewApprovals with the filter cleared, got %v", backResult.CurrentView)
+	}
+}
+
+// TestBulkApprovalFlow tests approving the marked approvals with one comment
+func TestBulkApprovalFlow(t *testing.T) {
+	m := newApprovalsModel(
+		cloud.ApprovalAction{PipelineName: "api-release", StageName: "Prod", ActionName: "Approve", Token: "token-1"},
+		cloud.ApprovalAction{PipelineName: "web-release", StageName: "Prod", ActionName: "Approve", Token: "token-2"},
+	)
+	m.MarkedApprovals = []string{"token-1", "token-2"}
+
+	// Selecting with marked approvals starts the bulk approval
+	result, _ := HandleTableSelect(m)
+	wrapper := result.(ModelWrapper)
+	if wrapper.Model.CurrentView != constants.ViewBulkConfirmation {
+		t.Fatalf("Expected to be at ViewBulkConfirmation, got %v", wrapper.Model.CurrentView)
+	}
+
+	result, _ = HandleBulkConfirmationSelection(wrapper.Model)
+	wrapper = result.(ModelWrapper)
+	if !wrapper.Model.ApproveAction || !wrapper.Model.ManualInput {
+		t.Fatalf("Expected to prompt for the approval comment")
+	}
+
+	// The comment is required
+	_, cmd := HandleBulkCommentSubmission(wrapper.Model, "  ")
+	if msg, ok := cmd().(model.ErrMsg); !ok || msg.Err.Error() != constants.MsgErrorEmptyComment {
+		t.Errorf("Expected an error for the empty comment, got %v", msg)
+	}
+
+	result, cmd = HandleBulkCommentSubmission(wrapper.Model, "Release 1.2")
+	if cmd == nil || !result.(ModelWrapper).Model.IsLoading {
+		t.Fatalf("Expected a command executing the bulk approval")
+	}
+
+	// Each approval reports its own outcome
+	HandleBulkApprovalResult(wrapper.Model, []model.BulkApprovalResult{
+		{Approval: wrapper.Model.Approvals[0]},
+		{Approval: wrapper.Model.Approvals[1], Err: errors.New("token expired")},
+	})
+	if wrapper.Model.CurrentView != constants.ViewBulkResults || wrapper.Model.MarkedApprovals != nil {
+		t.Fatalf("Expected to be at ViewBulkResults with the marks cleared, got %v", wrapper.Model.CurrentView)
+	}
+	rows := wrapper.Model.Table.Rows()
+	if len(rows) != 2 || rows[0][3] != "Approved" || rows[1][3] != "Failed: token expired" {
+		t.Errorf("Expected the outcome of each approval, got %v", rows)
+	}
+
+	// Inbox results name the account and region of each approval
+	wrapper.Model.SelectedOperation = &model.Operation{Name: "Approvals Inbox"}
+	wrapper.Model.BulkResults[1].Approval.AccountID = "222222222222"
+	wrapper.Model.BulkResults[1].Approval.Region = "eu-west-1"
+	view.UpdateTableForView(wrapper.Model)
+	rows = wrapper.Model.Table.Rows()
+	if len(rows) != 2 || rows[1][0] != "222222222222" || rows[1][1] != "eu-west-1" || rows[1][5] != "Failed: token expired" {
+		t.Errorf("Expected the account and region of each inbox approval, got %v", rows)
+	}
+
+	// Leaving the results returns to the operation selection
+	result, _ = HandleBulkResultsSelection(wrapper.Model)
+	if done := result.(ModelWrapper).Model; done.CurrentView != constants.ViewSelectOperation || done.BulkResults != nil {
+		t.Errorf("Expected to return to ViewSelectOperation with the results cleared, got %v", done.CurrentView)
+	}
+}
+
+// TestBulkApprovalTimeoutAndCancel tests that each approval times out on its own and
+// that the approvals left after a cancel are skipped, keeping the approvals that went through
+func TestBulkApprovalTimeoutAndCancel(t *testing.T) {
+	m := newApprovalsModel(
+		cloud.ApprovalAction{PipelineName: "api-release", StageName: "Prod", ActionName: "Approve", Token: "token-1"},
+		cloud.ApprovalAction{PipelineName: "web-release", StageName: "Prod", ActionName: "Approve", Token: "token-2"},
+		cloud.ApprovalAction{PipelineName: "api-hotfix", StageName: "Prod", ActionName: "Approve", Token: "token-3"},
+	)
+	m.MarkedApprovals = []string{"token-1", "token-2", "token-3"}
+	m.ApproveAction = true
+	m.RequestTimeout = 50 * time.Millisecond
+
+	// A slow approval times out without taking the time of the next ones
+	m.Registry = InitializeTestRegistry(&bulkApprovalProvider{approve: func(ctx context.Context, action cloud.ApprovalAction) error {
+		if action.Token == "token-2" {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		time.Sleep(30 * time.Millisecond)
+		return ctx.Err()
+	}})
+	msg, ok := ExecuteBulkApproval(m, "Release 1.2")().(model.BulkApprovalMsg)
+	if !ok || len(msg.Results) != 3 {
+		t.Fatalf("Expected the results of every approval, got %v", msg)
+	}
+	if msg.Results[0].Err != nil || msg.Results[2].Err != nil {
+		t.Errorf("Expected the other approvals to succeed, got %v and %v", msg.Results[0].Err, msg.Results[2].Err)
+	}
+	if err := msg.Results[1].Err; err == nil || err.Error() != "Request timed out after 50ms" {
+		t.Errorf("Expected the slow approval to time out, got %v", err)
+	}
+
+	// Cancelling skips the approvals that have not started
+	m.Registry = InitializeTestRegistry(&bulkApprovalProvider{approve: func(ctx context.Context, action cloud.ApprovalAction) error {
+		CancelRequests(m)
+		return nil
+	}})
+	msg, ok = ExecuteBulkApproval(m, "Release 1.2")().(model.BulkApprovalMsg)
+	if !ok || len(msg.Results) != 3 {
+		t.Fatalf("Expected the results of every approval, got %v", msg)
+	}
+	if msg.Results[0].Skipped || msg.Results[0].Err != nil || !msg.Results[1].Skipped || !msg.Results[2].Skipped {
+		t.Fatalf("Expected only the first approval to go through, got %v", msg.Results)
+	}
+
+	HandleBulkApprovalResult(m, msg.Results)
+	rows := m.Table.Rows()
+	if m.CurrentView != constants.ViewBulkResults || rows[0][3] != "Approved" || rows[1][3] != "Skipped" {
+		t.Errorf("Expected the results with the skipped approvals, got %v", rows)
+	}
+	if m.MarkedApprovals != nil {
+		t.Errorf("Expected the marks to be cleared, got %v", m.MarkedApprovals)
+	}
+}
@@ -0,0 +1,261 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HandleApprovalsKey handles the keys marking and filtering approvals in the approvals view
+func HandleApprovalsKey(m *model.Model, key string) (tea.Model, tea.Cmd) {
+	switch key {
+	case constants.KeyMark:
+		return HandleApprovalMark(m)
+	case constants.KeyMarkAll:
+		return HandleApprovalMarkAll(m)
+	case constants.KeyFilter:
+		return HandleApprovalFilter(m)
+	default:
+		return WrapModel(m), nil
+	}
+}
+
+// HandleApprovalMark marks or unmarks the approval under the cursor
+func HandleApprovalMark(m *model.Model) (tea.Model, tea.Cmd) {
+	approvals := view.FilterApprovals(m.Approvals, m.ApprovalsFilter)
+	cursor := m.Table.Cursor()
+	if cursor < 0 || cursor >= len(approvals) {
+		return WrapModel(m), nil
+	}
+
+	newModel := m.Clone()
+	newModel.MarkedApprovals = toggleSelectedValue(m.MarkedApprovals, approvals[cursor].Token)
+	view.UpdateTableForView(newModel)
+	newModel.Table.SetCursor(cursor)
+	return WrapModel(newModel), nil
+}
+
+// HandleApprovalMarkAll marks every approval matching the filter, or unmarks them
+// when they are all marked already
+func HandleApprovalMarkAll(m *model.Model) (tea.Model, tea.Cmd) {
+	approvals := view.FilterApprovals(m.Approvals, m.ApprovalsFilter)
+	if len(approvals) == 0 {
+		return WrapModel(m), nil
+	}
+
+	allMarked := true
+	for _, approval := range approvals {
+		if !containsValue(m.MarkedApprovals, approval.Token) {
+			allMarked = false
+			break
+		}
+	}
+
+	newModel := m.Clone()
+	marked := make([]string, 0, len(m.MarkedApprovals)+len(approvals))
+	for _, token := range m.MarkedApprovals {
+		if !allMarked || !containsApprovalToken(approvals, token) {
+			marked = append(marked, token)
+		}
+	}
+	if !allMarked {
+		for _, approval := range approvals {
+			if !containsValue(marked, approval.Token) {
+				marked = append(marked, approval.Token)
+			}
+		}
+	}
+	newModel.MarkedApprovals = marked
+
+	view.UpdateTableForView(newModel)
+	newModel.Table.SetCursor(m.Table.Cursor())
+	return WrapModel(newModel), nil
+}
+
+// HandleApprovalFilter prompts for the text filtering the approvals
+func HandleApprovalFilter(m *model.Model) (tea.Model, tea.Cmd) {
+	newModel := m.Clone()
+	newModel.ManualInput = true
+	newModel.TextInput.SetValue(m.ApprovalsFilter)
+	newModel.TextInput.Focus()
+	newModel.TextInput.Placeholder = constants.MsgEnterApprovalFilter
+	return WrapModel(newModel), nil
+}
+
+// HandleApprovalFilterSubmission filters the approvals by the entered text
+func HandleApprovalFilterSubmission(m *model.Model, filter string) (tea.Model, tea.Cmd) {
+	newModel := m.Clone()
+	newModel.ApprovalsFilter = strings.TrimSpace(filter)
+	newModel.ManualInput = false
+	newModel.ResetTextInput()
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
+
+// HandleBulkApproval asks whether to approve or reject the marked approvals
+func HandleBulkApproval(m *model.Model) (tea.Model, tea.Cmd) {
+	if len(m.MarkedApprovals) == 0 {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoMarkedApprovals)}
+		}
+	}
+
+	newModel := m.Clone()
+	newModel.CurrentView = constants.ViewBulkConfirmation
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
+
+// HandleBulkConfirmationSelection handles the choice to approve or reject the marked
+// approvals and prompts for the shared comment
+func HandleBulkConfirmationSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	if selected := m.Table.SelectedRow(); len(selected) > 0 {
+		newModel := m.Clone()
+		switch selected[0] {
+		case "Approve":
+			newModel.ApproveAction = true
+		case "Reject":
+			newModel.ApproveAction = false
+		default:
+			return WrapModel(m), nil
+		}
+		newModel.ManualInput = true
+		newModel.SetTextInputForApproval(newModel.ApproveAction)
+		return WrapModel(newModel), nil
+	}
+	return WrapModel(m), nil
+}
+
+// HandleBulkCommentSubmission approves or rejects the marked approvals with the entered comment
+func HandleBulkCommentSubmission(m *model.Model, comment string) (tea.Model, tea.Cmd) {
+	if strings.TrimSpace(comment) == "" {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorEmptyComment)}
+		}
+	}
+
+	newModel := m.Clone()
+	newModel.ApprovalComment = comment
+	newModel.ManualInput = false
+	newModel.ResetTextInput()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgExecutingBulkApproval
+	return WrapModel(newModel), ExecuteBulkApproval(m, comment)
+}
+
+// ExecuteBulkApproval approves or rejects each marked approval, collecting the
+// outcome of every approval instead of stopping at the first failure. Each approval
+// times out on its own, and the approvals left when the requests are cancelled are
+// skipped, so the results always report the approvals that went through.
+func ExecuteBulkApproval(m *model.Model, comment string) tea.Cmd {
+	approvals := getMarkedApprovals(m)
+	approve := m.ApproveAction
+	parent := m.Requests.Context()
+	timeout := m.RequestTimeout
+	return func() tea.Msg {
+		// Get the provider from the registry
+		provider, err := m.Registry.Get("AWS")
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Get the approval operation from the provider
+		approvalOperation, err := getApprovalOperation(m, provider)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Execute the approval action for each approval using the operation
+		results := make([]model.BulkApprovalResult, len(approvals))
+		for i, approval := range approvals {
+			results[i].Approval = approval
+			if parent.Err() != nil {
+				results[i].Skipped = true
+				continue
+			}
+			results[i].Err = executeBulkApprovalItem(parent, timeout, func(ctx context.Context) error {
+				return approvalOperation.ApproveAction(ctx, approval, approve, comment)
+			})
+		}
+
+		return model.BulkApprovalMsg{Results: results}
+	}
+}
+
+// executeBulkApprovalItem runs one approval of a bulk approval with its own request
+// timeout, telling when it failed because it timed out or was cancelled
+func executeBulkApprovalItem(parent context.Context, timeout time.Duration, approve func(ctx context.Context) error) error {
+	ctx, cancel := newRequestContext(parent, timeout)
+	defer cancel()
+
+	err := approve(ctx)
+	if err == nil {
+		return nil
+	}
+	switch ctxErr := ctx.Err(); {
+	case errors.Is(ctxErr, context.DeadlineExceeded):
+		return fmt.Errorf(constants.MsgErrorRequestTimedOut, timeout)
+	case errors.Is(ctxErr, context.Canceled):
+		return errors.New(constants.MsgRequestCancelled)
+	}
+	return err
+}
+
+// HandleBulkApprovalResult handles the results of a bulk approval
+func HandleBulkApprovalResult(m *model.Model, results []model.BulkApprovalResult) {
+	m.BulkResults = results
+	m.MarkedApprovals = nil
+	invalidateCache(m, constants.CacheApprovals, constants.CacheInboxApprovals, constants.CachePipelineStatus)
+	m.ApprovalComment = ""
+	m.CurrentView = constants.ViewBulkResults
+	view.UpdateTableForView(m)
+}
+
+// HandleBulkResultsSelection leaves the bulk approval results
+func HandleBulkResultsSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	newModel := m.Clone()
+	newModel.CurrentView = constants.ViewSelectOperation
+	newModel.ApproveAction = false
+	newModel.ResetApprovalState()
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
+
+// getMarkedApprovals returns the marked approvals in the order they are listed
+func getMarkedApprovals(m *model.Model) []cloud.ApprovalAction {
+	var approvals []cloud.ApprovalAction
+	for _, approval := range m.Approvals {
+		if containsValue(m.MarkedApprovals, approval.Token) {
+			approvals = append(approvals, approval)
+		}
+	}
+	return approvals
+}
+
+// containsApprovalToken checks if an approval with the token is in the approvals
+func containsApprovalToken(approvals []cloud.ApprovalAction, token string) bool {
+	for _, approval := range approvals {
+		if approval.Token == token {
+			return true
+		}
+	}
+	return false
+}
+
+// containsValue checks if a slice contains a value
+func containsValue(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
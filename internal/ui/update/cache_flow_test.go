@@ -0,0 +1,108 @@
+package update
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TestCachedReads tests reusing provider reads until they are invalidated
+func TestCachedReads(t *testing.T) {
+	m := model.New()
+	m.ProviderState.ProviderName = "AWS"
+	m.AwsProfile = "dev"
+	m.AwsRegion = "us-east-1"
+
+	fetches := 0
+	fetch := func() tea.Msg {
+		fetches++
+		return model.PipelineStatusMsg{Pipelines: []cloud.PipelineStatus{{Name: "TestPipeline"}}}
+	}
+	cmd := fetchCached(m, constants.CachePipelineStatus, constants.PipelineStatusCacheTTL, fetch)
+	cmd()
+	if msg, ok := cmd().(model.PipelineStatusMsg); !ok || len(msg.Pipelines) != 1 || fetches != 1 {
+		t.Fatalf("Expected the second read to come from the cache, got %d fetches", fetches)
+	}
+
+	// Another region is cached apart
+	m.AwsRegion = "eu-west-1"
+	fetchCached(m, constants.CachePipelineStatus, constants.PipelineStatusCacheTTL, fetch)()
+	if fetches != 2 {
+		t.Errorf("Expected another region to be fetched, got %d fetches", fetches)
+	}
+
+	// Writes drop the cached reads
+	invalidateCache(m, constants.CachePipelineStatus)
+	cmd()
+	if fetches != 3 {
+		t.Errorf("Expected an invalidated read to be fetched again, got %d fetches", fetches)
+	}
+
+	// Errors are never cached
+	failures := 0
+	failing := fetchCached(m, constants.CacheFunctionStatus, constants.FunctionStatusCacheTTL, func() tea.Msg {
+		failures++
+		return model.ErrMsg{Err: errors.New("throttled")}
+	})
+	failing()
+	failing()
+	if failures != 2 {
+		t.Errorf("Expected a failed read to be fetched again, got %d fetches", failures)
+	}
+}
+
+// TestManualRefresh tests that a manual refresh bypasses the cache and keeps the auto-refresh loop
+func TestManualRefresh(t *testing.T) {
+	m := model.New()
+	m.RefreshInterval = 0
+	m.Pipelines = []cloud.PipelineStatus{{Name: "TestPipeline"}}
+	m.CurrentView = constants.ViewPipelineStatus
+	view.UpdateTableForView(m)
+	ScheduleAutoRefresh(m)
+	m.Cache.Set(getCacheKey(m, constants.CachePipelineStatus), model.PipelineStatusMsg{}, constants.PipelineStatusCacheTTL)
+
+	result, cmd := HandleManualRefresh(m)
+	refreshing := result.(ModelWrapper).Model
+	if !refreshing.IsLoading || cmd == nil {
+		t.Fatalf("Expected the pipelines to be fetched again")
+	}
+	if _, ok := m.Cache.Get(getCacheKey(m, constants.CachePipelineStatus)); ok {
+		t.Errorf("Expected the cached pipelines to be dropped")
+	}
+
+	// The registry has no provider, so the refresh fails without replacing the view
+	msg, ok := cmd().(model.RefreshMsg)
+	if !ok || !msg.Manual {
+		t.Fatalf("Expected a manual refresh result, got %T", msg)
+	}
+	if next := HandleRefreshResult(refreshing, msg); next != nil {
+		t.Errorf("Expected a manual refresh not to schedule a tick")
+	}
+	if refreshing.RefreshErr == nil || len(refreshing.Pipelines) != 1 {
+		t.Errorf("Expected the refresh error to be kept apart from the pipelines")
+	}
+}
+
+// TestRestoreCursorAfterBack tests that navigating back from a pipeline keeps the cursor on it
+func TestRestoreCursorAfterBack(t *testing.T) {
+	m := model.New()
+	m.Pipelines = []cloud.PipelineStatus{{Name: "First"}, {Name: "Second"}, {Name: "Third"}}
+	m.SelectedPipeline = &m.Pipelines[2]
+	m.CurrentView = constants.ViewPipelineStages
+	view.UpdateTableForView(m)
+
+	backResult := NavigateBack(m)
+	view.UpdateTableForView(backResult)
+	RestoreCursorAfterBack(backResult, m)
+	if backResult.CurrentView != constants.ViewPipelineStatus || len(backResult.Pipelines) != 3 {
+		t.Fatalf("Expected to navigate back to the kept pipelines, got %v", backResult.CurrentView)
+	}
+	if backResult.Table.Cursor() != 2 {
+		t.Errorf("Expected the cursor on the Third pipeline, got %d", backResult.Table.Cursor())
+	}
+}
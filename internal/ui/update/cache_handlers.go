@@ -0,0 +1,92 @@
+package update
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// fetchCached returns the command that loads the cached result of an operation, or
+// runs the fetch and caches its result for the TTL when there is none. Errors,
+// cancelled fetches and results missing failed pipelines or targets are never cached.
+func fetchCached(m *model.Model, operation string, ttl time.Duration, fetch tea.Cmd) tea.Cmd {
+	cache := m.Cache
+	if cache == nil {
+		return fetch
+	}
+
+	key := getCacheKey(m, operation)
+	return func() tea.Msg {
+		if msg, ok := cache.Get(key); ok {
+			return msg
+		}
+		msg := fetch()
+		if isCompleteResult(msg) {
+			cache.Set(key, msg, ttl)
+		}
+		return msg
+	}
+}
+
+// isCompleteResult checks if a fetch result holds all the data it was asked for
+func isCompleteResult(msg tea.Msg) bool {
+	switch msg := msg.(type) {
+	case model.ErrMsg, model.RequestCancelledMsg:
+		return false
+	case model.PipelineStatusMsg:
+		return len(msg.FailedPipelines) == 0
+	case model.ApprovalsMsg:
+		return len(msg.FailedPipelines) == 0 && len(msg.FailedTargets) == 0
+	default:
+		return true
+	}
+}
+
+// getCacheKey returns the key of an operation's reads in the configured account. The
+// approvals inbox reads every selected profile and region at once.
+func getCacheKey(m *model.Model, operation string) cloud.CacheKey {
+	key := cloud.CacheKey{
+		Provider:  m.ProviderState.ProviderName,
+		Profile:   m.AwsProfile,
+		Region:    m.AwsRegion,
+		Operation: operation,
+	}
+	if operation == constants.CacheInboxApprovals {
+		key.Profile = strings.Join(m.InboxProfiles, ",")
+		key.Region = strings.Join(m.InboxRegions, ",")
+	}
+	return key
+}
+
+// invalidateCache drops the cached results of the operations, so that their next
+// read fetches them again
+func invalidateCache(m *model.Model, operations ...string) {
+	if m.Cache == nil {
+		return
+	}
+	for _, operation := range operations {
+		m.Cache.Invalidate(operation)
+	}
+}
+
+// getViewCacheOperation returns the cached operation whose result the current view shows
+func getViewCacheOperation(m *model.Model) string {
+	switch m.CurrentView {
+	case constants.ViewPipelineStatus, constants.ViewPipelineStages, constants.ViewPipelineGraph:
+		return constants.CachePipelineStatus
+	case constants.ViewApprovals:
+		if m.SelectedOperation != nil && m.SelectedOperation.Name == "Approvals Inbox" {
+			return constants.CacheInboxApprovals
+		}
+		return constants.CacheApprovals
+	case constants.ViewFunctionStatus:
+		return constants.CacheFunctionStatus
+	default:
+		return ""
+	}
+}
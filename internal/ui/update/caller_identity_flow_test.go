@@ -0,0 +1,93 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+)
+
+// identityProvider is an AWS provider signing its requests as the given identity
+type identityProvider struct {
+	cloud.Provider
+	identity *cloud.CallerIdentity
+	err      error
+}
+
+func (p *identityProvider) Name() string {
+	return "AWS"
+}
+
+func (p *identityProvider) Services() []cloud.Service {
+	return nil
+}
+
+func (p *identityProvider) LoadConfig(profile, region string) error {
+	return nil
+}
+
+func (p *identityProvider) GetCallerIdentity(ctx context.Context) (*cloud.CallerIdentity, error) {
+	return p.identity, p.err
+}
+
+// selectIdentityRegion selects the region of the prod profile and returns the model on
+// the service selection with the identity looked up
+func selectIdentityRegion(t *testing.T, provider cloud.Provider) *model.Model {
+	m := model.New()
+	m.Registry = InitializeTestRegistry(provider)
+	m.CurrentView = constants.ViewAWSConfig
+	m.Regions = constants.DefaultAWSRegions
+	m.SetAwsProfile("prod")
+	view.UpdateTableForView(m)
+	m.Table.SetCursor(1)
+
+	newModel, cmd := HandleAWSConfigSelection(m)
+	m = newModel.(ModelWrapper).Model
+	if m.CurrentView != constants.ViewSelectService || cmd == nil {
+		t.Fatalf("Expected the service selection to look up the identity, got view %v", m.CurrentView)
+	}
+	msg, ok := cmd().(model.CallerIdentityMsg)
+	if !ok || msg.Profile != "prod" {
+		t.Fatalf("Expected the identity of the prod profile, got %v", msg)
+	}
+	HandleCallerIdentity(m, msg)
+	return m
+}
+
+// TestCallerIdentityFlow tests showing the account and identity the selected profile
+// signs in as
+func TestCallerIdentityFlow(t *testing.T) {
+	provider := &identityProvider{identity: &cloud.CallerIdentity{
+		Account:      "222222222222",
+		AccountAlias: "acme-prod",
+		ARN:          "arn:aws:sts::222222222222:assumed-role/Deployer/session",
+	}}
+	m := selectIdentityRegion(t, provider)
+
+	rendered := view.Render(m)
+	if !strings.Contains(rendered, "Account: 222222222222 (acme-prod)") ||
+		!strings.Contains(rendered, "Identity: arn:aws:sts::222222222222:assumed-role/Deployer/session") {
+		t.Errorf("Expected the account and identity in the context, got %q", rendered)
+	}
+
+	// The identity of a profile the user has left is dropped
+	m.SetAwsProfile("dev")
+	HandleCallerIdentity(m, model.CallerIdentityMsg{Profile: "prod", Identity: provider.identity})
+	if m.CallerIdentity != nil {
+		t.Errorf("Expected the identity of another profile to be dropped, got %+v", m.CallerIdentity)
+	}
+}
+
+// TestCallerIdentityFlowError tests warning that the account could not be verified
+func TestCallerIdentityFlowError(t *testing.T) {
+	m := selectIdentityRegion(t, &identityProvider{err: errors.New("ExpiredToken")})
+
+	if rendered := view.Render(m); !strings.Contains(rendered, "Account: could not be verified (ExpiredToken)") {
+		t.Errorf("Expected the account to be unverified, got %q", rendered)
+	}
+}
@@ -0,0 +1,34 @@
+package update
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// FetchCallerIdentity returns the command looking up the account and identity the
+// provider signs the requests of the selected profile as. The identity of the previous
+// profile is cleared, so the views never show an account the user is not in.
+func FetchCallerIdentity(m *model.Model, provider cloud.Provider) tea.Cmd {
+	m.CallerIdentity = nil
+	m.CallerIdentityErr = nil
+
+	profile := m.GetAwsProfile()
+	return withRequest(m, func(ctx context.Context) tea.Msg {
+		identity, err := provider.GetCallerIdentity(ctx)
+		return model.CallerIdentityMsg{Profile: profile, Identity: identity, Err: err}
+	})
+}
+
+// HandleCallerIdentity stores the identity of the selected profile. Identities of a
+// profile the user has since left are dropped.
+func HandleCallerIdentity(m *model.Model, msg model.CallerIdentityMsg) {
+	if msg.Profile != m.GetAwsProfile() {
+		return
+	}
+	m.CallerIdentity = msg.Identity
+	m.CallerIdentityErr = msg.Err
+}
@@ -1,18 +1,19 @@
 package update
 
 import (
-	"context"
-	"fmt"
 	"sort"
-	"strings"
 
 	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
 	"github.com/HenryOwenz/cloudgate/internal/ui/model"
 	"github.com/HenryOwenz/cloudgate/internal/ui/view"
 )
 
-// UpdateModelForView updates the model based on the current view
+// UpdateModelForView updates the model based on the current view. Provider calls are
+// cancelled with the other requests and time out after the request timeout.
 func UpdateModelForView(m *model.Model) error {
+	ctx, cancel := newRequestContext(m.Requests.Context(), m.RequestTimeout)
+	defer cancel()
+
 	switch m.CurrentView {
 	case constants.ViewAWSConfig:
 		if m.AwsProfile == "" {
@@ -30,6 +31,9 @@ func UpdateModelForView(m *model.Model) error {
 			sort.Strings(profiles)
 
 			m.Profiles = profiles
+			if details, err := provider.GetProfileDetails(); err == nil {
+				m.SetProfileDetails(details)
+			}
 		} else {
 			m.Regions = constants.DefaultAWSRegions
 		}
@@ -48,7 +52,6 @@ func UpdateModelForView(m *model.Model) error {
 			}
 
 			// Get approvals using the operation
-			ctx := context.Background()
 			approvals, err := approvalOperation.GetPendingApprovals(ctx)
 			if err != nil {
 				return err
@@ -72,7 +75,6 @@ func UpdateModelForView(m *model.Model) error {
 			}
 
 			// Get pipeline status using the operation
-			ctx := context.Background()
 			pipelines, err := statusOperation.GetPipelineStatus(ctx)
 			if err != nil {
 				return err
@@ -105,68 +107,3 @@ func UpdateModelForView(m *model.Model) error {
 	view.UpdateTableForView(m)
 	return nil
 }
-
-// ExecuteAction executes the selected action
-func ExecuteAction(m *model.Model) error {
-	if m.SelectedOperation != nil && m.SelectedOperation.Name == "Start Pipeline" {
-		if m.SelectedPipeline == nil {
-			return fmt.Errorf(constants.MsgErrorNoPipeline)
-		}
-
-		if m.Provider == nil {
-			// Get the provider from the registry
-			provider, err := m.Registry.Get("AWS")
-			if err != nil {
-				return err
-			}
-			m.Provider = provider
-		}
-
-		// Get the StartPipelineOperation from the provider
-		startOperation, err := m.Provider.GetStartPipelineOperation()
-		if err != nil {
-			return err
-		}
-
-		if m.ManualCommitID && strings.TrimSpace(m.CommitID) == "" {
-			return fmt.Errorf(constants.MsgErrorEmptyCommitID)
-		}
-
-		// Start the pipeline execution using the operation
-		ctx := context.Background()
-		err = startOperation.StartPipelineExecution(ctx, m.SelectedPipeline.Name, m.CommitID)
-
-		HandlePipelineExecution(m, err)
-		return nil
-	}
-
-	if m.SelectedApproval == nil {
-		return fmt.Errorf(constants.MsgErrorNoApproval)
-	}
-
-	if m.Provider == nil {
-		// Get the provider from the registry
-		provider, err := m.Registry.Get("AWS")
-		if err != nil {
-			return err
-		}
-		m.Provider = provider
-	}
-
-	// Get the CodePipelineManualApprovalOperation from the provider
-	approvalOperation, err := m.Provider.GetCodePipelineManualApprovalOperation()
-	if err != nil {
-		return err
-	}
-
-	if strings.TrimSpace(m.ApprovalComment) == "" {
-		return fmt.Errorf(constants.MsgErrorEmptyComment)
-	}
-
-	// Execute the approval action using the operation
-	ctx := context.Background()
-	err = approvalOperation.ApproveAction(ctx, *m.SelectedApproval, m.ApproveAction, m.ApprovalComment)
-
-	HandleApprovalResult(m, err)
-	return nil
-}
@@ -59,6 +59,18 @@ func HandleSummaryConfirmation(m *model.Model) (tea.Model, tea.Cmd) {
 	// For non-manual input, check if we have a selected row
 	if selected := m.Table.SelectedRow(); len(selected) > 0 {
 		newModel := m.Clone()
+		if m.SelectedOperation != nil && m.SelectedOperation.Name == "Start Pipeline" {
+			switch selected[0] {
+			case "Latest Commit":
+				// Start from the latest source revision
+				resetSourceRevision(newModel)
+				newModel.CurrentView = constants.ViewExecutingAction
+				view.UpdateTableForView(newModel)
+				return WrapModel(newModel), nil
+			case "Manual Input":
+				return HandleSourceRevision(newModel)
+			}
+		}
 		if selected[0] == "Execute" {
 			// Start loading and execute the action
 			newModel.IsLoading = true
@@ -72,8 +84,8 @@ func HandleSummaryConfirmation(m *model.Model) (tea.Model, tea.Cmd) {
 			newModel.SelectedApproval = nil
 			newModel.SelectedPipeline = nil
 			newModel.ApprovalComment = ""
-			newModel.CommitID = ""
-			newModel.ManualCommitID = false
+			resetSourceRevision(newModel)
+			resetPipelineVariables(newModel)
 			newModel.ResetTextInput()
 			view.UpdateTableForView(newModel)
 			return WrapModel(newModel), nil
@@ -86,6 +98,12 @@ func HandleSummaryConfirmation(m *model.Model) (tea.Model, tea.Cmd) {
 func HandleExecutionSelection(m *model.Model) (tea.Model, tea.Cmd) {
 	if selected := m.Table.SelectedRow(); len(selected) > 0 {
 		newModel := m.Clone()
+		if selected[0] == "Source Revision" {
+			return HandleSourceRevision(newModel)
+		}
+		if selected[0] == "Variables" {
+			return HandlePipelineVariables(newModel)
+		}
 		if selected[0] == "Execute" {
 			// Start loading and execute the action
 			newModel.IsLoading = true
@@ -99,8 +117,8 @@ func HandleExecutionSelection(m *model.Model) (tea.Model, tea.Cmd) {
 			newModel.SelectedApproval = nil
 			newModel.SelectedPipeline = nil
 			newModel.ApprovalComment = ""
-			newModel.CommitID = ""
-			newModel.ManualCommitID = false
+			resetSourceRevision(newModel)
+			resetPipelineVariables(newModel)
 			newModel.ResetTextInput()
 			view.UpdateTableForView(newModel)
 			return WrapModel(newModel), nil
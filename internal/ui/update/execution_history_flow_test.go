@@ -0,0 +1,88 @@
+package update
+
+import (
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// TestExecutionHistoryFlow tests paging through a pipeline's execution history
+// and opening the details of an execution.
+func TestExecutionHistoryFlow(t *testing.T) {
+	m := model.New()
+	m.SelectedOperation = &model.Operation{Name: "Execution History"}
+	m.SelectedPipeline = &cloud.PipelineStatus{Name: "TestPipeline"}
+	m.CurrentView = constants.ViewPipelineStatus
+
+	// Load the first page
+	HandleExecutionHistoryLoaded(m, model.ExecutionHistoryMsg{
+		Executions: []cloud.PipelineExecution{
+			{ExecutionID: "execution-3", Status: "InProgress"},
+			{ExecutionID: "execution-2", Status: "Failed"},
+		},
+		NextToken: "page-2",
+	})
+	if m.CurrentView != constants.ViewExecutionHistory {
+		t.Fatalf("Expected to be at ViewExecutionHistory, got %v", m.CurrentView)
+	}
+
+	// The last row loads the next page
+	rows := m.Table.Rows()
+	if len(rows) != 3 || rows[2][0] != "Load More" {
+		t.Fatalf("Expected two executions and a Load More row, got %v", rows)
+	}
+	m.Table.SetCursor(2)
+	result, cmd := HandleExecutionHistorySelection(m)
+	if cmd == nil {
+		t.Errorf("Expected Load More to return a command")
+	}
+	if wrapper := result.(ModelWrapper); !wrapper.Model.IsLoading {
+		t.Errorf("Expected IsLoading to be true")
+	}
+
+	// The next page is appended and the Load More row goes away on the last page
+	HandleExecutionHistoryLoaded(m, model.ExecutionHistoryMsg{
+		Executions: []cloud.PipelineExecution{
+			{
+				ExecutionID: "execution-1",
+				Status:      "Succeeded",
+				SourceRevisions: []cloud.ExecutionSourceRevision{
+					{ActionName: "Source", RevisionID: "abc123", RevisionSummary: "Initial commit"},
+				},
+			},
+		},
+		Append: true,
+	})
+	rows = m.Table.Rows()
+	if len(rows) != 3 || rows[2][0] != "execution-1" {
+		t.Fatalf("Expected three executions without a Load More row, got %v", rows)
+	}
+	if m.Table.Cursor() != 2 {
+		t.Errorf("Expected the cursor on the first execution of the new page, got %d", m.Table.Cursor())
+	}
+
+	// Open the details of the selected execution
+	result, _ = HandleExecutionHistorySelection(m)
+	wrapper := result.(ModelWrapper)
+	if wrapper.Model.CurrentView != constants.ViewExecutionDetails {
+		t.Fatalf("Expected to be at ViewExecutionDetails, got %v", wrapper.Model.CurrentView)
+	}
+	if wrapper.Model.SelectedExecution == nil || wrapper.Model.SelectedExecution.ExecutionID != "execution-1" {
+		t.Errorf("Expected execution-1 to be selected, got %v", wrapper.Model.SelectedExecution)
+	}
+
+	// Navigating back returns to the history and then to the pipeline selection
+	backResult := NavigateBack(wrapper.Model)
+	if backResult.CurrentView != constants.ViewExecutionHistory {
+		t.Errorf("Expected to navigate back to ViewExecutionHistory, got %v", backResult.CurrentView)
+	}
+	backResult = NavigateBack(backResult)
+	if backResult.CurrentView != constants.ViewPipelineStatus {
+		t.Errorf("Expected to navigate back to ViewPipelineStatus, got %v", backResult.CurrentView)
+	}
+	if backResult.Executions != nil {
+		t.Errorf("Expected the executions to be cleared")
+	}
+}
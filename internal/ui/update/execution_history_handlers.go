@@ -0,0 +1,104 @@
+package update
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HandleExecutionHistory loads the first page of the selected pipeline's executions
+func HandleExecutionHistory(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.SelectedPipeline == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoPipeline)}
+		}
+	}
+
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgLoadingExecutions
+
+	return WrapModel(newModel), loadPipelineExecutions(m, m.SelectedPipeline.Name, "")
+}
+
+// HandleExecutionHistoryLoaded handles a page of executions loaded for the selected pipeline
+func HandleExecutionHistoryLoaded(m *model.Model, msg model.ExecutionHistoryMsg) {
+	previousCount := 0
+	if msg.Append {
+		// Copy on append so clones of the model are not affected
+		previousCount = len(m.Executions)
+		m.Executions = append(m.Executions[:previousCount:previousCount], msg.Executions...)
+	} else {
+		m.Executions = msg.Executions
+	}
+	m.ExecutionsToken = msg.NextToken
+	m.CurrentView = constants.ViewExecutionHistory
+	view.UpdateTableForView(m)
+
+	// Keep the cursor on the first execution of the new page
+	m.Table.SetCursor(previousCount)
+}
+
+// HandleExecutionHistorySelection handles the selection of an execution or of the next page
+func HandleExecutionHistorySelection(m *model.Model) (tea.Model, tea.Cmd) {
+	if selected := m.Table.SelectedRow(); len(selected) > 0 {
+		if selected[0] == "Load More" {
+			if m.SelectedPipeline == nil || m.ExecutionsToken == "" {
+				return WrapModel(m), nil
+			}
+
+			newModel := m.Clone()
+			newModel.IsLoading = true
+			newModel.LoadingMsg = constants.MsgLoadingExecutions
+			return WrapModel(newModel), loadPipelineExecutions(m, m.SelectedPipeline.Name, m.ExecutionsToken)
+		}
+
+		for _, execution := range m.Executions {
+			if execution.ExecutionID == selected[0] {
+				newModel := m.Clone()
+				newModel.SelectedExecution = &execution
+				newModel.CurrentView = constants.ViewExecutionDetails
+				view.UpdateTableForView(newModel)
+				return WrapModel(newModel), nil
+			}
+		}
+
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoExecution)}
+		}
+	}
+	return WrapModel(m), nil
+}
+
+// loadPipelineExecutions returns a command loading a page of a pipeline's executions
+func loadPipelineExecutions(m *model.Model, pipelineName, nextToken string) tea.Cmd {
+	return withRequest(m, func(ctx context.Context) tea.Msg {
+		// Get the provider from the registry
+		provider, err := m.Registry.Get("AWS")
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Get the ExecutionHistoryOperation from the provider
+		historyOperation, err := provider.GetExecutionHistoryOperation()
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Get the pipeline executions using the operation
+		executions, token, err := historyOperation.GetPipelineExecutions(ctx, pipelineName, nextToken)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.ExecutionHistoryMsg{
+			Executions: executions,
+			NextToken:  token,
+			Append:     nextToken != "",
+		}
+	})
+}
@@ -0,0 +1,134 @@
+package update
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+)
+
+// TestExecutionWatchFlow tests following a started pipeline execution until it finishes
+func TestExecutionWatchFlow(t *testing.T) {
+	m := model.New()
+	m.SelectedOperation = &model.Operation{Name: "Start Pipeline"}
+	m.SelectedPipeline = &cloud.PipelineStatus{Name: "TestPipeline"}
+	m.CurrentView = constants.ViewExecutingAction
+
+	// Starting the pipeline switches to the watch of the new execution
+	cmd := HandlePipelineExecution(m, "execution-1", nil)
+	if cmd == nil {
+		t.Fatalf("Expected a command loading the execution progress")
+	}
+	if m.CurrentView != constants.ViewExecutionWatch || m.WatchExecutionID != "execution-1" || m.SelectedPipeline == nil {
+		t.Fatalf("Expected to watch execution-1 of the pipeline, got %v", m.CurrentView)
+	}
+
+	// The progress lists each stage with its elapsed time and its actions in run order
+	started := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	progress := &cloud.ExecutionProgress{
+		ExecutionID: "execution-1",
+		Status:      "InProgress",
+		Stages: []cloud.StageProgress{
+			{
+				Name:      "Source",
+				Status:    "Succeeded",
+				StartTime: started,
+				EndTime:   started.Add(65 * time.Second),
+				Actions:   []cloud.ActionStatus{{Name: "Checkout", Status: "Succeeded", RunOrder: 1}},
+			},
+			{
+				Name:      "Build",
+				Status:    "InProgress",
+				StartTime: started.Add(70 * time.Second),
+				Actions: []cloud.ActionStatus{
+					{Name: "Package", Status: cloud.ExecutionStatusPending, RunOrder: 2},
+					{Name: "Compile", Status: "InProgress", RunOrder: 1},
+				},
+			},
+			{Name: "Deploy", Status: cloud.ExecutionStatusPending},
+		},
+	}
+	if cmd := HandleExecutionProgress(m, model.ExecutionProgressMsg{ExecutionID: "execution-1", Seq: m.WatchSeq, Progress: progress}); cmd == nil {
+		t.Errorf("Expected the running execution to be polled again")
+	}
+	rows := m.Table.Rows()
+	if len(rows) != 6 {
+		t.Fatalf("Expected a row for each stage and action, got %v", rows)
+	}
+	if rows[0][2] != "1m5s" || rows[1][0] != "  [1] Checkout" || rows[3][0] != "  [1] Compile" || rows[5][2] != "-" {
+		t.Errorf("Expected the stages with their elapsed time and actions in run order, got %v", rows)
+	}
+
+	// Progress of another execution is dropped
+	other := &cloud.ExecutionProgress{ExecutionID: "execution-2", Status: "InProgress"}
+	if cmd := HandleExecutionProgress(m, model.ExecutionProgressMsg{ExecutionID: "execution-2", Seq: m.WatchSeq, Progress: other}); cmd != nil {
+		t.Errorf("Expected the progress of another execution to be dropped")
+	}
+	if m.ExecutionProgress != progress {
+		t.Errorf("Expected the watched progress to be kept")
+	}
+
+	// A failed poll keeps the progress, shows the error and keeps polling
+	pollErr := errors.New("ThrottlingException: Rate exceeded")
+	if cmd := HandleExecutionProgress(m, model.ExecutionProgressMsg{ExecutionID: "execution-1", Seq: m.WatchSeq, Err: pollErr}); cmd == nil {
+		t.Errorf("Expected the execution to be polled again after a failed poll")
+	}
+	if m.ExecutionProgress != progress || m.WatchErr != pollErr {
+		t.Errorf("Expected the last progress to be kept with the poll error, got %v", m.WatchErr)
+	}
+	if rendered := view.Render(m); !strings.Contains(rendered, "Poll failed: ThrottlingException: Rate exceeded") {
+		t.Errorf("Expected the poll error in the context, got %q", rendered)
+	}
+
+	// Polling stops once the execution finishes
+	finished := *progress
+	finished.Status = "Succeeded"
+	if cmd := HandleExecutionProgress(m, model.ExecutionProgressMsg{ExecutionID: "execution-1", Seq: m.WatchSeq, Progress: &finished}); cmd != nil {
+		t.Errorf("Expected the finished execution not to be polled again")
+	}
+	if m.WatchErr != nil {
+		t.Errorf("Expected a successful poll to clear the poll error, got %v", m.WatchErr)
+	}
+
+	// Restarting the watch polls again and drops the ticks of the earlier polling
+	seq := m.WatchSeq
+	result, cmd := HandleRestartExecutionWatch(m)
+	restarted := result.(ModelWrapper).Model
+	if cmd == nil || restarted.WatchSeq != seq+1 {
+		t.Fatalf("Expected the restart to poll the execution again")
+	}
+	if cmd := HandleWatchTick(restarted, model.WatchTickMsg{ExecutionID: "execution-1", Seq: seq}); cmd != nil {
+		t.Errorf("Expected the ticks of the earlier polling to be dropped")
+	}
+	if cmd := HandleExecutionProgress(restarted, model.ExecutionProgressMsg{ExecutionID: "execution-1", Seq: seq, Progress: progress}); cmd != nil {
+		t.Errorf("Expected the progress of the earlier polling to be dropped")
+	}
+
+	// Leaving the watch stops polling
+	backResult := NavigateBack(m)
+	if backResult.CurrentView != constants.ViewSelectOperation || backResult.WatchExecutionID != "" {
+		t.Errorf("Expected to navigate back to ViewSelectOperation with the watch cleared, got %v", backResult.CurrentView)
+	}
+	if cmd := HandleWatchTick(backResult, model.WatchTickMsg{ExecutionID: "execution-1", Seq: backResult.WatchSeq}); cmd != nil {
+		t.Errorf("Expected no polling after leaving the watch")
+	}
+}
+
+// TestPipelineExecutionWithoutID tests that a start without an execution ID returns to the operations
+func TestPipelineExecutionWithoutID(t *testing.T) {
+	m := model.New()
+	m.SelectedPipeline = &cloud.PipelineStatus{Name: "TestPipeline"}
+	m.CurrentView = constants.ViewExecutingAction
+
+	if cmd := HandlePipelineExecution(m, "", nil); cmd != nil {
+		t.Errorf("Expected no command without an execution ID")
+	}
+	if m.CurrentView != constants.ViewSelectOperation || m.SelectedPipeline != nil {
+		t.Errorf("Expected to return to ViewSelectOperation, got %v", m.CurrentView)
+	}
+}
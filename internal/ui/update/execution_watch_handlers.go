@@ -0,0 +1,149 @@
+package update
+
+import (
+	"context"
+	"time"
+
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// StartExecutionWatch switches to the live view of a started pipeline execution and
+// returns the command that loads its progress
+func StartExecutionWatch(m *model.Model, executionID string) tea.Cmd {
+	m.WatchExecutionID = executionID
+	m.ExecutionProgress = nil
+	m.WatchSeq++
+	m.WatchErr = nil
+	m.CurrentView = constants.ViewExecutionWatch
+	view.UpdateTableForView(m)
+	return FetchExecutionProgress(m)
+}
+
+// HandleRestartExecutionWatch polls the watched execution again right away, replacing
+// the polling of the watch, so a watch that stopped can be resumed
+func HandleRestartExecutionWatch(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.WatchExecutionID == "" {
+		return WrapModel(m), nil
+	}
+
+	newModel := m.Clone()
+	newModel.WatchSeq++
+	newModel.WatchErr = nil
+	return WrapModel(newModel), FetchExecutionProgress(newModel)
+}
+
+// FetchExecutionProgress fetches the progress of the watched pipeline execution. Failed
+// polls are returned as the progress message of the watch so that it keeps polling.
+func FetchExecutionProgress(m *model.Model) tea.Cmd {
+	if m.SelectedPipeline == nil || m.WatchExecutionID == "" {
+		return nil
+	}
+
+	pipelineName := m.SelectedPipeline.Name
+	executionID := m.WatchExecutionID
+	seq := m.WatchSeq
+	previous := m.ExecutionProgress
+	fetch := withRequest(m, func(ctx context.Context) tea.Msg {
+		// Get the provider from the registry
+		provider, err := m.Registry.Get("AWS")
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Get the ExecutionWatchOperation from the provider
+		watchOperation, err := provider.GetExecutionWatchOperation()
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Get the execution progress using the operation
+		progress, err := watchOperation.GetExecutionProgress(ctx, pipelineName, executionID, previous)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.ExecutionProgressMsg{
+			Progress: progress,
+		}
+	})
+
+	return func() tea.Msg {
+		switch msg := fetch().(type) {
+		case model.ExecutionProgressMsg:
+			msg.ExecutionID = executionID
+			msg.Seq = seq
+			return msg
+		case model.ErrMsg:
+			return model.ExecutionProgressMsg{ExecutionID: executionID, Seq: seq, Err: msg.Err}
+		default:
+			return msg
+		}
+	}
+}
+
+// HandleExecutionProgress updates the watch view with the progress of the watched
+// execution, and polls it again until the execution finishes. A failed poll keeps the
+// last progress and shows the error until a poll succeeds again.
+func HandleExecutionProgress(m *model.Model, msg model.ExecutionProgressMsg) tea.Cmd {
+	// Drop progress arriving after the watch was left or restarted
+	if m.CurrentView != constants.ViewExecutionWatch || msg.ExecutionID != m.WatchExecutionID || msg.Seq != m.WatchSeq {
+		return nil
+	}
+
+	if msg.Err != nil {
+		m.WatchErr = msg.Err
+		return scheduleWatchTick(m)
+	}
+	if msg.Progress == nil {
+		return nil
+	}
+
+	cursor := m.Table.Cursor()
+	m.ExecutionProgress = msg.Progress
+	m.WatchErr = nil
+	view.UpdateTableForView(m)
+	m.Table.SetCursor(cursor)
+
+	if isExecutionFinished(msg.Progress.Status) {
+		return nil
+	}
+	return scheduleWatchTick(m)
+}
+
+// HandleWatchTick polls the watched execution again, unless the watch was left,
+// restarted or moved on to another execution
+func HandleWatchTick(m *model.Model, msg model.WatchTickMsg) tea.Cmd {
+	if m.CurrentView != constants.ViewExecutionWatch || m.WatchExecutionID != msg.ExecutionID || m.WatchSeq != msg.Seq {
+		return nil
+	}
+	return FetchExecutionProgress(m)
+}
+
+// scheduleWatchTick returns the command that ticks the polling of the current watch
+func scheduleWatchTick(m *model.Model) tea.Cmd {
+	executionID := m.WatchExecutionID
+	seq := m.WatchSeq
+	return tea.Tick(constants.ExecutionWatchInterval, func(time.Time) tea.Msg {
+		return model.WatchTickMsg{ExecutionID: executionID, Seq: seq}
+	})
+}
+
+// isExecutionFinished checks if a pipeline execution status is final
+func isExecutionFinished(status string) bool {
+	switch status {
+	case "Succeeded", "Failed", "Stopped", "Superseded", "Cancelled":
+		return true
+	default:
+		return false
+	}
+}
+
+// resetExecutionWatch clears the watched execution
+func resetExecutionWatch(m *model.Model) {
+	m.WatchExecutionID = ""
+	m.ExecutionProgress = nil
+	m.WatchErr = nil
+}
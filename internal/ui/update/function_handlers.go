@@ -17,7 +17,12 @@ func HandleFunctionStatus(m *model.Model) (tea.Model, tea.Cmd) {
 	newModel.IsLoading = true
 	newModel.LoadingMsg = constants.MsgLoadingFunctions
 
-	return WrapModel(newModel), func() tea.Msg {
+	return WrapModel(newModel), FetchFunctionStatus(m)
+}
+
+// FetchFunctionStatus fetches function status from the provider
+func FetchFunctionStatus(m *model.Model) tea.Cmd {
+	return fetchCached(m, constants.CacheFunctionStatus, constants.FunctionStatusCacheTTL, withRequest(m, func(ctx context.Context) tea.Msg {
 		// Get the provider
 		provider, err := m.Registry.Get(m.ProviderState.ProviderName)
 		if err != nil {
@@ -31,7 +36,6 @@ func HandleFunctionStatus(m *model.Model) (tea.Model, tea.Cmd) {
 		}
 
 		// Get function status using the operation
-		ctx := context.Background()
 		functions, err := functionOperation.GetFunctionStatus(ctx)
 		if err != nil {
 			return model.ErrMsg{Err: err}
@@ -41,7 +45,7 @@ func HandleFunctionStatus(m *model.Model) (tea.Model, tea.Cmd) {
 			Functions: functions,
 			Provider:  provider,
 		}
-	}
+	}))
 }
 
 // HandleFunctionSelection handles the selection of a function
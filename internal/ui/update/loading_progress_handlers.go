@@ -0,0 +1,69 @@
+package update
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// newLoadingProgress returns the channel the progress of a fetch is relayed through.
+// Only the latest progress is kept until the spinner shows it.
+func newLoadingProgress() chan cloud.Progress {
+	return make(chan cloud.Progress, 1)
+}
+
+// reportLoadingProgress returns a context whose reads report their progress to the channel
+func reportLoadingProgress(ctx context.Context, progress chan cloud.Progress) context.Context {
+	return cloud.WithProgress(ctx, func(p cloud.Progress) {
+		select {
+		case <-progress:
+		default:
+		}
+		progress <- p
+	})
+}
+
+// withLoadingProgress runs the fetch while relaying its progress to the loading
+// spinner, and stops relaying once the fetch returns
+func withLoadingProgress(progress chan cloud.Progress, fetch tea.Cmd) tea.Cmd {
+	return tea.Batch(
+		func() tea.Msg {
+			defer close(progress)
+			return fetch()
+		},
+		listenLoadingProgress(progress),
+	)
+}
+
+// listenLoadingProgress returns the command that waits for the next progress of a fetch
+func listenLoadingProgress(progress <-chan cloud.Progress) tea.Cmd {
+	return func() tea.Msg {
+		p, ok := <-progress
+		if !ok {
+			return nil
+		}
+		return model.LoadingProgressMsg{Progress: p, Updates: progress}
+	}
+}
+
+// HandleLoadingProgress shows the progress of a fetch next to the loading spinner and
+// waits for the next one. Progress arriving after the fetch finished is dropped.
+func HandleLoadingProgress(m *model.Model, msg model.LoadingProgressMsg) tea.Cmd {
+	if m.IsLoading {
+		m.LoadingMsg = formatLoadingProgress(msg.Progress)
+	}
+	return listenLoadingProgress(msg.Updates)
+}
+
+// formatLoadingProgress returns the loading message of the pipelines listed or loaded so far
+func formatLoadingProgress(progress cloud.Progress) string {
+	if progress.Total == 0 {
+		return fmt.Sprintf(constants.MsgListedPipelines, progress.Loaded)
+	}
+	return fmt.Sprintf(constants.MsgLoadedPipelines, progress.Loaded, progress.Total)
+}
@@ -0,0 +1,50 @@
+package update
+
+import (
+	"context"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TestLoadingProgress tests relaying the progress of a fetch to the loading spinner
+func TestLoadingProgress(t *testing.T) {
+	m := model.New()
+	m.IsLoading = true
+
+	progress := newLoadingProgress()
+	ctx := reportLoadingProgress(context.Background(), progress)
+	cmd := withLoadingProgress(progress, func() tea.Msg {
+		cloud.ReportProgress(ctx, cloud.Progress{Loaded: 100})
+		cloud.ReportProgress(ctx, cloud.Progress{Loaded: 240})
+		cloud.ReportProgress(ctx, cloud.Progress{Loaded: 12, Total: 240})
+		return model.PipelineStatusMsg{}
+	})
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok || len(batch) != 2 {
+		t.Fatalf("Expected the fetch and the progress listener")
+	}
+	if _, ok := batch[0]().(model.PipelineStatusMsg); !ok {
+		t.Fatalf("Expected the fetch result")
+	}
+
+	// Only the latest progress is shown
+	msg, ok := batch[1]().(model.LoadingProgressMsg)
+	if !ok {
+		t.Fatalf("Expected the progress of the fetch")
+	}
+	next := HandleLoadingProgress(m, msg)
+	if m.LoadingMsg != "Loaded 12 of 240 pipelines..." {
+		t.Errorf("Expected the loaded pipelines, got %q", m.LoadingMsg)
+	}
+
+	// The listener stops once the fetch returned
+	if msg := next(); msg != nil {
+		t.Errorf("Expected no more progress, got %T", msg)
+	}
+	if got := formatLoadingProgress(cloud.Progress{Loaded: 240}); got != "Listed 240 pipelines..." {
+		t.Errorf("Expected the listed pipelines, got %q", got)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
 	"github.com/HenryOwenz/cloudgate/internal/ui/model"
 	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -19,6 +20,15 @@ func NavigateBack(m *model.Model) *model.Model {
 		newModel.CurrentView = constants.ViewProviders
 		newModel.ProviderState.ProviderName = ""
 	case constants.ViewAuthConfig:
+		// Leaving the MFA code prompt goes back to the region selection
+		if IsTokenCodePrompt(m) {
+			newModel.CurrentView = constants.ViewAWSConfig
+			resetTokenCodePrompt(newModel)
+			break
+		}
+		// Drop the entered configuration, which may hold secrets
+		newModel.ProviderState.AuthState.AuthConfig = make(map[string]string)
+		newModel.ProviderState.AuthState.ConfigKeys = nil
 		// Go back to auth method selection or provider selection
 		if len(m.ProviderState.AuthState.AvailableMethods) > 1 {
 			newModel.CurrentView = constants.ViewAuthMethodSelect
@@ -47,6 +57,9 @@ func NavigateBack(m *model.Model) *model.Model {
 			newModel.SetAwsRegion("")
 			newModel.SetAwsProfile("")
 			// Don't change the view - we'll stay in AWS config to show profiles
+		} else if len(m.ProviderState.AuthState.AvailableMethods) > 1 {
+			// If we're in profile selection, go back to the authentication methods
+			newModel.CurrentView = constants.ViewAuthMethodSelect
 		} else {
 			// If we're in profile selection, go back to providers
 			newModel.CurrentView = constants.ViewProviders
@@ -58,6 +71,10 @@ func NavigateBack(m *model.Model) *model.Model {
 		// In the future, this will go back to provider config
 		newModel.CurrentView = constants.ViewAWSConfig
 		newModel.SelectedService = nil
+		// Credential sources go back to their configuration
+		if len(m.ProviderState.AuthState.ConfigKeys) > 0 {
+			newModel.CurrentView = constants.ViewAuthConfig
+		}
 	case constants.ViewSelectCategory:
 		newModel.CurrentView = constants.ViewSelectService
 		newModel.SelectedCategory = nil
@@ -65,15 +82,47 @@ func NavigateBack(m *model.Model) *model.Model {
 		newModel.CurrentView = constants.ViewSelectCategory
 		newModel.SelectedOperation = nil
 	case constants.ViewApprovals:
+		// Clear the filter before leaving the approvals
+		if m.ApprovalsFilter != "" {
+			newModel.ApprovalsFilter = ""
+			return newModel
+		}
 		newModel.CurrentView = constants.ViewSelectOperation
+		if m.SelectedOperation != nil && m.SelectedOperation.Name == "Approvals Inbox" {
+			// For approvals inbox, go back to the region selection
+			newModel.CurrentView = constants.ViewInboxRegions
+		}
 		newModel.ResetApprovalState()
+	case constants.ViewInboxProfiles:
+		newModel.CurrentView = constants.ViewSelectOperation
+	case constants.ViewBulkConfirmation:
+		newModel.CurrentView = constants.ViewApprovals
+		newModel.ApproveAction = false
+		newModel.ManualInput = false
+		newModel.ResetTextInput()
+	case constants.ViewBulkResults:
+		newModel.CurrentView = constants.ViewSelectOperation
+		newModel.ApproveAction = false
+		newModel.ResetApprovalState()
+	case constants.ViewInboxRegions:
+		newModel.CurrentView = constants.ViewInboxProfiles
 	case constants.ViewConfirmation:
 		newModel.CurrentView = constants.ViewApprovals
 		newModel.SelectedApproval = nil
 	case constants.ViewSummary:
-		// For pipeline start flow, go back to pipeline status view
+		// For pipeline start flow, go back to the source action selection
+		// when a source action was chosen, or to the pipeline status view
 		if m.SelectedOperation != nil && m.SelectedOperation.Name == "Start Pipeline" {
-			newModel.CurrentView = constants.ViewPipelineStatus
+			switch {
+			case m.SelectedSource != nil && len(m.SourceActions) > 1:
+				newModel.CurrentView = constants.ViewSelectSourceAction
+				newModel.SelectedSource = nil
+			case m.SelectedSource != nil:
+				newModel.CurrentView = constants.ViewExecutingAction
+				resetSourceRevision(newModel)
+			default:
+				newModel.CurrentView = constants.ViewPipelineStatus
+			}
 		} else {
 			// For approval flow, go back to confirmation view
 			newModel.CurrentView = constants.ViewConfirmation
@@ -84,6 +133,7 @@ func NavigateBack(m *model.Model) *model.Model {
 		if m.SelectedOperation != nil && m.SelectedOperation.Name == "Start Pipeline" {
 			// For pipeline start flow, go back to pipeline status view
 			newModel.CurrentView = constants.ViewPipelineStatus
+			resetPipelineVariables(newModel)
 
 			// Make sure we're showing the pipeline selection table, not the approval table
 			// This ensures we stay in the pipeline start flow, not the approval flow
@@ -108,12 +158,83 @@ func NavigateBack(m *model.Model) *model.Model {
 				newModel.TextInput.Placeholder = constants.MsgEnterRejectionComment
 			}
 		}
+	case constants.ViewSelectSourceAction:
+		newModel.CurrentView = constants.ViewExecutingAction
+		newModel.SourceActions = nil
+	case constants.ViewPipelineVariables:
+		// Keep the entered values for the pipeline start
+		newModel.CurrentView = constants.ViewExecutingAction
+		newModel.EditingVariable = ""
 	case constants.ViewPipelineStages:
 		newModel.CurrentView = constants.ViewPipelineStatus
 		newModel.SelectedPipeline = nil
+	case constants.ViewPipelineGraph:
+		newModel.CurrentView = constants.ViewPipelineStages
+	case constants.ViewStageOperations:
+		newModel.CurrentView = constants.ViewPipelineStages
+		resetStageState(newModel)
+	case constants.ViewStopExecution:
+		newModel.CurrentView = constants.ViewStageOperations
+		newModel.AbandonExecution = false
+		newModel.ManualInput = false
+		newModel.ResetTextInput()
+	case constants.ViewRetryStage:
+		newModel.CurrentView = constants.ViewStageOperations
+		newModel.RetryMode = ""
+	case constants.ViewStageTransition:
+		newModel.CurrentView = constants.ViewStageOperations
+		newModel.ManualInput = false
+		newModel.ResetTextInput()
+	case constants.ViewRollbackTargets:
+		newModel.CurrentView = constants.ViewStageOperations
+		newModel.RollbackTargets = nil
+		newModel.RollbackToken = ""
+	case constants.ViewRollbackStage:
+		newModel.CurrentView = constants.ViewRollbackTargets
+		newModel.RollbackTarget = nil
+		newModel.ManualInput = false
+		newModel.ResetTextInput()
+	case constants.ViewOverrideCondition:
+		newModel.CurrentView = constants.ViewStageOperations
+		newModel.OverrideCondition = ""
+		newModel.ManualInput = false
+		newModel.ResetTextInput()
+	case constants.ViewStageActions:
+		newModel.CurrentView = constants.ViewStageOperations
+	case constants.ViewActionDetails:
+		newModel.CurrentView = constants.ViewStageActions
+		newModel.SelectedAction = nil
+	case constants.ViewPipelineDefinition:
+		// Cancel the file input before leaving the definition
+		if m.ManualInput {
+			newModel.ManualInput = false
+			newModel.DefinitionAction = ""
+			newModel.ResetTextInput()
+			return newModel
+		}
+		newModel.CurrentView = constants.ViewPipelineStatus
+		newModel.SelectedPipeline = nil
+		resetPipelineDefinition(newModel)
+	case constants.ViewDefinitionDiff:
+		newModel.CurrentView = constants.ViewPipelineDefinition
+		newModel.DefinitionDiff = nil
+	case constants.ViewExecutionHistory:
+		newModel.CurrentView = constants.ViewPipelineStatus
+		newModel.SelectedPipeline = nil
+		newModel.Executions = nil
+		newModel.ExecutionsToken = ""
+	case constants.ViewExecutionDetails:
+		newModel.CurrentView = constants.ViewExecutionHistory
+		newModel.SelectedExecution = nil
+	case constants.ViewExecutionWatch:
+		// Stop watching; the execution keeps running
+		newModel.CurrentView = constants.ViewSelectOperation
+		newModel.SelectedPipeline = nil
+		resetExecutionWatch(newModel)
 	case constants.ViewPipelineStatus:
 		newModel.CurrentView = constants.ViewSelectOperation
 		newModel.Pipelines = nil
+		newModel.PipelineFailures = nil
 		newModel.Provider = nil
 	case constants.ViewFunctionStatus:
 		newModel.CurrentView = constants.ViewSelectOperation
@@ -126,6 +247,27 @@ func NavigateBack(m *model.Model) *model.Model {
 	return newModel
 }
 
+// RestoreCursorAfterBack puts the cursor of a status table back on the pipeline or
+// function that was selected before navigating back to it
+func RestoreCursorAfterBack(m *model.Model, previous *model.Model) {
+	name := ""
+	switch {
+	case m.CurrentView == constants.ViewPipelineStatus && previous.SelectedPipeline != nil:
+		name = previous.SelectedPipeline.Name
+	case m.CurrentView == constants.ViewFunctionStatus && previous.SelectedFunction != nil:
+		name = previous.SelectedFunction.Name
+	default:
+		return
+	}
+
+	for i, row := range m.Table.Rows() {
+		if len(row) > 0 && row[0] == name {
+			m.Table.SetCursor(i)
+			return
+		}
+	}
+}
+
 // HandleTableSelect handles table row selection based on the current view
 func HandleTableSelect(m *model.Model) (tea.Model, tea.Cmd) {
 	switch m.CurrentView {
@@ -146,7 +288,18 @@ func HandleTableSelect(m *model.Model) (tea.Model, tea.Cmd) {
 	case constants.ViewSelectOperation:
 		return SelectOperation(m)
 	case constants.ViewApprovals:
+		if len(m.MarkedApprovals) > 0 {
+			return HandleBulkApproval(m)
+		}
 		return SelectApproval(m)
+	case constants.ViewBulkConfirmation:
+		return HandleBulkConfirmationSelection(m)
+	case constants.ViewBulkResults:
+		return HandleBulkResultsSelection(m)
+	case constants.ViewInboxProfiles:
+		return HandleInboxProfileSelection(m)
+	case constants.ViewInboxRegions:
+		return HandleInboxRegionSelection(m)
 	case constants.ViewConfirmation:
 		return HandleConfirmationSelection(m)
 	case constants.ViewSummary:
@@ -155,6 +308,30 @@ func HandleTableSelect(m *model.Model) (tea.Model, tea.Cmd) {
 		return HandleExecutionSelection(m)
 	case constants.ViewPipelineStatus:
 		return HandlePipelineSelection(m)
+	case constants.ViewSelectSourceAction:
+		return HandleSourceActionSelection(m)
+	case constants.ViewPipelineVariables:
+		return HandlePipelineVariableSelection(m)
+	case constants.ViewExecutionHistory:
+		return HandleExecutionHistorySelection(m)
+	case constants.ViewPipelineStages:
+		return HandleStageSelection(m)
+	case constants.ViewStageOperations:
+		return HandleStageOperationSelection(m)
+	case constants.ViewStopExecution:
+		return HandleStopModeSelection(m)
+	case constants.ViewRetryStage:
+		return HandleRetryConfirmation(m)
+	case constants.ViewStageTransition:
+		return HandleTransitionConfirmation(m)
+	case constants.ViewRollbackTargets:
+		return HandleRollbackTargetSelection(m)
+	case constants.ViewPipelineDefinition:
+		return HandleDefinitionSelection(m)
+	case constants.ViewRollbackStage, constants.ViewOverrideCondition:
+		return HandleGuardedConfirmation(m)
+	case constants.ViewStageActions:
+		return HandleActionSelection(m)
 	case constants.ViewFunctionStatus:
 		return HandleFunctionSelection(m)
 	default:
@@ -193,7 +370,16 @@ func HandleProviderSelection(m *model.Model) (tea.Model, tea.Cmd) {
 
 			// Set profiles and transition to AWS config view
 			newModel.Profiles = profiles
+			if details, err := provider.GetProfileDetails(); err == nil {
+				newModel.SetProfileDetails(details)
+			}
 			newModel.CurrentView = constants.ViewAWSConfig
+
+			// Choose between the profiles and the other authentication methods first
+			if authMethods := provider.GetAuthenticationMethods(); len(authMethods) > 1 {
+				newModel.ProviderState.AuthState.AvailableMethods = authMethods
+				newModel.CurrentView = constants.ViewAuthMethodSelect
+			}
 			view.UpdateTableForView(newModel)
 			return WrapModel(newModel), nil
 		}
@@ -259,6 +445,7 @@ func HandleAWSConfigSelection(m *model.Model) (tea.Model, tea.Cmd) {
 
 			newModel.SetAwsProfile(profile)
 			view.UpdateTableForView(newModel)
+			selectProfileRegion(newModel)
 		} else {
 			// If profile is already selected, this is region selection
 			region := selected[0]
@@ -271,8 +458,6 @@ func HandleAWSConfigSelection(m *model.Model) (tea.Model, tea.Cmd) {
 				return WrapModel(newModel), nil
 			}
 
-			newModel.SetAwsRegion(region)
-
 			// Configure the provider with the selected profile and region
 			provider, err := m.Registry.Get("AWS")
 			if err != nil {
@@ -281,6 +466,13 @@ func HandleAWSConfigSelection(m *model.Model) (tea.Model, tea.Cmd) {
 				}
 			}
 
+			// SSO and MFA profiles sign in first, which may wait for the user
+			if getSignInMethod(newModel) != "" {
+				return WrapModel(newModel), StartSignIn(newModel, provider, region, "")
+			}
+
+			newModel.SetAwsRegion(region)
+
 			// Use LoadConfig instead of Configure to properly initialize the services
 			err = provider.LoadConfig(newModel.GetAwsProfile(), region)
 			if err != nil {
@@ -292,6 +484,7 @@ func HandleAWSConfigSelection(m *model.Model) (tea.Model, tea.Cmd) {
 			// Move to service selection
 			newModel.CurrentView = constants.ViewSelectService
 			view.UpdateTableForView(newModel)
+			return WrapModel(newModel), FetchCallerIdentity(newModel, provider)
 		}
 
 		return WrapModel(newModel), nil
@@ -299,6 +492,21 @@ func HandleAWSConfigSelection(m *model.Model) (tea.Model, tea.Cmd) {
 	return WrapModel(m), nil
 }
 
+// selectProfileRegion moves the cursor of the region table to the region configured
+// for the selected profile, after the manual entry row
+func selectProfileRegion(m *model.Model) {
+	region := m.ProfileDetails[m.GetAwsProfile()].Region
+	if region == "" {
+		return
+	}
+	for i, r := range m.Regions {
+		if r == region {
+			m.Table.SetCursor(i + 1)
+			return
+		}
+	}
+}
+
 // HandleAuthMethodSelection handles the selection of an authentication method
 func HandleAuthMethodSelection(m *model.Model) (tea.Model, tea.Cmd) {
 	if selected := m.Table.SelectedRow(); len(selected) > 0 {
@@ -325,14 +533,21 @@ func HandleAuthMethodSelection(m *model.Model) (tea.Model, tea.Cmd) {
 				}
 			}
 
+			// AWS profiles are selected from the shared config
+			if m.ProviderState.ProviderName == "AWS" && selectedMethod == constants.AWSProfileAuth {
+				newModel.ProviderState.AuthState.ConfigKeys = nil
+				newModel.CurrentView = constants.ViewAWSConfig
+				view.UpdateTableForView(newModel)
+				return WrapModel(newModel), nil
+			}
+
 			// Get auth config keys for the selected method
 			configKeys := provider.GetAuthConfigKeys(selectedMethod)
 
 			if len(configKeys) > 0 {
-				// Initialize auth config map if needed
-				if newModel.ProviderState.AuthState.AuthConfig == nil {
-					newModel.ProviderState.AuthState.AuthConfig = make(map[string]string)
-				}
+				// Start from an empty config so no values of another method are kept
+				newModel.ProviderState.AuthState.AuthConfig = make(map[string]string)
+				newModel.ProviderState.AuthState.ConfigKeys = configKeys
 				newModel.CurrentView = constants.ViewAuthConfig
 			} else {
 				newModel.CurrentView = constants.ViewProviderConfig
@@ -349,12 +564,18 @@ func HandleAuthMethodSelection(m *model.Model) (tea.Model, tea.Cmd) {
 func HandleAuthConfigSelection(m *model.Model) (tea.Model, tea.Cmd) {
 	if selected := m.Table.SelectedRow(); len(selected) > 0 {
 		configKey := selected[0]
+		if configKey == constants.AuthConfigSignInRow {
+			return SubmitAuthConfig(m)
+		}
 
 		newModel := m.Clone()
 		newModel.ProviderState.AuthState.CurrentAuthConfigKey = configKey
 		newModel.ManualInput = true
 		newModel.TextInput.Focus()
 		newModel.TextInput.Placeholder = "Enter value for " + configKey
+		if constants.SecretAuthConfigKeys[configKey] {
+			newModel.TextInput.EchoMode = textinput.EchoPassword
+		}
 
 		return WrapModel(newModel), nil
 	}
@@ -403,11 +624,11 @@ func HandleTextInputSubmission(m *model.Model) (tea.Model, tea.Cmd) {
 				newModel.ManualInput = false
 				newModel.ResetTextInput()
 				view.UpdateTableForView(newModel)
+				selectProfileRegion(newModel)
 			}
 		} else {
 			// This is region input
 			if value != "" {
-				newModel.SetAwsRegion(value)
 				newModel.ManualInput = false
 				newModel.ResetTextInput()
 
@@ -419,6 +640,13 @@ func HandleTextInputSubmission(m *model.Model) (tea.Model, tea.Cmd) {
 					}
 				}
 
+				// SSO and MFA profiles sign in first, which may wait for the user
+				if getSignInMethod(newModel) != "" {
+					return WrapModel(newModel), StartSignIn(newModel, provider, value, "")
+				}
+
+				newModel.SetAwsRegion(value)
+
 				// Use LoadConfig instead of Configure to properly initialize the services
 				err = provider.LoadConfig(newModel.GetAwsProfile(), value)
 				if err != nil {
@@ -433,9 +661,14 @@ func HandleTextInputSubmission(m *model.Model) (tea.Model, tea.Cmd) {
 				// Move to service selection
 				newModel.CurrentView = constants.ViewSelectService
 				view.UpdateTableForView(newModel)
+				return WrapModel(newModel), FetchCallerIdentity(newModel, provider)
 			}
 		}
 	case constants.ViewAuthConfig:
+		// The MFA code of a role profile signs in right away
+		if IsTokenCodePrompt(m) {
+			return SubmitTokenCode(m, value)
+		}
 		// Handle auth config input
 		if m.ProviderState.AuthState.CurrentAuthConfigKey != "" {
 			if newModel.ProviderState.AuthState.AuthConfig == nil {
@@ -459,10 +692,38 @@ func HandleTextInputSubmission(m *model.Model) (tea.Model, tea.Cmd) {
 			newModel.ResetTextInput()
 			view.UpdateTableForView(newModel)
 		}
+	case constants.ViewPipelineVariables:
+		// Handle pipeline variable input
+		if m.EditingVariable != "" {
+			setPipelineVariable(newModel, m.EditingVariable, value)
+			newModel.EditingVariable = ""
+			newModel.ManualInput = false
+			newModel.ResetTextInput()
+			view.UpdateTableForView(newModel)
+		}
+	case constants.ViewStopExecution:
+		// Handle stop reason input
+		return HandleStopReasonSubmission(m, value)
+	case constants.ViewStageTransition:
+		// Handle disable reason input
+		return HandleDisableReasonSubmission(m, value)
+	case constants.ViewPipelineDefinition:
+		// Handle definition export or diff file input
+		return HandleDefinitionFileSubmission(m, value)
+	case constants.ViewRollbackStage, constants.ViewOverrideCondition:
+		// Handle stage name confirmation input
+		return HandleStageNameConfirmation(m, value)
+	case constants.ViewApprovals:
+		// Handle approvals filter input
+		return HandleApprovalFilterSubmission(m, value)
+	case constants.ViewBulkConfirmation:
+		// Handle bulk approval comment input
+		return HandleBulkCommentSubmission(m, value)
 	case constants.ViewSummary:
 		// Handle summary input (comments for approvals or commit IDs for pipeline starts)
 		if m.SelectedOperation != nil && m.SelectedOperation.Name == "Start Pipeline" {
 			newModel.CommitID = value
+			newModel.ManualCommitID = value != ""
 			newModel.ManualInput = false
 			newModel.ResetTextInput()
 			newModel.CurrentView = constants.ViewExecutingAction
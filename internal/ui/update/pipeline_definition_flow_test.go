@@ -0,0 +1,223 @@
+package update
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// newDefinitionModel creates a model showing the definition of a pipeline
+func newDefinitionModel() *model.Model {
+	m := model.New()
+	m.SelectedOperation = &model.Operation{Name: "Pipeline Definition"}
+	m.SelectedPipeline = &cloud.PipelineStatus{Name: "TestPipeline"}
+	HandlePipelineDefinitionLoaded(m, &cloud.PipelineDefinition{
+		Name:         "TestPipeline",
+		RoleArn:      "arn:aws:iam::111111111111:role/pipeline",
+		Version:      3,
+		PipelineType: "V2",
+		Stages: []cloud.StageDefinition{
+			{
+				Name: "Build",
+				Actions: []cloud.ActionDefinition{
+					{
+						Name:            "Test",
+						ActionTypeID:    cloud.ActionTypeID{Category: "Test", Owner: "AWS", Provider: "CodeBuild", Version: "1"},
+						RunOrder:        2,
+						Configuration:   map[string]string{"ProjectName": "test"},
+						InputArtifacts:  []cloud.ArtifactDefinition{{Name: "BuildOutput"}},
+						OutputArtifacts: []cloud.ArtifactDefinition{},
+					},
+					{
+						Name:            "Compile",
+						ActionTypeID:    cloud.ActionTypeID{Category: "Build", Owner: "AWS", Provider: "CodeBuild", Version: "1"},
+						RunOrder:        1,
+						Configuration:   map[string]string{"ProjectName": "compile"},
+						InputArtifacts:  []cloud.ArtifactDefinition{{Name: "SourceOutput"}},
+						OutputArtifacts: []cloud.ArtifactDefinition{{Name: "BuildOutput"}},
+					},
+				},
+			},
+		},
+	})
+	return m
+}
+
+// TestPipelineDefinitionTree tests expanding the stages and actions of the definition tree
+func TestPipelineDefinitionTree(t *testing.T) {
+	m := newDefinitionModel()
+	if m.CurrentView != constants.ViewPipelineDefinition {
+		t.Fatalf("Expected to be at ViewPipelineDefinition, got %v", m.CurrentView)
+	}
+	rows := m.Table.Rows()
+	if len(rows) != 6 || rows[5][0] != "▸ Stage: Build" {
+		t.Fatalf("Expected the stage to be collapsed, got %v", rows)
+	}
+
+	// Expanding the stage lists its actions in run order
+	m.Table.SetCursor(5)
+	result, _ := HandleDefinitionSelection(m)
+	wrapper := result.(ModelWrapper)
+	rows = wrapper.Model.Table.Rows()
+	if len(rows) != 8 || rows[5][0] != "▾ Stage: Build" || rows[6][0] != "  ▸ [1] Compile" {
+		t.Fatalf("Expected the actions in run order, got %v", rows)
+	}
+	if wrapper.Model.Table.Cursor() != 5 {
+		t.Errorf("Expected the cursor to stay on the stage, got %d", wrapper.Model.Table.Cursor())
+	}
+
+	// Expanding an action lists its artifacts and configuration
+	wrapper.Model.Table.SetCursor(6)
+	result, _ = HandleDefinitionSelection(wrapper.Model)
+	wrapper = result.(ModelWrapper)
+	rows = wrapper.Model.Table.Rows()
+	if len(rows) != 11 || rows[8][1] != "BuildOutput" || rows[9][1] != "compile" {
+		t.Fatalf("Expected the details of the Compile action, got %v", rows)
+	}
+
+	// Navigating back leaves the definition
+	backResult := NavigateBack(wrapper.Model)
+	if backResult.CurrentView != constants.ViewPipelineStatus || backResult.PipelineDefinition != nil {
+		t.Errorf("Expected to navigate back to ViewPipelineStatus with the definition cleared, got %v", backResult.CurrentView)
+	}
+}
+
+// TestPipelineDefinitionExportAndDiff tests exporting the definition and comparing it with local files
+func TestPipelineDefinitionExportAndDiff(t *testing.T) {
+	dir := t.TempDir()
+	m := newDefinitionModel()
+
+	// Export the definition as YAML
+	m.Table.SetCursor(1)
+	result, _ := HandleDefinitionSelection(m)
+	wrapper := result.(ModelWrapper)
+	if !wrapper.Model.ManualInput || wrapper.Model.TextInput.Value() != "TestPipeline.yaml" {
+		t.Fatalf("Expected to enter the export file, got %q", wrapper.Model.TextInput.Value())
+	}
+	yamlPath := filepath.Join(dir, "pipeline.yaml")
+	result, _ = HandleDefinitionFileSubmission(wrapper.Model, yamlPath)
+	exported := result.(ModelWrapper).Model
+	if exported.ManualInput || exported.Success == "" {
+		t.Fatalf("Expected the definition to be exported")
+	}
+	data, err := os.ReadFile(yamlPath)
+	if err != nil {
+		t.Fatalf("Expected the YAML file to be written: %v", err)
+	}
+	if !strings.Contains(string(data), "stages:\n  - actions:\n      - actionTypeId:\n") {
+		t.Errorf("Expected nested stages and actions in the YAML export, got\n%s", data)
+	}
+
+	// The exported YAML matches the live definition
+	exported.DefinitionAction = "diff"
+	result, _ = HandleDefinitionFileSubmission(exported, yamlPath)
+	wrapper = result.(ModelWrapper)
+	if wrapper.Model.CurrentView != constants.ViewDefinitionDiff || wrapper.Model.DefinitionDiff != nil {
+		t.Fatalf("Expected no differences, got %v", wrapper.Model.DefinitionDiff)
+	}
+
+	// Exporting again to the same file is refused and leaves the file unchanged
+	exported.DefinitionAction = "yaml"
+	if err := os.WriteFile(yamlPath, []byte("edited\n"), 0o644); err != nil {
+		t.Fatalf("Failed to edit the exported file: %v", err)
+	}
+	_, cmd := HandleDefinitionFileSubmission(exported, yamlPath)
+	if cmd == nil {
+		t.Fatalf("Expected an error when the export file exists")
+	}
+	if _, ok := cmd().(model.ErrMsg); !ok {
+		t.Errorf("Expected an ErrMsg when the export file exists")
+	}
+	if data, _ := os.ReadFile(yamlPath); string(data) != "edited\n" {
+		t.Errorf("Expected the existing file to be kept, got\n%s", data)
+	}
+
+	// A hand-written YAML file is compared by content
+	handPath := filepath.Join(dir, "pipeline.yml")
+	hand := `# Deployed by the platform team
+pipeline:
+  name: TestPipeline
+  version: 3
+  pipelineType: "V2"
+  roleArn: 'arn:aws:iam::111111111111:role/pipeline'
+  stages:
+    - name: Build
+      actions:
+        - name: Test
+          runOrder: 2
+          configuration: {ProjectName: old-test}
+          actionTypeId: {category: Test, owner: AWS, provider: CodeBuild, version: "1"}
+          inputArtifacts: [{name: BuildOutput}]
+          outputArtifacts: []
+        - name: Compile
+          runOrder: 1
+          configuration:
+            ProjectName: compile
+          actionTypeId:
+            category: Build
+            owner: AWS
+            provider: CodeBuild
+            version: "1"
+          inputArtifacts:
+            - name: SourceOutput
+          outputArtifacts:
+            - name: BuildOutput
+metadata: {}
+`
+	if err := os.WriteFile(handPath, []byte(hand), 0o644); err != nil {
+		t.Fatalf("Failed to write the local file: %v", err)
+	}
+	exported.DefinitionAction = "diff"
+	result, _ = HandleDefinitionFileSubmission(exported, handPath)
+	var yamlChanges []string
+	for _, line := range result.(ModelWrapper).Model.DefinitionDiff {
+		if line.Kind == "+" || line.Kind == "-" {
+			yamlChanges = append(yamlChanges, line.Kind+strings.TrimSpace(line.Text))
+		}
+	}
+	if len(yamlChanges) != 2 || yamlChanges[0] != "-ProjectName: old-test" || yamlChanges[1] != "+ProjectName: test" {
+		t.Errorf("Expected only the changed project name, got %v", yamlChanges)
+	}
+
+	// A JSON file from the AWS CLI is compared by content
+	jsonPath := filepath.Join(dir, "pipeline.json")
+	local := strings.Replace(`{"pipeline": {"version": 3, "pipelineType": "V2", "name": "TestPipeline",
+		"roleArn": "arn:aws:iam::111111111111:role/pipeline", "stages": [STAGES]}, "metadata": {}}`,
+		"STAGES", `{"name": "Build", "actions": [
+			{"name": "Test", "runOrder": 2, "configuration": {"ProjectName": "old-test"},
+			 "actionTypeId": {"category": "Test", "owner": "AWS", "provider": "CodeBuild", "version": "1"},
+			 "inputArtifacts": [{"name": "BuildOutput"}], "outputArtifacts": []},
+			{"name": "Compile", "runOrder": 1, "configuration": {"ProjectName": "compile"},
+			 "actionTypeId": {"category": "Build", "owner": "AWS", "provider": "CodeBuild", "version": "1"},
+			 "inputArtifacts": [{"name": "SourceOutput"}], "outputArtifacts": [{"name": "BuildOutput"}]}]}`, 1)
+	if err := os.WriteFile(jsonPath, []byte(local), 0o644); err != nil {
+		t.Fatalf("Failed to write the local file: %v", err)
+	}
+	exported.DefinitionAction = "diff"
+	result, _ = HandleDefinitionFileSubmission(exported, jsonPath)
+	diff := result.(ModelWrapper).Model.DefinitionDiff
+	var changes []string
+	for _, line := range diff {
+		if line.Kind == "+" || line.Kind == "-" {
+			changes = append(changes, line.Kind+strings.TrimSpace(line.Text))
+		}
+	}
+	if len(changes) != 2 || changes[0] != `-"ProjectName": "old-test"` || changes[1] != `+"ProjectName": "test"` {
+		t.Errorf("Expected only the changed project name, got %v", changes)
+	}
+
+	// Unsupported files are reported
+	exported.DefinitionAction = "diff"
+	_, cmd = HandleDefinitionFileSubmission(exported, filepath.Join(dir, "pipeline.txt"))
+	if cmd == nil {
+		t.Fatalf("Expected an error for an unsupported file")
+	}
+	if _, ok := cmd().(model.ErrMsg); !ok {
+		t.Errorf("Expected an ErrMsg for an unsupported file")
+	}
+}
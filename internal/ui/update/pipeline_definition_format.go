@@ -0,0 +1,209 @@
+package update
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"gopkg.in/yaml.v3"
+)
+
+// diffContextLines is the number of unchanged lines kept around each change of a definition diff
+const diffContextLines = 2
+
+// encodePipelineDefinition encodes a pipeline definition as JSON or YAML
+func encodePipelineDefinition(definition *cloud.PipelineDefinition, format string) ([]byte, error) {
+	switch format {
+	case definitionFormatJSON:
+		data, err := json.MarshalIndent(definition, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	case definitionFormatYAML:
+		value, err := toGenericValue(definition)
+		if err != nil {
+			return nil, err
+		}
+		return encodeGenericValue(value, format)
+	default:
+		return nil, fmt.Errorf("unsupported definition format %s", format)
+	}
+}
+
+// diffPipelineDefinition compares a local definition file with the live definition.
+// Files are compared by content, so key order, indentation, quoting and comments do
+// not matter, and may hold either the pipeline or the AWS CLI output wrapping it.
+func diffPipelineDefinition(definition *cloud.PipelineDefinition, path string) ([]model.DefinitionDiffLine, error) {
+	var format string
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		format = definitionFormatJSON
+	case ".yaml", ".yml":
+		format = definitionFormatYAML
+	default:
+		return nil, fmt.Errorf(constants.MsgErrorUnsupportedFormat, path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var localValue interface{}
+	if format == definitionFormatJSON {
+		err = json.Unmarshal(data, &localValue)
+	} else {
+		err = yaml.Unmarshal(data, &localValue)
+	}
+	if err == nil {
+		// YAML decodes numbers and timestamps to other types than JSON does
+		localValue, err = toGenericValue(localValue)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if wrapper, ok := localValue.(map[string]interface{}); ok {
+		if pipeline, ok := wrapper["pipeline"].(map[string]interface{}); ok {
+			localValue = pipeline
+		}
+	}
+	liveValue, err := toGenericValue(definition)
+	if err != nil {
+		return nil, err
+	}
+
+	// Maps are encoded with sorted keys, so both sides share the same layout
+	local, err := encodeGenericValue(localValue, format)
+	if err != nil {
+		return nil, err
+	}
+	live, err := encodeGenericValue(liveValue, format)
+	if err != nil {
+		return nil, err
+	}
+
+	return diffLines(splitLines(string(local)), splitLines(string(live))), nil
+}
+
+// encodeGenericValue encodes a value decoded from JSON as JSON or YAML with sorted keys
+func encodeGenericValue(value interface{}, format string) ([]byte, error) {
+	if format == definitionFormatJSON {
+		return json.MarshalIndent(value, "", "  ")
+	}
+	var b bytes.Buffer
+	encoder := yaml.NewEncoder(&b)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// toGenericValue converts a value to the maps, slices and scalars it encodes to in JSON
+func toGenericValue(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// splitLines splits text into lines without line endings or trailing whitespace
+func splitLines(text string) []string {
+	lines := strings.Split(strings.TrimRight(text, "\r\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	return lines
+}
+
+// diffLines returns the changes from the local to the live lines with a few unchanged
+// lines around each change, or nil when the lines are the same
+func diffLines(local, live []string) []model.DefinitionDiffLine {
+	// lcs[i][j] is the length of the longest common subsequence of local[i:] and live[j:]
+	lcs := make([][]int, len(local)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(live)+1)
+	}
+	for i := len(local) - 1; i >= 0; i-- {
+		for j := len(live) - 1; j >= 0; j-- {
+			if local[i] == live[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var lines []model.DefinitionDiffLine
+	changed := false
+	i, j := 0, 0
+	for i < len(local) || j < len(live) {
+		switch {
+		case i < len(local) && j < len(live) && local[i] == live[j]:
+			lines = append(lines, model.DefinitionDiffLine{Kind: " ", Text: local[i]})
+			i++
+			j++
+		case i < len(local) && (j == len(live) || lcs[i+1][j] >= lcs[i][j+1]):
+			// Removed lines come before the lines added in their place
+			lines = append(lines, model.DefinitionDiffLine{Kind: "-", Text: local[i]})
+			changed = true
+			i++
+		default:
+			lines = append(lines, model.DefinitionDiffLine{Kind: "+", Text: live[j]})
+			changed = true
+			j++
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	return collapseUnchangedLines(lines)
+}
+
+// collapseUnchangedLines replaces the unchanged lines away from any change with a
+// line counting the skipped lines
+func collapseUnchangedLines(lines []model.DefinitionDiffLine) []model.DefinitionDiffLine {
+	keep := make([]bool, len(lines))
+	for i, line := range lines {
+		if line.Kind == " " {
+			continue
+		}
+		for k := max(0, i-diffContextLines); k <= min(len(lines)-1, i+diffContextLines); k++ {
+			keep[k] = true
+		}
+	}
+
+	var collapsed []model.DefinitionDiffLine
+	skipped := 0
+	for i, line := range lines {
+		if keep[i] {
+			if skipped > 0 {
+				collapsed = append(collapsed, model.DefinitionDiffLine{Kind: "~", Text: fmt.Sprintf("... %d unchanged lines", skipped)})
+				skipped = 0
+			}
+			collapsed = append(collapsed, line)
+			continue
+		}
+		skipped++
+	}
+	if skipped > 0 {
+		collapsed = append(collapsed, model.DefinitionDiffLine{Kind: "~", Text: fmt.Sprintf("... %d unchanged lines", skipped)})
+	}
+	return collapsed
+}
@@ -0,0 +1,184 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Definition file formats
+const (
+	definitionFormatJSON = "json"
+	definitionFormatYAML = "yaml"
+)
+
+// HandlePipelineDefinition loads the definition of the selected pipeline
+func HandlePipelineDefinition(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.SelectedPipeline == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoPipeline)}
+		}
+	}
+
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgLoadingDefinition
+
+	pipelineName := m.SelectedPipeline.Name
+	return WrapModel(newModel), withRequest(m, func(ctx context.Context) tea.Msg {
+		// Get the provider from the registry
+		provider, err := m.Registry.Get("AWS")
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Get the PipelineDefinitionOperation from the provider
+		definitionOperation, err := provider.GetPipelineDefinitionOperation()
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Get the pipeline definition using the operation
+		definition, err := definitionOperation.GetPipelineDefinition(ctx, pipelineName)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.PipelineDefinitionMsg{
+			Definition: definition,
+		}
+	})
+}
+
+// HandlePipelineDefinitionLoaded handles the definition loaded for the selected pipeline
+func HandlePipelineDefinitionLoaded(m *model.Model, definition *cloud.PipelineDefinition) {
+	m.PipelineDefinition = definition
+	m.DefinitionExpanded = nil
+	m.Success = ""
+	m.CurrentView = constants.ViewPipelineDefinition
+	view.UpdateTableForView(m)
+}
+
+// HandleDefinitionSelection expands or collapses the selected node of the definition
+// tree, or prompts for the file to export the definition to or compare it with
+func HandleDefinitionSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	nodes := view.GetDefinitionNodes(m.PipelineDefinition, m.DefinitionExpanded)
+	cursor := m.Table.Cursor()
+	if cursor < 0 || cursor >= len(nodes) {
+		return WrapModel(m), nil
+	}
+	node := nodes[cursor]
+
+	newModel := m.Clone()
+	switch node.Key {
+	case view.DefinitionKeyExportJSON:
+		startDefinitionFileInput(newModel, definitionFormatJSON, m.PipelineDefinition.Name+".json", constants.MsgEnterExportPath)
+		return WrapModel(newModel), nil
+	case view.DefinitionKeyExportYAML:
+		startDefinitionFileInput(newModel, definitionFormatYAML, m.PipelineDefinition.Name+".yaml", constants.MsgEnterExportPath)
+		return WrapModel(newModel), nil
+	case view.DefinitionKeyDiff:
+		startDefinitionFileInput(newModel, view.DefinitionKeyDiff, m.DefinitionFile, constants.MsgEnterDiffPath)
+		return WrapModel(newModel), nil
+	}
+
+	if !node.Expandable {
+		return WrapModel(m), nil
+	}
+	newModel.DefinitionExpanded = toggleSelectedValue(m.DefinitionExpanded, node.Key)
+	view.UpdateTableForView(newModel)
+	newModel.Table.SetCursor(cursor)
+	return WrapModel(newModel), nil
+}
+
+// startDefinitionFileInput prompts for the file a definition action works with
+func startDefinitionFileInput(m *model.Model, action, path, placeholder string) {
+	m.DefinitionAction = action
+	m.ManualInput = true
+	m.TextInput.SetValue(path)
+	m.TextInput.Focus()
+	m.TextInput.Placeholder = placeholder
+}
+
+// HandleDefinitionFileSubmission exports the definition to the entered file, or
+// compares it with the entered file. Exports refuse to overwrite an existing file.
+func HandleDefinitionFileSubmission(m *model.Model, path string) (tea.Model, tea.Cmd) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorEmptyFilePath)}
+		}
+	}
+	if m.PipelineDefinition == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoPipeline)}
+		}
+	}
+
+	newModel := m.Clone()
+	newModel.ManualInput = false
+	newModel.ResetTextInput()
+	newModel.DefinitionAction = ""
+	newModel.DefinitionFile = path
+
+	if m.DefinitionAction == view.DefinitionKeyDiff {
+		diff, err := diffPipelineDefinition(m.PipelineDefinition, path)
+		if err != nil {
+			return WrapModel(m), func() tea.Msg {
+				return model.ErrMsg{Err: err}
+			}
+		}
+		newModel.DefinitionDiff = diff
+		newModel.CurrentView = constants.ViewDefinitionDiff
+		view.UpdateTableForView(newModel)
+		return WrapModel(newModel), nil
+	}
+
+	data, err := encodePipelineDefinition(m.PipelineDefinition, m.DefinitionAction)
+	if err == nil {
+		err = writeNewFile(path, data)
+	}
+	if errors.Is(err, fs.ErrExist) {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorFileExists, path)}
+		}
+	}
+	if err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf("failed to export pipeline definition: %w", err)}
+		}
+	}
+	newModel.Success = fmt.Sprintf(constants.MsgDefinitionExportSuccess, path)
+	return WrapModel(newModel), nil
+}
+
+// writeNewFile writes data to a file that must not exist yet, so an export never
+// overwrites an existing file
+func writeNewFile(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// resetPipelineDefinition clears the pipeline definition and its export and diff state
+func resetPipelineDefinition(m *model.Model) {
+	m.PipelineDefinition = nil
+	m.DefinitionExpanded = nil
+	m.DefinitionAction = ""
+	m.DefinitionDiff = nil
+}
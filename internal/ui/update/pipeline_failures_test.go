@@ -0,0 +1,53 @@
+package update
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TestGetFailedPipelines tests reading the failed pipelines out of a partial pipeline read
+func TestGetFailedPipelines(t *testing.T) {
+	throttled := errors.New("throttled")
+	err := errors.Join(
+		&cloud.PipelineError{Pipeline: "First", Err: throttled},
+		&cloud.PipelineError{Pipeline: "Third", Err: fmt.Errorf("failed to get pipeline state: %w", throttled)},
+	)
+	failed := getFailedPipelines(err)
+	if len(failed) != 2 || failed[0] != "First" || failed[1] != "Third" {
+		t.Errorf("Expected the First and Third pipelines to be reported, got %v", failed)
+	}
+	if !errors.Is(err, throttled) {
+		t.Errorf("Expected the pipeline errors to wrap the cause")
+	}
+
+	if failed := getFailedPipelines(errors.New("failed to list pipelines")); len(failed) != 0 {
+		t.Errorf("Expected no failed pipelines for an account error, got %v", failed)
+	}
+	if failed := getFailedPipelines(nil); failed != nil {
+		t.Errorf("Expected no failed pipelines without an error, got %v", failed)
+	}
+}
+
+// TestPartialResultsNotCached tests that reads missing failed pipelines are fetched again
+func TestPartialResultsNotCached(t *testing.T) {
+	m := model.New()
+	fetches := 0
+	cmd := fetchCached(m, constants.CachePipelineStatus, constants.PipelineStatusCacheTTL, func() tea.Msg {
+		fetches++
+		return model.PipelineStatusMsg{
+			Pipelines:       []cloud.PipelineStatus{{Name: "Second"}},
+			FailedPipelines: []string{"First"},
+		}
+	})
+	cmd()
+	cmd()
+	if fetches != 2 {
+		t.Errorf("Expected a partial read to be fetched again, got %d fetches", fetches)
+	}
+}
@@ -13,6 +13,24 @@ import (
 func SelectApproval(m *model.Model) (tea.Model, tea.Cmd) {
 	if selected := m.Table.SelectedRow(); len(selected) > 0 {
 		newModel := m.Clone()
+
+		// The approvals inbox shows the account and region before the approval
+		if m.SelectedOperation != nil && m.SelectedOperation.Name == "Approvals Inbox" && len(selected) > 3 {
+			for _, approval := range m.Approvals {
+				if view.GetApprovalAccount(approval) == selected[0] &&
+					approval.Region == selected[1] &&
+					approval.PipelineName == selected[2] &&
+					approval.StageName == selected[3] &&
+					approval.ActionName == selected[4] {
+					newModel.SelectedApproval = &approval
+					newModel.CurrentView = constants.ViewConfirmation
+					view.UpdateTableForView(newModel)
+					return WrapModel(newModel), nil
+				}
+			}
+			return WrapModel(m), nil
+		}
+
 		for _, approval := range m.Approvals {
 			if approval.PipelineName == selected[0] &&
 				approval.StageName == selected[1] &&
@@ -34,6 +52,12 @@ func HandlePipelineSelection(m *model.Model) (tea.Model, tea.Cmd) {
 		for _, pipeline := range m.Pipelines {
 			if pipeline.Name == selected[0] {
 				newModel.SelectedPipeline = &pipeline
+				if m.SelectedOperation != nil && m.SelectedOperation.Name == "Execution History" {
+					return HandleExecutionHistory(newModel)
+				}
+				if m.SelectedOperation != nil && m.SelectedOperation.Name == "Pipeline Definition" {
+					return HandlePipelineDefinition(newModel)
+				}
 				if m.SelectedOperation != nil && m.SelectedOperation.Name == "Start Pipeline" {
 					newModel.CurrentView = constants.ViewExecutingAction
 				} else {
@@ -47,13 +71,33 @@ func HandlePipelineSelection(m *model.Model) (tea.Model, tea.Cmd) {
 	return WrapModel(m), nil
 }
 
+// HandlePipelineStagesKey handles the keys of the pipeline stages views, switching
+// between the stages table and the pipeline graph
+func HandlePipelineStagesKey(m *model.Model, key string) (tea.Model, tea.Cmd) {
+	if key != constants.KeyGraph || m.SelectedPipeline == nil {
+		return WrapModel(m), nil
+	}
+
+	newModel := m.Clone()
+	if m.CurrentView == constants.ViewPipelineGraph {
+		newModel.CurrentView = constants.ViewPipelineStages
+	} else {
+		newModel.CurrentView = constants.ViewPipelineGraph
+	}
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
+
 // HandlePipelineApprovals handles the pipeline approvals operation
 func HandlePipelineApprovals(m *model.Model) (tea.Model, tea.Cmd) {
 	newModel := m.Clone()
 	newModel.IsLoading = true
 	newModel.LoadingMsg = constants.MsgLoadingApprovals
 
-	return WrapModel(newModel), func() tea.Msg {
+	progress := newLoadingProgress()
+	return WrapModel(newModel), withLoadingProgress(progress, fetchCached(m, constants.CacheApprovals, constants.ApprovalsCacheTTL, withRequest(m, func(ctx context.Context) tea.Msg {
+		ctx = reportLoadingProgress(ctx, progress)
+
 		// Get the provider
 		provider, err := m.Registry.Get(m.ProviderState.ProviderName)
 		if err != nil {
@@ -66,18 +110,19 @@ func HandlePipelineApprovals(m *model.Model) (tea.Model, tea.Cmd) {
 			return model.ErrMsg{Err: err}
 		}
 
-		// Get approvals using the operation
-		ctx := context.Background()
+		// Get approvals using the operation, reporting the pipelines loaded so far
 		approvals, err := approvalOperation.GetPendingApprovals(ctx)
-		if err != nil {
+		failedPipelines := getFailedPipelines(err)
+		if err != nil && len(failedPipelines) == 0 {
 			return model.ErrMsg{Err: err}
 		}
 
 		return model.ApprovalsMsg{
-			Approvals: approvals,
-			Provider:  provider,
+			Approvals:       approvals,
+			Provider:        provider,
+			FailedPipelines: failedPipelines,
 		}
-	}
+	})))
 }
 
 // HandlePipelineStatus handles the pipeline status operation
@@ -86,7 +131,10 @@ func HandlePipelineStatus(m *model.Model) (tea.Model, tea.Cmd) {
 	newModel.IsLoading = true
 	newModel.LoadingMsg = constants.MsgLoadingPipelines
 
-	return WrapModel(newModel), func() tea.Msg {
+	progress := newLoadingProgress()
+	return WrapModel(newModel), withLoadingProgress(progress, fetchCached(m, constants.CachePipelineStatus, constants.PipelineStatusCacheTTL, withRequest(m, func(ctx context.Context) tea.Msg {
+		ctx = reportLoadingProgress(ctx, progress)
+
 		// Get the provider
 		provider, err := m.Registry.Get(m.ProviderState.ProviderName)
 		if err != nil {
@@ -99,16 +147,17 @@ func HandlePipelineStatus(m *model.Model) (tea.Model, tea.Cmd) {
 			return model.ErrMsg{Err: err}
 		}
 
-		// Get pipeline status using the operation
-		ctx := context.Background()
+		// Get pipeline status using the operation, reporting the pipelines loaded so far
 		pipelines, err := statusOperation.GetPipelineStatus(ctx)
-		if err != nil {
+		failedPipelines := getFailedPipelines(err)
+		if err != nil && (len(failedPipelines) == 0 || len(pipelines) == 0) {
 			return model.ErrMsg{Err: err}
 		}
 
 		return model.PipelineStatusMsg{
-			Pipelines: pipelines,
-			Provider:  provider,
+			Pipelines:       pipelines,
+			Provider:        provider,
+			FailedPipelines: failedPipelines,
 		}
-	}
+	})))
 }
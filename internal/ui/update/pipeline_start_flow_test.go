@@ -114,3 +114,131 @@ func TestCompletePipelineStartFlow(t *testing.T) {
 		t.Errorf("Expected to navigate back to ViewPipelineStatus, got %v", backResult.CurrentView)
 	}
 }
+
+// TestPipelineStartSourceRevisionFlow tests choosing a source action and
+// entering a revision for it when the pipeline has several source actions.
+func TestPipelineStartSourceRevisionFlow(t *testing.T) {
+	m := model.New()
+	m.SelectedOperation = &model.Operation{Name: "Start Pipeline"}
+	m.SelectedPipeline = &cloud.PipelineStatus{Name: "TestPipeline"}
+	m.CurrentView = constants.ViewExecutingAction
+
+	// Several source actions require the user to pick one
+	HandleSourceActions(m, []cloud.SourceAction{
+		{Name: "Source", Provider: "CodeStarSourceConnection", RevisionType: cloud.RevisionTypeCommitID},
+		{Name: "Artifacts", Provider: "S3", RevisionType: cloud.RevisionTypeS3ObjectVersionID},
+	})
+	if m.CurrentView != constants.ViewSelectSourceAction {
+		t.Fatalf("Expected to be at ViewSelectSourceAction, got %v", m.CurrentView)
+	}
+
+	// Select the S3 source action
+	m.Table.SetCursor(1)
+	result, cmd := HandleSourceActionSelection(m)
+	if cmd != nil {
+		t.Errorf("Expected HandleSourceActionSelection to return nil command, got %T", cmd)
+	}
+	wrapper, ok := result.(ModelWrapper)
+	if !ok {
+		t.Fatalf("Expected HandleSourceActionSelection to return a ModelWrapper, got %T", result)
+	}
+	if wrapper.Model.CurrentView != constants.ViewSummary || !wrapper.Model.ManualInput {
+		t.Fatalf("Expected manual input on ViewSummary, got view %v (manual input %v)",
+			wrapper.Model.CurrentView, wrapper.Model.ManualInput)
+	}
+	if wrapper.Model.SelectedSource == nil || wrapper.Model.SelectedSource.Name != "Artifacts" {
+		t.Fatalf("Expected Artifacts to be the selected source action, got %v", wrapper.Model.SelectedSource)
+	}
+
+	// The entered revision is sent for the selected source action
+	wrapper.Model.CommitID = " 3sL4kqtJlcpXroDTDmJ.rmSpXd3dIbrHY "
+	wrapper.Model.ManualCommitID = true
+	revisions := getSourceRevisions(wrapper.Model)
+	expected := cloud.SourceRevision{
+		ActionName:    "Artifacts",
+		RevisionType:  cloud.RevisionTypeS3ObjectVersionID,
+		RevisionValue: "3sL4kqtJlcpXroDTDmJ.rmSpXd3dIbrHY",
+	}
+	if len(revisions) != 1 || revisions[0] != expected {
+		t.Errorf("Expected source revisions %v, got %v", []cloud.SourceRevision{expected}, revisions)
+	}
+
+	// Navigating back returns to the source action selection
+	backResult := NavigateBack(wrapper.Model)
+	if backResult.CurrentView != constants.ViewSelectSourceAction {
+		t.Errorf("Expected to navigate back to ViewSelectSourceAction, got %v", backResult.CurrentView)
+	}
+	if backResult.SelectedSource != nil {
+		t.Errorf("Expected the selected source action to be cleared")
+	}
+}
+
+// TestPipelineStartUnsupportedSourceAction tests that a lone source action
+// without revision override support is reported as an error.
+func TestPipelineStartUnsupportedSourceAction(t *testing.T) {
+	m := model.New()
+	m.SelectedOperation = &model.Operation{Name: "Start Pipeline"}
+	m.SelectedPipeline = &cloud.PipelineStatus{Name: "TestPipeline"}
+	m.CurrentView = constants.ViewExecutingAction
+
+	HandleSourceActions(m, []cloud.SourceAction{
+		{Name: "Source", Provider: "GitHub"},
+	})
+	if m.Err == nil {
+		t.Errorf("Expected an error for an unsupported source action")
+	}
+	if m.CurrentView != constants.ViewExecutingAction {
+		t.Errorf("Expected to stay at ViewExecutingAction, got %v", m.CurrentView)
+	}
+}
+
+// TestPipelineStartVariablesFlow tests overriding a pipeline variable before
+// starting a pipeline.
+func TestPipelineStartVariablesFlow(t *testing.T) {
+	m := model.New()
+	m.SelectedOperation = &model.Operation{Name: "Start Pipeline"}
+	m.SelectedPipeline = &cloud.PipelineStatus{Name: "TestPipeline"}
+	m.CurrentView = constants.ViewExecutingAction
+
+	HandlePipelineVariablesLoaded(m, []cloud.PipelineVariable{
+		{Name: "DeployTarget", Value: "staging", DefaultValue: "staging"},
+		{Name: "ImageTag"},
+	})
+	if m.CurrentView != constants.ViewPipelineVariables {
+		t.Fatalf("Expected to be at ViewPipelineVariables, got %v", m.CurrentView)
+	}
+
+	// Select the first variable and enter a new value
+	m.Table.SetCursor(0)
+	result, _ := HandlePipelineVariableSelection(m)
+	wrapper := result.(ModelWrapper)
+	if !wrapper.Model.ManualInput || wrapper.Model.EditingVariable != "DeployTarget" {
+		t.Fatalf("Expected to edit DeployTarget, got %q (manual input %v)",
+			wrapper.Model.EditingVariable, wrapper.Model.ManualInput)
+	}
+	if wrapper.Model.TextInput.Value() != "staging" {
+		t.Errorf("Expected the text input to be pre-filled with staging, got %q", wrapper.Model.TextInput.Value())
+	}
+
+	wrapper.Model.TextInput.SetValue("production")
+	result, _ = HandleTextInputSubmission(wrapper.Model)
+	wrapper = result.(ModelWrapper)
+	if wrapper.Model.ManualInput {
+		t.Errorf("Expected manual input to be disabled after submission")
+	}
+
+	// Variables without a value are not sent
+	variables := getPipelineVariables(wrapper.Model)
+	if len(variables) != 1 || variables[0].Name != "DeployTarget" || variables[0].Value != "production" {
+		t.Errorf("Expected DeployTarget=production to be sent, got %v", variables)
+	}
+
+	// Navigating back keeps the entered values
+	backResult := NavigateBack(wrapper.Model)
+	if backResult.CurrentView != constants.ViewExecutingAction {
+		t.Errorf("Expected to navigate back to ViewExecutingAction, got %v", backResult.CurrentView)
+	}
+	if len(backResult.PipelineVariables) != 2 {
+		t.Errorf("Expected the pipeline variables to be kept, got %v", backResult.PipelineVariables)
+	}
+}
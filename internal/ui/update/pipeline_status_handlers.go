@@ -2,48 +2,59 @@ package update
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
 	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
 	"github.com/HenryOwenz/cloudgate/internal/ui/model"
 	"github.com/HenryOwenz/cloudgate/internal/ui/view"
 )
 
-// HandlePipelineExecution handles the result of a pipeline execution
-func HandlePipelineExecution(m *model.Model, err error) {
+// HandlePipelineExecution handles the result of a pipeline execution. When the ID of
+// the started execution is known, it returns the command that starts watching it.
+func HandlePipelineExecution(m *model.Model, executionID string, err error) tea.Cmd {
 	if err != nil {
 		m.Error = fmt.Sprintf(constants.MsgErrorGeneric, err.Error())
 		m.CurrentView = constants.ViewError
-		return
+		return nil
 	}
 
 	m.Success = fmt.Sprintf(constants.MsgPipelineStartSuccess, m.SelectedPipeline.Name)
 
-	// Reset pipeline state
-	m.SelectedPipeline = nil
-	m.CommitID = ""
-	m.ManualCommitID = false
+	// Reset pipeline state, keeping the pipeline to watch the execution
+	if executionID == "" {
+		m.SelectedPipeline = nil
+	}
+	resetSourceRevision(m)
+	resetPipelineVariables(m)
 
 	// Completely reset the text input
 	m.ResetTextInput()
 	m.TextInput.Placeholder = constants.MsgEnterComment
 	m.ManualInput = false
 
+	// Clear the pipelines list and the cached status to force a refresh next time
+	m.Pipelines = nil
+	invalidateCache(m, constants.CachePipelineStatus)
+
+	if executionID != "" {
+		return StartExecutionWatch(m, executionID)
+	}
+
 	// Navigate back to the operation selection view
 	m.CurrentView = constants.ViewSelectOperation
 
-	// Clear the pipelines list to force a refresh next time
-	m.Pipelines = nil
-
 	// Update the table for the current view
 	view.UpdateTableForView(m)
+	return nil
 }
 
 // FetchPipelineStatus fetches pipeline status from the provider
 func FetchPipelineStatus(m *model.Model) tea.Cmd {
-	return func() tea.Msg {
+	return fetchCached(m, constants.CachePipelineStatus, constants.PipelineStatusCacheTTL, withRequest(m, func(ctx context.Context) tea.Msg {
 		// Get the provider from the registry
 		provider, err := m.Registry.Get("AWS")
 		if err != nil {
@@ -57,22 +68,44 @@ func FetchPipelineStatus(m *model.Model) tea.Cmd {
 		}
 
 		// Get pipeline status using the operation
-		ctx := context.Background()
 		pipelines, err := statusOperation.GetPipelineStatus(ctx)
-		if err != nil {
+		failedPipelines := getFailedPipelines(err)
+		if err != nil && (len(failedPipelines) == 0 || len(pipelines) == 0) {
 			return model.ErrMsg{Err: err}
 		}
 
 		return model.PipelineStatusMsg{
-			Pipelines: pipelines,
-			Provider:  provider,
+			Pipelines:       pipelines,
+			Provider:        provider,
+			FailedPipelines: failedPipelines,
 		}
+	}))
+}
+
+// getFailedPipelines returns the pipelines reported as failed by a pipeline read
+func getFailedPipelines(err error) []string {
+	if err == nil {
+		return nil
+	}
+
+	errs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
 	}
+
+	var failedPipelines []string
+	for _, err := range errs {
+		var pipelineErr *cloud.PipelineError
+		if errors.As(err, &pipelineErr) {
+			failedPipelines = append(failedPipelines, pipelineErr.Pipeline)
+		}
+	}
+	return failedPipelines
 }
 
 // ExecutePipeline executes a pipeline
 func ExecutePipeline(m *model.Model) tea.Cmd {
-	return func() tea.Msg {
+	return withRequest(m, func(ctx context.Context) tea.Msg {
 		if m.SelectedPipeline == nil {
 			return model.ErrMsg{Err: fmt.Errorf("no pipeline selected")}
 		}
@@ -90,12 +123,11 @@ func ExecutePipeline(m *model.Model) tea.Cmd {
 		}
 
 		// Execute the pipeline using the operation
-		ctx := context.Background()
-		err = startOperation.StartPipelineExecution(ctx, m.SelectedPipeline.Name, m.CommitID)
+		executionID, err := startOperation.StartPipelineExecution(ctx, m.SelectedPipeline.Name, getSourceRevisions(m), getPipelineVariables(m))
 		if err != nil {
 			return model.PipelineExecutionMsg{Err: err}
 		}
 
-		return model.PipelineExecutionMsg{Err: nil}
-	}
+		return model.PipelineExecutionMsg{ExecutionID: executionID}
+	})
 }
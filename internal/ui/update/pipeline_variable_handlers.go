@@ -0,0 +1,127 @@
+package update
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HandlePipelineVariables shows the variables declared in the selected pipeline,
+// loading them the first time so previously entered values are kept
+func HandlePipelineVariables(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.SelectedPipeline == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoPipeline)}
+		}
+	}
+
+	if len(m.PipelineVariables) > 0 {
+		newModel := m.Clone()
+		newModel.CurrentView = constants.ViewPipelineVariables
+		view.UpdateTableForView(newModel)
+		return WrapModel(newModel), nil
+	}
+
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgLoadingPipelineVariables
+
+	pipelineName := m.SelectedPipeline.Name
+	return WrapModel(newModel), withRequest(m, func(ctx context.Context) tea.Msg {
+		// Get the provider from the registry
+		provider, err := m.Registry.Get("AWS")
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Get the StartPipelineOperation from the provider
+		startOperation, err := provider.GetStartPipelineOperation()
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Get the pipeline variables using the operation
+		variables, err := startOperation.GetPipelineVariables(ctx, pipelineName)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.PipelineVariablesMsg{
+			Variables: variables,
+		}
+	})
+}
+
+// HandlePipelineVariablesLoaded handles the variables loaded for the selected pipeline
+func HandlePipelineVariablesLoaded(m *model.Model, variables []cloud.PipelineVariable) {
+	if len(variables) == 0 {
+		pipelineName := ""
+		if m.SelectedPipeline != nil {
+			pipelineName = m.SelectedPipeline.Name
+		}
+		m.Err = fmt.Errorf(constants.MsgErrorNoPipelineVariables, pipelineName)
+		return
+	}
+
+	m.PipelineVariables = variables
+	m.CurrentView = constants.ViewPipelineVariables
+	view.UpdateTableForView(m)
+}
+
+// HandlePipelineVariableSelection prompts for the value of the selected pipeline variable
+func HandlePipelineVariableSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	if selected := m.Table.SelectedRow(); len(selected) > 0 {
+		for _, variable := range m.PipelineVariables {
+			if variable.Name == selected[0] {
+				newModel := m.Clone()
+				newModel.EditingVariable = variable.Name
+				newModel.ManualInput = true
+				newModel.TextInput.SetValue(variable.Value)
+				newModel.TextInput.Focus()
+				newModel.TextInput.Placeholder = fmt.Sprintf(constants.MsgEnterVariableValue, variable.Name)
+				return WrapModel(newModel), nil
+			}
+		}
+	}
+	return WrapModel(m), nil
+}
+
+// setPipelineVariable sets the value of a pipeline variable, falling back to
+// the declared default when the value is cleared
+func setPipelineVariable(m *model.Model, name, value string) {
+	// Copy the variables so clones of the model are not affected
+	variables := make([]cloud.PipelineVariable, len(m.PipelineVariables))
+	copy(variables, m.PipelineVariables)
+
+	for i := range variables {
+		if variables[i].Name == name {
+			variables[i].Value = value
+			if value == "" {
+				variables[i].Value = variables[i].DefaultValue
+			}
+		}
+	}
+	m.PipelineVariables = variables
+}
+
+// getPipelineVariables returns the pipeline variables entered for the pipeline start
+func getPipelineVariables(m *model.Model) []cloud.PipelineVariable {
+	var variables []cloud.PipelineVariable
+	for _, variable := range m.PipelineVariables {
+		if variable.Value != "" {
+			variables = append(variables, variable)
+		}
+	}
+	return variables
+}
+
+// resetPipelineVariables clears the pipeline variables entered for the pipeline start
+func resetPipelineVariables(m *model.Model) {
+	m.PipelineVariables = nil
+	m.EditingVariable = ""
+}
@@ -0,0 +1,50 @@
+package update
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+)
+
+// TestProfileDetails tests showing the configured details of the profiles and
+// pre-selecting the region of the selected one
+func TestProfileDetails(t *testing.T) {
+	m := model.New()
+	m.CurrentView = constants.ViewAWSConfig
+	m.Profiles = []string{"default", "dev"}
+	m.Regions = constants.DefaultAWSRegions
+	m.SetProfileDetails([]cloud.ProfileDetails{
+		{Name: "dev", Region: m.Regions[2], Account: "111111111111", Role: "Developer", AuthType: "SSO"},
+	})
+	view.UpdateTableForView(m)
+
+	rows := m.Table.Rows()
+	if len(rows) != 3 || strings.Join(rows[2], ",") != "dev,111111111111,Developer,SSO" {
+		t.Fatalf("Expected the profile details in the table, got %v", rows)
+	}
+	if strings.Join(rows[1], ",") != "default,,," {
+		t.Errorf("Expected a profile without details to have empty columns, got %v", rows[1])
+	}
+
+	// Selecting the profile moves the cursor to its region
+	m.Table.SetCursor(2)
+	newModel, _ := HandleAWSConfigSelection(m)
+	next := newModel.(ModelWrapper).Model
+	if next.GetAwsProfile() != "dev" {
+		t.Fatalf("Expected the dev profile, got %q", next.GetAwsProfile())
+	}
+	if selected := next.Table.SelectedRow(); len(selected) == 0 || selected[0] != m.Regions[2] {
+		t.Errorf("Expected the profile region to be selected, got %v", selected)
+	}
+
+	// Profiles without a region start on the manual entry row
+	m.Table.SetCursor(1)
+	newModel, _ = HandleAWSConfigSelection(m)
+	if cursor := newModel.(ModelWrapper).Model.Table.Cursor(); cursor != 0 {
+		t.Errorf("Expected the cursor on the first row, got %d", cursor)
+	}
+}
@@ -0,0 +1,88 @@
+package update
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// waitForCancel is a fetch that only returns once its request is done
+func waitForCancel(ctx context.Context) tea.Msg {
+	<-ctx.Done()
+	return model.ErrMsg{Err: ctx.Err()}
+}
+
+// TestRequestTimeout tests giving up a request that takes longer than the timeout
+func TestRequestTimeout(t *testing.T) {
+	m := model.New()
+	m.RequestTimeout = time.Millisecond
+
+	msg, ok := withRequest(m, waitForCancel)().(model.ErrMsg)
+	if !ok {
+		t.Fatalf("Expected an error message")
+	}
+	if msg.Err.Error() != "Request timed out after 1ms" {
+		t.Errorf("Expected the request to time out, got %q", msg.Err.Error())
+	}
+
+	// Requests finishing in time return their result
+	cmd := withRequest(m, func(ctx context.Context) tea.Msg {
+		return model.PipelineStatusMsg{}
+	})
+	if _, ok := cmd().(model.PipelineStatusMsg); !ok {
+		t.Errorf("Expected the result of the request")
+	}
+}
+
+// TestCancelRequests tests cancelling the requests in flight when leaving a load
+func TestCancelRequests(t *testing.T) {
+	m := model.New()
+	m.RequestTimeout = 0
+	m.IsLoading = true
+	m.LoadingMsg = constants.MsgLoadingPipelines
+
+	cmd := fetchCached(m, constants.CachePipelineStatus, constants.PipelineStatusCacheTTL, withRequest(m, waitForCancel))
+	CancelRequests(m.Clone())
+
+	if _, ok := cmd().(model.RequestCancelledMsg); !ok {
+		t.Fatalf("Expected the request to be cancelled")
+	}
+	if _, ok := m.Cache.Get(getCacheKey(m, constants.CachePipelineStatus)); ok {
+		t.Errorf("Expected a cancelled request not to be cached")
+	}
+
+	// Requests started after the cancel are not cancelled
+	cmd = withRequest(m, func(ctx context.Context) tea.Msg {
+		if ctx.Err() != nil {
+			return model.ErrMsg{Err: ctx.Err()}
+		}
+		return model.PipelineStatusMsg{}
+	})
+	if _, ok := cmd().(model.PipelineStatusMsg); !ok {
+		t.Errorf("Expected a new request to run")
+	}
+}
+
+// TestCancelRequestsState tests stopping the load and telling it was cancelled
+func TestCancelRequestsState(t *testing.T) {
+	m := model.New()
+	m.CurrentView = constants.ViewSelectOperation
+	m.IsLoading = true
+	m.LoadingMsg = constants.MsgLoadingPipelines
+
+	CancelRequests(m)
+
+	if m.IsLoading || m.LoadingMsg != "" {
+		t.Errorf("Expected loading to stop")
+	}
+	if m.CurrentView != constants.ViewSelectOperation {
+		t.Errorf("Expected to stay on the view the load started from, got %v", m.CurrentView)
+	}
+	if m.RequestNotice != constants.MsgRequestCancelled {
+		t.Errorf("Expected the cancelled notice, got %q", m.RequestNotice)
+	}
+}
@@ -0,0 +1,61 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+)
+
+// withRequest returns the command that runs the fetch with the context of a provider
+// request. The request times out after the request timeout and is cancelled when the
+// user leaves the view it loads, whatever the fetch returns then.
+func withRequest(m *model.Model, fetch func(ctx context.Context) tea.Msg) tea.Cmd {
+	return withRequestTimeout(m, m.RequestTimeout, fetch)
+}
+
+// withRequestTimeout returns the command that runs the fetch with the context of a
+// provider request that times out after the given timeout, or never when it is zero.
+func withRequestTimeout(m *model.Model, timeout time.Duration, fetch func(ctx context.Context) tea.Msg) tea.Cmd {
+	parent := m.Requests.Context()
+
+	return func() tea.Msg {
+		ctx, cancel := newRequestContext(parent, timeout)
+		defer cancel()
+
+		msg := fetch(ctx)
+		switch err := ctx.Err(); {
+		case errors.Is(err, context.DeadlineExceeded):
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorRequestTimedOut, timeout)}
+		case errors.Is(err, context.Canceled):
+			return model.RequestCancelledMsg{}
+		}
+		return msg
+	}
+}
+
+// newRequestContext returns the context of a provider request started from the parent
+// that times out after the given timeout, or never when it is zero
+func newRequestContext(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout > 0 {
+		return context.WithTimeout(parent, timeout)
+	}
+	return context.WithCancel(parent)
+}
+
+// CancelRequests cancels the requests in flight and stops loading, leaving the user on
+// the view the load was started from
+func CancelRequests(m *model.Model) {
+	m.Requests.Cancel()
+	m.IsLoading = false
+	m.LoadingMsg = ""
+	m.DeviceAuthorization = nil
+	m.RequestNotice = constants.MsgRequestCancelled
+	view.UpdateTableForView(m)
+}
@@ -152,10 +152,16 @@ func SelectOperation(m *model.Model) (tea.Model, tea.Cmd) {
 			switch operationName {
 			case "Pipeline Approvals":
 				return HandlePipelineApprovals(newModel)
+			case "Approvals Inbox":
+				return HandleApprovalsInbox(newModel)
 			case "Pipeline Status":
 				return HandlePipelineStatus(newModel)
 			case "Start Pipeline":
 				return HandlePipelineStatus(newModel)
+			case "Execution History":
+				return HandlePipelineStatus(newModel)
+			case "Pipeline Definition":
+				return HandlePipelineStatus(newModel)
 			case "Function Status":
 				return HandleFunctionStatus(newModel)
 			default:
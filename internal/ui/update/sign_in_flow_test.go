@@ -0,0 +1,342 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+)
+
+// signInProvider is an AWS provider whose sign in waits for the test to approve its
+// device authorization
+type signInProvider struct {
+	cloud.Provider
+	approve    chan error
+	authConfig map[string]string
+}
+
+func (p *signInProvider) Name() string {
+	return "AWS"
+}
+
+func (p *signInProvider) Services() []cloud.Service {
+	return nil
+}
+
+func (p *signInProvider) Authenticate(ctx context.Context, method string, authConfig map[string]string) error {
+	p.authConfig = authConfig
+	cloud.ReportDeviceAuthorization(ctx, cloud.DeviceAuthorization{
+		VerificationURIComplete: "https://device.sso.us-east-1.amazonaws.com/?user_code=ABCD-EFGH",
+		UserCode:                "ABCD-EFGH",
+	})
+	return <-p.approve
+}
+
+// newSignInModel returns a model on the region selection of an SSO profile
+func newSignInModel(provider cloud.Provider) *model.Model {
+	m := model.New()
+	m.Registry = InitializeTestRegistry(provider)
+	m.CurrentView = constants.ViewAWSConfig
+	m.Regions = constants.DefaultAWSRegions
+	m.SetProfileDetails([]cloud.ProfileDetails{{Name: "dev", AuthType: "SSO", AuthMethod: constants.AWSSSOAuth}})
+	m.SetAwsProfile("dev")
+	view.UpdateTableForView(m)
+	m.Table.SetCursor(1)
+	return m
+}
+
+// startSignIn selects the region and runs the sign in, returning the device
+// authorization and the channel of the sign in result
+func startSignIn(t *testing.T, m *model.Model) (*model.Model, model.DeviceAuthorizationMsg, chan tea.Msg) {
+	newModel, cmd := HandleAWSConfigSelection(m)
+	next := newModel.(ModelWrapper).Model
+	if !next.IsLoading || next.LoadingMsg != constants.MsgSigningIn {
+		t.Fatalf("Expected the sign in to start, got loading %v %q", next.IsLoading, next.LoadingMsg)
+	}
+	if next.GetAwsRegion() != "" {
+		t.Errorf("Expected the region to be set once signed in, got %q", next.GetAwsRegion())
+	}
+
+	cmds := cmd().(tea.BatchMsg)
+	results := make(chan tea.Msg, 1)
+	go func() { results <- cmds[0]() }()
+
+	authorization, ok := cmds[1]().(model.DeviceAuthorizationMsg)
+	if !ok {
+		t.Fatalf("Expected the device authorization of the sign in")
+	}
+	return next, authorization, results
+}
+
+// TestSignInFlow tests signing in to an SSO profile after selecting its region
+func TestSignInFlow(t *testing.T) {
+	provider := &signInProvider{approve: make(chan error)}
+	m, authorization, results := startSignIn(t, newSignInModel(provider))
+
+	HandleDeviceAuthorization(m, authorization)
+	if m.LoadingMsg != constants.MsgWaitingForSignIn {
+		t.Errorf("Expected to wait for the sign in, got %q", m.LoadingMsg)
+	}
+	if rendered := view.Render(m); !strings.Contains(rendered, "ABCD-EFGH") || !strings.Contains(rendered, "user_code=ABCD-EFGH") {
+		t.Errorf("Expected the verification URL and code to be shown, got %q", rendered)
+	}
+
+	provider.approve <- nil
+	msg, ok := (<-results).(model.SignInMsg)
+	if !ok {
+		t.Fatalf("Expected the sign in result")
+	}
+	HandleSignInResult(m, msg)
+
+	if m.CurrentView != constants.ViewSelectService {
+		t.Errorf("Expected the service selection, got view %v", m.CurrentView)
+	}
+	if m.GetAwsRegion() != constants.DefaultAWSRegions[0] || provider.authConfig["region"] != constants.DefaultAWSRegions[0] {
+		t.Errorf("Expected to sign in to the selected region, got %q", m.GetAwsRegion())
+	}
+	if m.IsLoading || m.DeviceAuthorization != nil {
+		t.Errorf("Expected the sign in to be done")
+	}
+}
+
+// TestSignInFlowErrors tests failing and cancelling a sign in
+func TestSignInFlowErrors(t *testing.T) {
+	provider := &signInProvider{approve: make(chan error, 1)}
+	m, authorization, results := startSignIn(t, newSignInModel(provider))
+
+	HandleDeviceAuthorization(m, authorization)
+	provider.approve <- errors.New("sign in denied")
+	HandleSignInResult(m, (<-results).(model.SignInMsg))
+	if m.Err == nil || m.CurrentView != constants.ViewAWSConfig || m.DeviceAuthorization != nil {
+		t.Errorf("Expected the sign in error on the region selection, got %v", m.Err)
+	}
+
+	// Authorizations arriving after the sign in was cancelled are dropped
+	m, authorization, results = startSignIn(t, newSignInModel(provider))
+	CancelRequests(m)
+	HandleDeviceAuthorization(m, authorization)
+	if m.DeviceAuthorization != nil {
+		t.Error("Expected no device authorization after cancelling")
+	}
+	provider.approve <- nil
+	if _, ok := (<-results).(model.RequestCancelledMsg); !ok {
+		t.Error("Expected the sign in to be cancelled")
+	}
+}
+
+// mfaProvider is an AWS provider whose role profile needs an MFA code to sign in
+type mfaProvider struct {
+	cloud.Provider
+	authConfigs []map[string]string
+}
+
+func (p *mfaProvider) Name() string {
+	return "AWS"
+}
+
+func (p *mfaProvider) Services() []cloud.Service {
+	return nil
+}
+
+func (p *mfaProvider) Authenticate(ctx context.Context, method string, authConfig map[string]string) error {
+	p.authConfigs = append(p.authConfigs, authConfig)
+	if authConfig[constants.AWSMFACodeKey] == "" {
+		return cloud.ErrTokenCodeRequired
+	}
+	return nil
+}
+
+// runSignIn runs the sign in started by a command and handles its result
+func runSignIn(t *testing.T, m *model.Model, cmd tea.Cmd) {
+	msg, ok := cmd().(tea.BatchMsg)[0]().(model.SignInMsg)
+	if !ok {
+		t.Fatalf("Expected the sign in result")
+	}
+	HandleSignInResult(m, msg)
+}
+
+// TestSignInFlowMFA tests entering the MFA code of a role profile after selecting its region
+func TestSignInFlowMFA(t *testing.T) {
+	provider := &mfaProvider{}
+	m := newSignInModel(provider)
+	m.SetProfileDetails([]cloud.ProfileDetails{{Name: "dev", AuthType: "AssumeRole", AuthMethod: constants.AWSMFAAuth, MFADevice: "arn:aws:iam::123456789012:mfa/dev"}})
+
+	newModel, cmd := HandleAWSConfigSelection(m)
+	m = newModel.(ModelWrapper).Model
+	if m.LoadingMsg != constants.MsgAssumingRole {
+		t.Errorf("Expected to assume the role, got %q", m.LoadingMsg)
+	}
+	runSignIn(t, m, cmd)
+
+	if !IsTokenCodePrompt(m) || !m.ManualInput || m.Err != nil {
+		t.Fatalf("Expected the MFA code prompt, got view %v and error %v", m.CurrentView, m.Err)
+	}
+	if rendered := view.Render(m); !strings.Contains(rendered, constants.TitleMFACode) || !strings.Contains(rendered, "mfa/dev") {
+		t.Errorf("Expected the prompt to name the MFA device, got %q", rendered)
+	}
+
+	// An empty code is rejected
+	newModel, cmd = HandleTextInputSubmission(m)
+	if _, ok := cmd().(model.ErrMsg); !ok || len(provider.authConfigs) != 1 {
+		t.Errorf("Expected an empty MFA code to be rejected")
+	}
+
+	m = newModel.(ModelWrapper).Model
+	m.TextInput.SetValue(" 123456 ")
+	newModel, cmd = HandleTextInputSubmission(m)
+	m = newModel.(ModelWrapper).Model
+	if m.TextInput.Value() != "" {
+		t.Errorf("Expected the MFA code not to be kept, got %q", m.TextInput.Value())
+	}
+	runSignIn(t, m, cmd)
+
+	if m.CurrentView != constants.ViewSelectService || m.ProviderState.AuthState.Method != "" || m.ManualInput {
+		t.Errorf("Expected the service selection, got view %v", m.CurrentView)
+	}
+	if authConfig := provider.authConfigs[1]; authConfig[constants.AWSMFACodeKey] != "123456" ||
+		authConfig[constants.AWSRegionKey] != constants.DefaultAWSRegions[0] {
+		t.Errorf("Expected to sign in with the MFA code in the selected region, got %v", authConfig)
+	}
+}
+
+// TestSignInFlowMFABack tests leaving the MFA code prompt
+func TestSignInFlowMFABack(t *testing.T) {
+	m := newSignInModel(&mfaProvider{})
+	m.SetProfileDetails([]cloud.ProfileDetails{{Name: "dev", AuthType: "AssumeRole", AuthMethod: constants.AWSMFAAuth}})
+
+	newModel, cmd := HandleAWSConfigSelection(m)
+	m = newModel.(ModelWrapper).Model
+	runSignIn(t, m, cmd)
+
+	m = NavigateBack(m)
+	if m.CurrentView != constants.ViewAWSConfig || IsTokenCodePrompt(m) || m.ManualInput {
+		t.Errorf("Expected the region selection, got view %v", m.CurrentView)
+	}
+}
+
+// credentialsProvider is an AWS provider offering static keys besides its profiles
+type credentialsProvider struct {
+	cloud.Provider
+	method     string
+	authConfig map[string]string
+}
+
+func (p *credentialsProvider) Name() string {
+	return "AWS"
+}
+
+func (p *credentialsProvider) Description() string {
+	return "Amazon Web Services"
+}
+
+func (p *credentialsProvider) Services() []cloud.Service {
+	return nil
+}
+
+func (p *credentialsProvider) GetProfiles() ([]string, error) {
+	return []string{"dev"}, nil
+}
+
+func (p *credentialsProvider) GetProfileDetails() ([]cloud.ProfileDetails, error) {
+	return nil, nil
+}
+
+func (p *credentialsProvider) GetAuthenticationMethods() []string {
+	return []string{constants.AWSProfileAuth, constants.AWSStaticKeysAuth}
+}
+
+func (p *credentialsProvider) GetAuthConfigKeys(method string) []string {
+	return []string{"access_key_id", constants.AWSSecretAccessKeyKey, constants.AWSRegionKey}
+}
+
+func (p *credentialsProvider) Authenticate(ctx context.Context, method string, authConfig map[string]string) error {
+	p.method = method
+	p.authConfig = authConfig
+	return nil
+}
+
+// selectRow selects the row of the table whose first column is value
+func selectRow(t *testing.T, m *model.Model, value string) *model.Model {
+	for i, row := range m.Table.Rows() {
+		if row[0] == value {
+			m.Table.SetCursor(i)
+			newModel, _ := HandleTableSelect(m)
+			return newModel.(ModelWrapper).Model
+		}
+	}
+	t.Fatalf("Expected a %q row in view %v", value, m.CurrentView)
+	return nil
+}
+
+// enterAuthConfig enters the value of an auth config key
+func enterAuthConfig(t *testing.T, m *model.Model, key, value string) *model.Model {
+	m = selectRow(t, m, key)
+	m.TextInput.SetValue(value)
+	newModel, _ := HandleTextInputSubmission(m)
+	return newModel.(ModelWrapper).Model
+}
+
+// TestSignInFlowStaticKeys tests authenticating with static keys entered in the auth
+// config view
+func TestSignInFlowStaticKeys(t *testing.T) {
+	provider := &credentialsProvider{}
+	m := model.New()
+	m.Registry = InitializeTestRegistry(provider)
+	view.UpdateTableForView(m)
+
+	m = selectRow(t, m, "AWS")
+	if m.CurrentView != constants.ViewAuthMethodSelect {
+		t.Fatalf("Expected the authentication methods, got view %v", m.CurrentView)
+	}
+
+	m = selectRow(t, m, constants.AWSStaticKeysAuth)
+	m = enterAuthConfig(t, m, "access_key_id", "AKIAEXAMPLE")
+	m = selectRow(t, m, constants.AWSSecretAccessKeyKey)
+	if m.TextInput.EchoMode != textinput.EchoPassword {
+		t.Error("Expected the secret to be masked while entered")
+	}
+	m.TextInput.SetValue("wJalrXUtnFEMI")
+	newModel, _ := HandleTextInputSubmission(m)
+	m = newModel.(ModelWrapper).Model
+	m = enterAuthConfig(t, m, constants.AWSRegionKey, "eu-west-1")
+
+	if rendered := view.Render(m); strings.Contains(rendered, "wJalrXUtnFEMI") || !strings.Contains(rendered, "AKIAEXAMPLE") {
+		t.Errorf("Expected the secret to be masked, got %q", rendered)
+	}
+
+	// Reads cached with the credentials of an earlier sign in are not reused
+	staleKey := cloud.CacheKey{Provider: "AWS", Profile: constants.AWSStaticKeysAuth, Region: "eu-west-1", Operation: constants.CachePipelineStatus}
+	m.Cache.Set(staleKey, model.PipelineStatusMsg{}, constants.PipelineStatusCacheTTL)
+
+	m.Table.SetCursor(len(m.Table.Rows()) - 1)
+	newModel, cmd := HandleTableSelect(m)
+	m = newModel.(ModelWrapper).Model
+	runSignIn(t, m, cmd)
+	if _, ok := m.Cache.Get(staleKey); ok {
+		t.Error("Expected the cached reads to be cleared on sign in")
+	}
+
+	if provider.method != constants.AWSStaticKeysAuth || provider.authConfig[constants.AWSSecretAccessKeyKey] != "wJalrXUtnFEMI" ||
+		provider.authConfig[constants.AWSProfileKey] != constants.AWSStaticKeysAuth {
+		t.Errorf("Expected to authenticate with the static keys, got %v", provider.authConfig)
+	}
+	if m.CurrentView != constants.ViewSelectService || m.GetAwsProfile() != constants.AWSStaticKeysAuth || m.GetAwsRegion() != "eu-west-1" {
+		t.Errorf("Expected the service selection, got view %v", m.CurrentView)
+	}
+	if len(m.ProviderState.AuthState.AuthConfig) != 0 {
+		t.Errorf("Expected the entered keys not to be kept, got %v", m.ProviderState.AuthState.AuthConfig)
+	}
+
+	m = NavigateBack(m)
+	if m.CurrentView != constants.ViewAuthConfig {
+		t.Errorf("Expected to go back to the auth config, got view %v", m.CurrentView)
+	}
+}
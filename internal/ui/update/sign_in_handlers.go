@@ -0,0 +1,213 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+)
+
+// getSignInMethod returns the authentication method the selected profile signs in
+// with before its services can be used, or an empty string when it needs no sign in
+func getSignInMethod(m *model.Model) string {
+	switch method := m.ProfileDetails[m.GetAwsProfile()].AuthMethod; method {
+	case constants.AWSSSOAuth, constants.AWSMFAAuth:
+		return method
+	default:
+		return ""
+	}
+}
+
+// StartSignIn signs in to the selected profile in the region, with the MFA code when
+// one was entered. The sign in does not time out, as it may wait for the user to
+// approve its device authorization in a browser, but it is cancelled like any other
+// request.
+func StartSignIn(m *model.Model, provider cloud.Provider, region, tokenCode string) tea.Cmd {
+	authConfig := map[string]string{
+		constants.AWSProfileKey: m.GetAwsProfile(),
+		constants.AWSRegionKey:  region,
+	}
+	if tokenCode != "" {
+		authConfig[constants.AWSMFACodeKey] = tokenCode
+	}
+	return authenticate(m, provider, getSignInMethod(m), authConfig)
+}
+
+// SubmitAuthConfig authenticates with the configuration entered for the selected
+// authentication method. Its credentials are cached by the provider under the name
+// of the method, which the views show as the profile.
+func SubmitAuthConfig(m *model.Model) (tea.Model, tea.Cmd) {
+	provider, err := m.Registry.Get(m.ProviderState.ProviderName)
+	if err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	method := m.ProviderState.AuthState.Method
+	authConfig := map[string]string{constants.AWSProfileKey: method}
+	for key, value := range m.ProviderState.AuthState.AuthConfig {
+		authConfig[key] = value
+	}
+
+	newModel := m.Clone()
+	return WrapModel(newModel), authenticate(newModel, provider, method, authConfig)
+}
+
+// authenticate returns the command authenticating with the provider, which reports
+// its device authorization if it needs one
+func authenticate(m *model.Model, provider cloud.Provider, method string, authConfig map[string]string) tea.Cmd {
+	profile, region := authConfig[constants.AWSProfileKey], authConfig[constants.AWSRegionKey]
+	authorizations := make(chan cloud.DeviceAuthorization, 1)
+
+	m.IsLoading = true
+	switch method {
+	case constants.AWSSSOAuth:
+		m.LoadingMsg = constants.MsgSigningIn
+	case constants.AWSMFAAuth:
+		m.LoadingMsg = constants.MsgAssumingRole
+	default:
+		m.LoadingMsg = constants.MsgAuthenticating
+	}
+	m.DeviceAuthorization = nil
+
+	signIn := withRequestTimeout(m, 0, func(ctx context.Context) tea.Msg {
+		ctx = cloud.WithDeviceAuthorization(ctx, func(authorization cloud.DeviceAuthorization) {
+			select {
+			case authorizations <- authorization:
+			default:
+			}
+		})
+
+		err := provider.Authenticate(ctx, method, authConfig)
+		return model.SignInMsg{Provider: provider, Profile: profile, Region: region, Err: err}
+	})
+
+	return tea.Batch(
+		func() tea.Msg {
+			defer close(authorizations)
+			return signIn()
+		},
+		listenDeviceAuthorization(authorizations),
+	)
+}
+
+// listenDeviceAuthorization returns the command that waits for the device authorization
+// of a sign in, if it needs one
+func listenDeviceAuthorization(authorizations <-chan cloud.DeviceAuthorization) tea.Cmd {
+	return func() tea.Msg {
+		authorization, ok := <-authorizations
+		if !ok {
+			return nil
+		}
+		return model.DeviceAuthorizationMsg{Authorization: authorization}
+	}
+}
+
+// HandleDeviceAuthorization shows the user how to approve the sign in. Authorizations
+// arriving after the sign in was cancelled are dropped.
+func HandleDeviceAuthorization(m *model.Model, msg model.DeviceAuthorizationMsg) {
+	if !m.IsLoading {
+		return
+	}
+	authorization := msg.Authorization
+	m.DeviceAuthorization = &authorization
+	m.LoadingMsg = constants.MsgWaitingForSignIn
+}
+
+// HandleSignInResult moves on to the service selection once signed in, looking up the
+// identity signed in as, or asks for the MFA code when the role of the profile needs
+// one. The entered configuration is cleared once signed in, so secrets are only kept
+// by the provider, and so are the cached reads, as credential sources sign in under
+// the same name whatever account their credentials are of.
+func HandleSignInResult(m *model.Model, msg model.SignInMsg) tea.Cmd {
+	m.IsLoading = false
+	m.LoadingMsg = ""
+	m.DeviceAuthorization = nil
+
+	if errors.Is(msg.Err, cloud.ErrTokenCodeRequired) && !IsTokenCodePrompt(m) {
+		promptTokenCode(m, msg.Region)
+		return nil
+	}
+	if msg.Err != nil {
+		m.Err = msg.Err
+		return nil
+	}
+
+	if IsTokenCodePrompt(m) {
+		resetTokenCodePrompt(m)
+	}
+	if m.Cache != nil {
+		m.Cache.Clear()
+	}
+	m.ProviderState.AuthState.AuthConfig = make(map[string]string)
+	m.ProviderState.AuthState.CurrentAuthConfigKey = ""
+	m.ManualInput = false
+	m.ResetTextInput()
+	m.SetAwsProfile(msg.Profile)
+	m.SetAwsRegion(msg.Region)
+	m.Provider = msg.Provider
+	m.CurrentView = constants.ViewSelectService
+	view.UpdateTableForView(m)
+	return FetchCallerIdentity(m, msg.Provider)
+}
+
+// IsTokenCodePrompt returns whether the model asks for the MFA code of a role profile
+func IsTokenCodePrompt(m *model.Model) bool {
+	return m.CurrentView == constants.ViewAuthConfig && m.ProviderState.AuthState.Method == constants.AWSMFAAuth
+}
+
+// promptTokenCode asks for the MFA code to sign in to the selected profile in the region
+func promptTokenCode(m *model.Model, region string) {
+	m.CurrentView = constants.ViewAuthConfig
+	m.ProviderState.AuthState.Method = constants.AWSMFAAuth
+	m.ProviderState.AuthState.AuthConfig = map[string]string{
+		constants.AWSProfileKey: m.GetAwsProfile(),
+		constants.AWSRegionKey:  region,
+	}
+	m.ProviderState.AuthState.CurrentAuthConfigKey = constants.AWSMFACodeKey
+	m.ManualInput = true
+	m.ResetTextInput()
+	m.TextInput.Placeholder = constants.MsgEnterMFACode
+	m.TextInput.Focus()
+	view.UpdateTableForView(m)
+}
+
+// resetTokenCodePrompt clears the authentication state of the MFA code prompt
+func resetTokenCodePrompt(m *model.Model) {
+	m.ProviderState.AuthState.Method = ""
+	m.ProviderState.AuthState.AuthConfig = make(map[string]string)
+	m.ProviderState.AuthState.CurrentAuthConfigKey = ""
+	m.ManualInput = false
+	m.ResetTextInput()
+}
+
+// SubmitTokenCode signs in to the selected profile with the entered MFA code. The code
+// is only passed to the sign in and never kept in the model.
+func SubmitTokenCode(m *model.Model, code string) (tea.Model, tea.Cmd) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorEmptyMFACode)}
+		}
+	}
+
+	provider, err := m.Registry.Get("AWS")
+	if err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	newModel := m.Clone()
+	newModel.TextInput.SetValue("")
+	region := m.ProviderState.AuthState.AuthConfig[constants.AWSRegionKey]
+	return WrapModel(newModel), StartSignIn(newModel, provider, region, code)
+}
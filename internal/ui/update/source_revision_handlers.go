@@ -0,0 +1,154 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HandleSourceRevision loads the source actions of the selected pipeline so the
+// user can choose which source revision to start the pipeline from
+func HandleSourceRevision(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.SelectedPipeline == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoPipeline)}
+		}
+	}
+
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgLoadingSourceActions
+
+	pipelineName := m.SelectedPipeline.Name
+	return WrapModel(newModel), withRequest(m, func(ctx context.Context) tea.Msg {
+		// Get the provider from the registry
+		provider, err := m.Registry.Get("AWS")
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Get the StartPipelineOperation from the provider
+		startOperation, err := provider.GetStartPipelineOperation()
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Get the source actions using the operation
+		sourceActions, err := startOperation.GetSourceActions(ctx, pipelineName)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.SourceActionsMsg{
+			SourceActions: sourceActions,
+		}
+	})
+}
+
+// HandleSourceActions handles the source actions loaded for the selected pipeline
+func HandleSourceActions(m *model.Model, sourceActions []cloud.SourceAction) {
+	m.SourceActions = sourceActions
+
+	switch len(sourceActions) {
+	case 0:
+		pipelineName := ""
+		if m.SelectedPipeline != nil {
+			pipelineName = m.SelectedPipeline.Name
+		}
+		m.Err = fmt.Errorf(constants.MsgErrorNoSourceActions, pipelineName)
+	case 1:
+		// Skip the source action selection when there is nothing to choose from
+		if err := startSourceRevisionInput(m, sourceActions[0]); err != nil {
+			m.Err = err
+		}
+	default:
+		m.CurrentView = constants.ViewSelectSourceAction
+		view.UpdateTableForView(m)
+	}
+}
+
+// HandleSourceActionSelection handles the selection of a source action
+func HandleSourceActionSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	if selected := m.Table.SelectedRow(); len(selected) > 0 {
+		for _, action := range m.SourceActions {
+			if action.Name == selected[0] {
+				newModel := m.Clone()
+				if err := startSourceRevisionInput(newModel, action); err != nil {
+					return WrapModel(m), func() tea.Msg {
+						return model.ErrMsg{Err: err}
+					}
+				}
+				return WrapModel(newModel), nil
+			}
+		}
+	}
+	return WrapModel(m), nil
+}
+
+// startSourceRevisionInput selects a source action and prompts for its revision
+func startSourceRevisionInput(m *model.Model, action cloud.SourceAction) error {
+	if action.RevisionType == "" {
+		return fmt.Errorf(constants.MsgErrorUnsupportedSourceAction, action.Name, action.Provider)
+	}
+
+	// Discard a revision entered for a different source action
+	if m.SelectedSource == nil || m.SelectedSource.Name != action.Name {
+		m.CommitID = ""
+		m.ManualCommitID = false
+	}
+
+	m.SelectedSource = &action
+	m.CurrentView = constants.ViewSummary
+	m.ManualInput = true
+	m.TextInput.SetValue(m.CommitID)
+	m.TextInput.Focus()
+	m.TextInput.Placeholder = getRevisionPlaceholder(action.RevisionType)
+	view.UpdateTableForView(m)
+	return nil
+}
+
+// getRevisionPlaceholder returns the text input placeholder for a revision type
+func getRevisionPlaceholder(revisionType string) string {
+	switch revisionType {
+	case cloud.RevisionTypeImageDigest:
+		return constants.MsgEnterImageDigest
+	case cloud.RevisionTypeS3ObjectVersionID:
+		return constants.MsgEnterS3ObjectVersion
+	default:
+		return constants.MsgEnterCommitID
+	}
+}
+
+// getSourceRevisions returns the source revision overrides entered for the pipeline start
+func getSourceRevisions(m *model.Model) []cloud.SourceRevision {
+	revisionValue := strings.TrimSpace(m.CommitID)
+	if revisionValue == "" {
+		return nil
+	}
+
+	// Without a selected source action the operation falls back to the
+	// pipeline's only source action and its provider's revision type
+	revision := cloud.SourceRevision{
+		RevisionValue: revisionValue,
+	}
+	if m.SelectedSource != nil {
+		revision.ActionName = m.SelectedSource.Name
+		revision.RevisionType = m.SelectedSource.RevisionType
+	}
+
+	return []cloud.SourceRevision{revision}
+}
+
+// resetSourceRevision clears the source revision entered for the pipeline start
+func resetSourceRevision(m *model.Model) {
+	m.CommitID = ""
+	m.ManualCommitID = false
+	m.SourceActions = nil
+	m.SelectedSource = nil
+}
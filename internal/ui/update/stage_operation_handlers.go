@@ -0,0 +1,371 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HandleStageSelection handles the selection of a stage in the pipeline stages view
+func HandleStageSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.SelectedPipeline == nil {
+		return WrapModel(m), nil
+	}
+
+	if selected := m.Table.SelectedRow(); len(selected) > 0 {
+		for _, stage := range m.SelectedPipeline.Stages {
+			if stage.Name == selected[0] {
+				newModel := m.Clone()
+				newModel.SelectedStage = &stage
+				newModel.CurrentView = constants.ViewStageOperations
+				view.UpdateTableForView(newModel)
+
+				// Stay on the stages view when nothing can be done with the stage
+				if len(newModel.Table.Rows()) == 0 {
+					return WrapModel(m), nil
+				}
+				return WrapModel(newModel), nil
+			}
+		}
+	}
+	return WrapModel(m), nil
+}
+
+// HandleStageOperationSelection handles the selection of an operation on the selected stage
+func HandleStageOperationSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	if selected := m.Table.SelectedRow(); len(selected) > 0 {
+		newModel := m.Clone()
+		switch selected[0] {
+		case "View Actions":
+			newModel.CurrentView = constants.ViewStageActions
+			view.UpdateTableForView(newModel)
+			return WrapModel(newModel), nil
+		case "Stop Execution":
+			newModel.CurrentView = constants.ViewStopExecution
+			view.UpdateTableForView(newModel)
+			return WrapModel(newModel), nil
+		case "Retry Failed Actions":
+			newModel.RetryMode = cloud.RetryModeFailedActions
+			newModel.CurrentView = constants.ViewRetryStage
+			view.UpdateTableForView(newModel)
+			return WrapModel(newModel), nil
+		case "Retry All Actions":
+			newModel.RetryMode = cloud.RetryModeAllActions
+			newModel.CurrentView = constants.ViewRetryStage
+			view.UpdateTableForView(newModel)
+			return WrapModel(newModel), nil
+		case "Enable Transition", "Disable Transition":
+			newModel.CurrentView = constants.ViewStageTransition
+			view.UpdateTableForView(newModel)
+			return WrapModel(newModel), nil
+		case "Rollback Stage":
+			return HandleRollbackStage(m)
+		case "Override Before Entry Condition":
+			newModel.OverrideCondition = cloud.ConditionTypeBeforeEntry
+			newModel.CurrentView = constants.ViewOverrideCondition
+			view.UpdateTableForView(newModel)
+			return WrapModel(newModel), nil
+		case "Override On Success Condition":
+			newModel.OverrideCondition = cloud.ConditionTypeOnSuccess
+			newModel.CurrentView = constants.ViewOverrideCondition
+			view.UpdateTableForView(newModel)
+			return WrapModel(newModel), nil
+		}
+	}
+	return WrapModel(m), nil
+}
+
+// HandleActionSelection handles the selection of an action in the stage actions view
+func HandleActionSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.SelectedStage == nil {
+		return WrapModel(m), nil
+	}
+
+	if selected := m.Table.SelectedRow(); len(selected) > 0 {
+		for _, action := range m.SelectedStage.Actions {
+			if action.Name == selected[0] {
+				newModel := m.Clone()
+				newModel.SelectedAction = &action
+				newModel.CurrentView = constants.ViewActionDetails
+				view.UpdateTableForView(newModel)
+				return WrapModel(newModel), nil
+			}
+		}
+	}
+	return WrapModel(m), nil
+}
+
+// HandleStopModeSelection handles the selection of how to stop the pipeline execution
+// and prompts for the reason
+func HandleStopModeSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	if selected := m.Table.SelectedRow(); len(selected) > 0 {
+		newModel := m.Clone()
+		switch selected[0] {
+		case "Stop and Wait":
+			newModel.AbandonExecution = false
+		case "Stop and Abandon":
+			newModel.AbandonExecution = true
+		default:
+			return WrapModel(m), nil
+		}
+		newModel.ManualInput = true
+		newModel.TextInput.Focus()
+		newModel.TextInput.Placeholder = constants.MsgEnterStopReason
+		return WrapModel(newModel), nil
+	}
+	return WrapModel(m), nil
+}
+
+// HandleStopReasonSubmission stops the pipeline execution with the entered reason
+func HandleStopReasonSubmission(m *model.Model, reason string) (tea.Model, tea.Cmd) {
+	if strings.TrimSpace(reason) == "" {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorEmptyStopReason)}
+		}
+	}
+
+	newModel := m.Clone()
+	newModel.ManualInput = false
+	newModel.ResetTextInput()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgStoppingExecution
+	return WrapModel(newModel), ExecuteStopPipeline(m, reason)
+}
+
+// ExecuteStopPipeline stops the pipeline execution the selected stage last ran in
+func ExecuteStopPipeline(m *model.Model, reason string) tea.Cmd {
+	return withRequest(m, func(ctx context.Context) tea.Msg {
+		if m.SelectedPipeline == nil {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoPipeline)}
+		}
+		if m.SelectedStage == nil {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoStage)}
+		}
+
+		// Get the provider from the registry
+		provider, err := m.Registry.Get("AWS")
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Get the StopPipelineExecutionOperation from the provider
+		stopOperation, err := provider.GetStopPipelineExecutionOperation()
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Stop the pipeline execution using the operation
+		err = stopOperation.StopPipelineExecution(ctx, m.SelectedPipeline.Name, m.SelectedStage.ExecutionID, m.AbandonExecution, reason)
+		return model.StopExecutionMsg{Err: err}
+	})
+}
+
+// HandleStopExecutionResult handles the result of stopping a pipeline execution
+func HandleStopExecutionResult(m *model.Model, err error) {
+	if err != nil {
+		m.Err = err
+		return
+	}
+
+	if m.SelectedPipeline != nil && m.SelectedStage != nil {
+		message := constants.MsgStopExecutionSuccess
+		if m.AbandonExecution {
+			message = constants.MsgAbandonExecutionSuccess
+		}
+		m.Success = fmt.Sprintf(message, m.SelectedPipeline.Name, m.SelectedStage.ExecutionID)
+	}
+
+	// Reset pipeline state, dropping the cached status the change made stale
+	m.SelectedPipeline = nil
+	resetStageState(m)
+	invalidateCache(m, constants.CachePipelineStatus)
+
+	// Navigate back to the operation selection view
+	m.CurrentView = constants.ViewSelectOperation
+}
+
+// HandleRetryConfirmation handles the confirmation of a stage retry
+func HandleRetryConfirmation(m *model.Model) (tea.Model, tea.Cmd) {
+	if selected := m.Table.SelectedRow(); len(selected) > 0 {
+		newModel := m.Clone()
+		switch selected[0] {
+		case "Confirm":
+			newModel.IsLoading = true
+			newModel.LoadingMsg = constants.MsgRetryingStage
+			return WrapModel(newModel), ExecuteRetryStage(m)
+		case "Cancel":
+			newModel.CurrentView = constants.ViewStageOperations
+			newModel.RetryMode = ""
+			view.UpdateTableForView(newModel)
+			return WrapModel(newModel), nil
+		}
+	}
+	return WrapModel(m), nil
+}
+
+// ExecuteRetryStage retries the selected stage in the pipeline execution it failed in
+func ExecuteRetryStage(m *model.Model) tea.Cmd {
+	return withRequest(m, func(ctx context.Context) tea.Msg {
+		if m.SelectedPipeline == nil {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoPipeline)}
+		}
+		if m.SelectedStage == nil {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoStage)}
+		}
+
+		// Get the provider from the registry
+		provider, err := m.Registry.Get("AWS")
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Get the RetryStageExecutionOperation from the provider
+		retryOperation, err := provider.GetRetryStageExecutionOperation()
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Retry the stage using the operation
+		err = retryOperation.RetryStageExecution(ctx, m.SelectedPipeline.Name, m.SelectedStage.Name, m.SelectedStage.ExecutionID, m.RetryMode)
+		return model.RetryStageMsg{Err: err}
+	})
+}
+
+// HandleRetryStageResult handles the result of retrying a failed stage
+func HandleRetryStageResult(m *model.Model, err error) {
+	if err != nil {
+		m.Err = err
+		return
+	}
+
+	if m.SelectedPipeline != nil && m.SelectedStage != nil {
+		m.Success = fmt.Sprintf(constants.MsgRetryStageSuccess, m.SelectedPipeline.Name, m.SelectedStage.Name)
+	}
+
+	// Reset pipeline state, dropping the cached status the change made stale
+	m.SelectedPipeline = nil
+	resetStageState(m)
+	invalidateCache(m, constants.CachePipelineStatus)
+
+	// Navigate back to the operation selection view
+	m.CurrentView = constants.ViewSelectOperation
+}
+
+// HandleTransitionConfirmation handles the confirmation of a stage transition change.
+// Enabling the transition runs right away, disabling it prompts for the reason first.
+func HandleTransitionConfirmation(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.SelectedStage == nil || m.SelectedStage.InboundTransition == nil {
+		return WrapModel(m), nil
+	}
+
+	if selected := m.Table.SelectedRow(); len(selected) > 0 {
+		newModel := m.Clone()
+		switch selected[0] {
+		case "Confirm":
+			if m.SelectedStage.InboundTransition.Enabled {
+				newModel.ManualInput = true
+				newModel.TextInput.Focus()
+				newModel.TextInput.Placeholder = constants.MsgEnterDisableReason
+				return WrapModel(newModel), nil
+			}
+			newModel.IsLoading = true
+			newModel.LoadingMsg = constants.MsgEnablingTransition
+			return WrapModel(newModel), ExecuteStageTransition(m, true, "")
+		case "Cancel":
+			newModel.CurrentView = constants.ViewStageOperations
+			view.UpdateTableForView(newModel)
+			return WrapModel(newModel), nil
+		}
+	}
+	return WrapModel(m), nil
+}
+
+// HandleDisableReasonSubmission disables the stage transition with the entered reason
+func HandleDisableReasonSubmission(m *model.Model, reason string) (tea.Model, tea.Cmd) {
+	if strings.TrimSpace(reason) == "" {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorEmptyDisableReason)}
+		}
+	}
+
+	newModel := m.Clone()
+	newModel.ManualInput = false
+	newModel.ResetTextInput()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgDisablingTransition
+	return WrapModel(newModel), ExecuteStageTransition(m, false, reason)
+}
+
+// ExecuteStageTransition enables or disables the transition into the selected stage
+func ExecuteStageTransition(m *model.Model, enable bool, reason string) tea.Cmd {
+	return withRequest(m, func(ctx context.Context) tea.Msg {
+		if m.SelectedPipeline == nil {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoPipeline)}
+		}
+		if m.SelectedStage == nil {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoStage)}
+		}
+
+		// Get the provider from the registry
+		provider, err := m.Registry.Get("AWS")
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Get the StageTransitionOperation from the provider
+		transitionOperation, err := provider.GetStageTransitionOperation()
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Change the stage transition using the operation
+		if enable {
+			err = transitionOperation.EnableStageTransition(ctx, m.SelectedPipeline.Name, m.SelectedStage.Name)
+		} else {
+			err = transitionOperation.DisableStageTransition(ctx, m.SelectedPipeline.Name, m.SelectedStage.Name, reason)
+		}
+		return model.StageTransitionMsg{Enabled: enable, Err: err}
+	})
+}
+
+// HandleStageTransitionResult handles the result of enabling or disabling a stage transition
+func HandleStageTransitionResult(m *model.Model, enabled bool, err error) {
+	if err != nil {
+		m.Err = err
+		return
+	}
+
+	if m.SelectedPipeline != nil && m.SelectedStage != nil {
+		message := constants.MsgDisableTransitionSuccess
+		if enabled {
+			message = constants.MsgEnableTransitionSuccess
+		}
+		m.Success = fmt.Sprintf(message, m.SelectedPipeline.Name, m.SelectedStage.Name)
+	}
+
+	// Reset pipeline state, dropping the cached status the change made stale
+	m.SelectedPipeline = nil
+	resetStageState(m)
+	invalidateCache(m, constants.CachePipelineStatus)
+
+	// Navigate back to the operation selection view
+	m.CurrentView = constants.ViewSelectOperation
+}
+
+// resetStageState clears the stage selected in the pipeline stages view
+func resetStageState(m *model.Model) {
+	m.SelectedStage = nil
+	m.SelectedAction = nil
+	m.AbandonExecution = false
+	m.RetryMode = ""
+	m.RollbackTargets = nil
+	m.RollbackToken = ""
+	m.RollbackTarget = nil
+	m.OverrideCondition = ""
+}
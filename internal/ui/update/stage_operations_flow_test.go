@@ -0,0 +1,422 @@
+package update
+
+import (
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+)
+
+// newStagesModel creates a model showing the stages of a pipeline
+func newStagesModel(stages ...cloud.StageStatus) *model.Model {
+	m := model.New()
+	m.SelectedOperation = &model.Operation{Name: "Pipeline Status"}
+	m.SelectedPipeline = &cloud.PipelineStatus{Name: "TestPipeline", Stages: stages}
+	m.CurrentView = constants.ViewPipelineStages
+	view.UpdateTableForView(m)
+	return m
+}
+
+// TestStopExecutionFlow tests stopping the execution running a stage
+func TestStopExecutionFlow(t *testing.T) {
+	m := newStagesModel(
+		cloud.StageStatus{Name: "Source", Status: "Succeeded", ExecutionID: "execution-1"},
+		cloud.StageStatus{Name: "Deploy", Status: "InProgress", ExecutionID: "execution-1"},
+	)
+
+	// Select the running stage
+	m.Table.SetCursor(1)
+	result, _ := HandleStageSelection(m)
+	wrapper := result.(ModelWrapper)
+	if wrapper.Model.CurrentView != constants.ViewStageOperations {
+		t.Fatalf("Expected to be at ViewStageOperations, got %v", wrapper.Model.CurrentView)
+	}
+
+	// Choose to stop the execution and abandon in-progress actions
+	result, _ = HandleStageOperationSelection(wrapper.Model)
+	wrapper = result.(ModelWrapper)
+	if wrapper.Model.CurrentView != constants.ViewStopExecution {
+		t.Fatalf("Expected to be at ViewStopExecution, got %v", wrapper.Model.CurrentView)
+	}
+	wrapper.Model.Table.SetCursor(1)
+	result, _ = HandleStopModeSelection(wrapper.Model)
+	wrapper = result.(ModelWrapper)
+	if !wrapper.Model.AbandonExecution || !wrapper.Model.ManualInput {
+		t.Fatalf("Expected to enter a reason to abandon the execution")
+	}
+
+	// A reason is required
+	result, cmd := HandleTextInputSubmission(wrapper.Model)
+	if cmd == nil {
+		t.Fatalf("Expected an error command for an empty reason")
+	}
+	if _, ok := cmd().(model.ErrMsg); !ok {
+		t.Errorf("Expected an ErrMsg for an empty reason")
+	}
+	if result.(ModelWrapper).Model.IsLoading {
+		t.Errorf("Expected not to stop the execution without a reason")
+	}
+
+	wrapper.Model.TextInput.SetValue("Bad deploy")
+	result, cmd = HandleTextInputSubmission(wrapper.Model)
+	if cmd == nil {
+		t.Errorf("Expected a command stopping the execution")
+	}
+	if !result.(ModelWrapper).Model.IsLoading {
+		t.Errorf("Expected IsLoading to be true")
+	}
+
+	// Navigating back from the stop view returns to the stage operations
+	backResult := NavigateBack(wrapper.Model)
+	if backResult.CurrentView != constants.ViewStageOperations {
+		t.Errorf("Expected to navigate back to ViewStageOperations, got %v", backResult.CurrentView)
+	}
+}
+
+// TestStageSelectionWithoutOperations tests that finished stages offer no operations
+func TestStageSelectionWithoutOperations(t *testing.T) {
+	m := newStagesModel(
+		cloud.StageStatus{Name: "Source", Status: "Succeeded", ExecutionID: "execution-1"},
+	)
+
+	result, _ := HandleStageSelection(m)
+	wrapper := result.(ModelWrapper)
+	if wrapper.Model.CurrentView != constants.ViewPipelineStages {
+		t.Errorf("Expected to stay at ViewPipelineStages, got %v", wrapper.Model.CurrentView)
+	}
+}
+
+// TestPipelineGraphToggle tests switching between the stages table and the pipeline graph
+func TestPipelineGraphToggle(t *testing.T) {
+	m := newStagesModel(
+		cloud.StageStatus{Name: "Source", Status: "Succeeded", ExecutionID: "execution-1"},
+	)
+
+	result, _ := HandlePipelineStagesKey(m, constants.KeyGraph)
+	wrapper := result.(ModelWrapper)
+	if wrapper.Model.CurrentView != constants.ViewPipelineGraph {
+		t.Fatalf("Expected to be at ViewPipelineGraph, got %v", wrapper.Model.CurrentView)
+	}
+
+	// Other keys leave the graph as it is
+	result, _ = HandlePipelineStagesKey(wrapper.Model, constants.KeyMark)
+	if result.(ModelWrapper).Model.CurrentView != constants.ViewPipelineGraph {
+		t.Errorf("Expected to stay at ViewPipelineGraph")
+	}
+
+	result, _ = HandlePipelineStagesKey(wrapper.Model, constants.KeyGraph)
+	if result.(ModelWrapper).Model.CurrentView != constants.ViewPipelineStages {
+		t.Errorf("Expected to switch back to ViewPipelineStages")
+	}
+
+	// Navigating back from the graph returns to the stages with the pipeline kept
+	backResult := NavigateBack(wrapper.Model)
+	if backResult.CurrentView != constants.ViewPipelineStages || backResult.SelectedPipeline == nil {
+		t.Errorf("Expected to navigate back to ViewPipelineStages, got %v", backResult.CurrentView)
+	}
+}
+
+// TestRetryStageFlow tests retrying the failed actions of a failed stage
+func TestRetryStageFlow(t *testing.T) {
+	m := newStagesModel(
+		cloud.StageStatus{Name: "Build", Status: "Failed", ExecutionID: "execution-1"},
+	)
+
+	result, _ := HandleStageSelection(m)
+	wrapper := result.(ModelWrapper)
+	if wrapper.Model.CurrentView != constants.ViewStageOperations {
+		t.Fatalf("Expected to be at ViewStageOperations, got %v", wrapper.Model.CurrentView)
+	}
+	rows := wrapper.Model.Table.Rows()
+	if len(rows) != 2 || rows[0][0] != "Retry Failed Actions" || rows[1][0] != "Retry All Actions" {
+		t.Fatalf("Expected the retry operations for a failed stage, got %v", rows)
+	}
+
+	// Retrying asks for confirmation first
+	result, _ = HandleStageOperationSelection(wrapper.Model)
+	wrapper = result.(ModelWrapper)
+	if wrapper.Model.CurrentView != constants.ViewRetryStage {
+		t.Fatalf("Expected to be at ViewRetryStage, got %v", wrapper.Model.CurrentView)
+	}
+	if wrapper.Model.RetryMode != cloud.RetryModeFailedActions {
+		t.Errorf("Expected retry mode %s, got %s", cloud.RetryModeFailedActions, wrapper.Model.RetryMode)
+	}
+
+	// Cancelling returns to the stage operations
+	wrapper.Model.Table.SetCursor(1)
+	result, _ = HandleRetryConfirmation(wrapper.Model)
+	if cancelled := result.(ModelWrapper).Model; cancelled.CurrentView != constants.ViewStageOperations || cancelled.RetryMode != "" {
+		t.Errorf("Expected cancelling to return to ViewStageOperations, got %v", cancelled.CurrentView)
+	}
+
+	// Confirming retries the stage
+	wrapper.Model.Table.SetCursor(0)
+	result, cmd := HandleRetryConfirmation(wrapper.Model)
+	if cmd == nil {
+		t.Errorf("Expected a command retrying the stage")
+	}
+	if !result.(ModelWrapper).Model.IsLoading {
+		t.Errorf("Expected IsLoading to be true")
+	}
+
+	// A successful retry returns to the operation selection
+	HandleRetryStageResult(wrapper.Model, nil)
+	if wrapper.Model.CurrentView != constants.ViewSelectOperation || wrapper.Model.SelectedStage != nil {
+		t.Errorf("Expected to return to ViewSelectOperation with the stage cleared, got %v", wrapper.Model.CurrentView)
+	}
+}
+
+// TestStageActionsFlow tests drilling down from a stage into the details of its actions
+func TestStageActionsFlow(t *testing.T) {
+	m := newStagesModel(
+		cloud.StageStatus{
+			Name:        "Build",
+			Status:      "Succeeded",
+			ExecutionID: "execution-1",
+			Actions: []cloud.ActionStatus{
+				{Name: "Compile", Status: "Succeeded", Provider: "CodeBuild", LastStatusChange: "N/A"},
+				{Name: "Test", Status: "Failed", Provider: "CodeBuild", ErrorCode: "JobFailed", ErrorMessage: "Tests failed"},
+			},
+		},
+	)
+
+	result, _ := HandleStageSelection(m)
+	wrapper := result.(ModelWrapper)
+	rows := wrapper.Model.Table.Rows()
+	if len(rows) != 1 || rows[0][0] != "View Actions" {
+		t.Fatalf("Expected only the View Actions operation for a finished stage, got %v", rows)
+	}
+
+	result, _ = HandleStageOperationSelection(wrapper.Model)
+	wrapper = result.(ModelWrapper)
+	if wrapper.Model.CurrentView != constants.ViewStageActions {
+		t.Fatalf("Expected to be at ViewStageActions, got %v", wrapper.Model.CurrentView)
+	}
+	if rows := wrapper.Model.Table.Rows(); len(rows) != 2 || rows[1][0] != "Test" || rows[1][1] != "Failed" {
+		t.Fatalf("Expected a row per action, got %v", rows)
+	}
+
+	// Selecting an action shows its details
+	wrapper.Model.Table.SetCursor(1)
+	result, _ = HandleActionSelection(wrapper.Model)
+	wrapper = result.(ModelWrapper)
+	if wrapper.Model.CurrentView != constants.ViewActionDetails {
+		t.Fatalf("Expected to be at ViewActionDetails, got %v", wrapper.Model.CurrentView)
+	}
+	if wrapper.Model.SelectedAction == nil || wrapper.Model.SelectedAction.Name != "Test" {
+		t.Fatalf("Expected the Test action to be selected, got %v", wrapper.Model.SelectedAction)
+	}
+	details := make(map[string]string)
+	for _, row := range wrapper.Model.Table.Rows() {
+		details[row[0]] = row[1]
+	}
+	if details["Error Code"] != "JobFailed" || details["Error Message"] != "Tests failed" {
+		t.Errorf("Expected the action error in the details, got %v", details)
+	}
+	if details["External Execution ID"] != "-" {
+		t.Errorf("Expected a dash for the missing external execution ID, got %s", details["External Execution ID"])
+	}
+
+	// Navigating back returns to the stage actions
+	backResult := NavigateBack(wrapper.Model)
+	if backResult.CurrentView != constants.ViewStageActions || backResult.SelectedAction != nil {
+		t.Errorf("Expected to navigate back to ViewStageActions with the action cleared, got %v", backResult.CurrentView)
+	}
+}
+
+// TestStageTransitionFlow tests disabling the transition into a stage
+func TestStageTransitionFlow(t *testing.T) {
+	m := newStagesModel(
+		cloud.StageStatus{Name: "Source", Status: "Succeeded", ExecutionID: "execution-1"},
+		cloud.StageStatus{Name: "Deploy", Status: "Succeeded", ExecutionID: "execution-1", InboundTransition: &cloud.TransitionState{Enabled: true}},
+	)
+	if rows := m.Table.Rows(); rows[0][3] != "-" || rows[1][3] != "Enabled" {
+		t.Fatalf("Expected the transition state of each stage, got %v", rows)
+	}
+
+	m.Table.SetCursor(1)
+	result, _ := HandleStageSelection(m)
+	wrapper := result.(ModelWrapper)
+	rows := wrapper.Model.Table.Rows()
+	if len(rows) != 2 || rows[1][0] != "Disable Transition" {
+		t.Fatalf("Expected the Disable Transition operation, got %v", rows)
+	}
+
+	wrapper.Model.Table.SetCursor(1)
+	result, _ = HandleStageOperationSelection(wrapper.Model)
+	wrapper = result.(ModelWrapper)
+	if wrapper.Model.CurrentView != constants.ViewStageTransition {
+		t.Fatalf("Expected to be at ViewStageTransition, got %v", wrapper.Model.CurrentView)
+	}
+
+	// Confirming prompts for the reason
+	result, _ = HandleTransitionConfirmation(wrapper.Model)
+	wrapper = result.(ModelWrapper)
+	if !wrapper.Model.ManualInput {
+		t.Fatalf("Expected to enter a reason to disable the transition")
+	}
+
+	// A reason is required
+	_, cmd := HandleTextInputSubmission(wrapper.Model)
+	if msg, ok := cmd().(model.ErrMsg); !ok || msg.Err.Error() != constants.MsgErrorEmptyDisableReason {
+		t.Errorf("Expected an error for the empty reason, got %v", msg)
+	}
+
+	wrapper.Model.TextInput.SetValue("Release freeze")
+	result, cmd = HandleTextInputSubmission(wrapper.Model)
+	if cmd == nil || !result.(ModelWrapper).Model.IsLoading {
+		t.Fatalf("Expected a command disabling the transition")
+	}
+
+	// A successful change returns to the operation selection
+	HandleStageTransitionResult(wrapper.Model, false, nil)
+	if wrapper.Model.CurrentView != constants.ViewSelectOperation || wrapper.Model.Success == "" {
+		t.Errorf("Expected to return to ViewSelectOperation with a success message, got %v", wrapper.Model.CurrentView)
+	}
+}
+
+// TestEnableStageTransition tests enabling a disabled transition without a reason
+func TestEnableStageTransition(t *testing.T) {
+	m := newStagesModel(
+		cloud.StageStatus{Name: "Deploy", Status: "Succeeded", InboundTransition: &cloud.TransitionState{Enabled: false, DisabledReason: "Release freeze"}},
+	)
+	m.SelectedStage = &m.SelectedPipeline.Stages[0]
+	m.CurrentView = constants.ViewStageTransition
+	view.UpdateTableForView(m)
+
+	result, cmd := HandleTransitionConfirmation(m)
+	if cmd == nil || !result.(ModelWrapper).Model.IsLoading {
+		t.Errorf("Expected a command enabling the transition")
+	}
+	if result.(ModelWrapper).Model.ManualInput {
+		t.Errorf("Expected no reason to be asked for")
+	}
+}
+
+// TestRollbackStageFlow tests rolling a stage back to a previous successful execution
+func TestRollbackStageFlow(t *testing.T) {
+	m := newStagesModel(
+		cloud.StageStatus{Name: "Source", Status: "Succeeded", ExecutionID: "execution-3"},
+		cloud.StageStatus{Name: "Deploy", Status: "Failed", ExecutionID: "execution-3"},
+	)
+
+	m.Table.SetCursor(1)
+	result, _ := HandleStageSelection(m)
+	wrapper := result.(ModelWrapper)
+	rows := wrapper.Model.Table.Rows()
+	if len(rows) != 3 || rows[2][0] != "Rollback Stage" {
+		t.Fatalf("Expected the Rollback Stage operation, got %v", rows)
+	}
+
+	// The execution the stage last ran is not offered as a target
+	HandleRollbackTargetsLoaded(wrapper.Model, model.RollbackTargetsMsg{
+		Executions: []cloud.PipelineExecution{
+			{ExecutionID: "execution-3", Status: "Failed"},
+			{ExecutionID: "execution-2", Status: "Succeeded"},
+		},
+		NextToken: "page-2",
+	})
+	if wrapper.Model.CurrentView != constants.ViewRollbackTargets {
+		t.Fatalf("Expected to be at ViewRollbackTargets, got %v", wrapper.Model.CurrentView)
+	}
+	if rows := wrapper.Model.Table.Rows(); len(rows) != 2 || rows[0][0] != "execution-2" || rows[1][0] != "Load More" {
+		t.Fatalf("Expected the earlier executions as targets and the next page, got %v", rows)
+	}
+
+	// Older targets are loaded on demand
+	wrapper.Model.Table.SetCursor(1)
+	result, cmd := HandleRollbackTargetSelection(wrapper.Model)
+	if cmd == nil || !result.(ModelWrapper).Model.IsLoading {
+		t.Fatalf("Expected a command loading the next page of targets")
+	}
+
+	HandleRollbackTargetsLoaded(wrapper.Model, model.RollbackTargetsMsg{
+		Executions: []cloud.PipelineExecution{{ExecutionID: "execution-1", Status: "Succeeded"}},
+		Append:     true,
+	})
+	if rows := wrapper.Model.Table.Rows(); len(rows) != 2 || rows[1][0] != "execution-1" || wrapper.Model.Table.Cursor() != 1 {
+		t.Fatalf("Expected the next page appended without Load More, got %v", rows)
+	}
+
+	result, _ = HandleRollbackTargetSelection(wrapper.Model)
+	wrapper = result.(ModelWrapper)
+	if wrapper.Model.CurrentView != constants.ViewRollbackStage || wrapper.Model.RollbackTarget.ExecutionID != "execution-1" {
+		t.Fatalf("Expected to confirm the rollback to execution-1, got %v", wrapper.Model.CurrentView)
+	}
+
+	// Confirming asks for the stage name
+	result, _ = HandleGuardedConfirmation(wrapper.Model)
+	wrapper = result.(ModelWrapper)
+	if !wrapper.Model.ManualInput {
+		t.Fatalf("Expected to type the stage name to confirm the rollback")
+	}
+
+	wrapper.Model.TextInput.SetValue("Source")
+	result, cmd = HandleTextInputSubmission(wrapper.Model)
+	if msg, ok := cmd().(model.ErrMsg); !ok || result.(ModelWrapper).Model.IsLoading {
+		t.Errorf("Expected an error for a mismatching stage name, got %v", msg)
+	}
+
+	wrapper.Model.TextInput.SetValue("Deploy")
+	result, cmd = HandleTextInputSubmission(wrapper.Model)
+	if cmd == nil || !result.(ModelWrapper).Model.IsLoading {
+		t.Fatalf("Expected a command rolling back the stage")
+	}
+
+	// Navigating back returns to the rollback targets
+	backResult := NavigateBack(wrapper.Model)
+	if backResult.CurrentView != constants.ViewRollbackTargets || backResult.RollbackTarget != nil {
+		t.Errorf("Expected to navigate back to ViewRollbackTargets, got %v", backResult.CurrentView)
+	}
+
+	HandleRollbackStageResult(wrapper.Model, "execution-4", nil)
+	if wrapper.Model.CurrentView != constants.ViewSelectOperation || wrapper.Model.RollbackTargets != nil {
+		t.Errorf("Expected to return to ViewSelectOperation with the targets cleared, got %v", wrapper.Model.CurrentView)
+	}
+}
+
+// TestOverrideConditionFlow tests overriding the failed condition of a stage
+func TestOverrideConditionFlow(t *testing.T) {
+	m := newStagesModel(
+		cloud.StageStatus{
+			Name:        "Source",
+			Status:      "Succeeded",
+			ExecutionID: "execution-1",
+			Conditions: []cloud.ConditionStatus{
+				{Type: cloud.ConditionTypeBeforeEntry, Status: "Succeeded"},
+				{Type: cloud.ConditionTypeOnSuccess, Status: "Failed", Summary: "Alarm in ALARM state"},
+				{Type: cloud.ConditionTypeOnFailure, Status: "Failed"},
+			},
+		},
+	)
+
+	result, _ := HandleStageSelection(m)
+	wrapper := result.(ModelWrapper)
+	rows := wrapper.Model.Table.Rows()
+	if len(rows) != 1 || rows[0][0] != "Override On Success Condition" {
+		t.Fatalf("Expected only the failed on-success condition to be overridable, got %v", rows)
+	}
+
+	result, _ = HandleStageOperationSelection(wrapper.Model)
+	wrapper = result.(ModelWrapper)
+	if wrapper.Model.CurrentView != constants.ViewOverrideCondition || wrapper.Model.OverrideCondition != cloud.ConditionTypeOnSuccess {
+		t.Fatalf("Expected to confirm the on-success override, got %v", wrapper.Model.CurrentView)
+	}
+
+	// Cancelling returns to the stage operations
+	wrapper.Model.Table.SetCursor(1)
+	result, _ = HandleGuardedConfirmation(wrapper.Model)
+	if cancelled := result.(ModelWrapper).Model; cancelled.CurrentView != constants.ViewStageOperations || cancelled.OverrideCondition != "" {
+		t.Errorf("Expected cancelling to return to ViewStageOperations, got %v", cancelled.CurrentView)
+	}
+
+	wrapper.Model.Table.SetCursor(0)
+	result, _ = HandleGuardedConfirmation(wrapper.Model)
+	wrapper = result.(ModelWrapper)
+	result, cmd := HandleStageNameConfirmation(wrapper.Model, "Source")
+	if cmd == nil || result.(ModelWrapper).Model.LoadingMsg != constants.MsgOverridingCondition {
+		t.Errorf("Expected a command overriding the condition")
+	}
+}
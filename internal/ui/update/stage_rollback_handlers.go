@@ -0,0 +1,272 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HandleRollbackStage loads the executions the selected stage can be rolled back to
+func HandleRollbackStage(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.SelectedPipeline == nil || m.SelectedStage == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoStage)}
+		}
+	}
+
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgLoadingRollbackTargets
+
+	return WrapModel(newModel), loadRollbackTargets(m, m.SelectedPipeline.Name, m.SelectedStage.Name, "")
+}
+
+// HandleRollbackTargetsLoaded handles a page of executions loaded for the stage rollback,
+// leaving out the execution the stage already ran last
+func HandleRollbackTargetsLoaded(m *model.Model, msg model.RollbackTargetsMsg) {
+	if m.SelectedStage == nil {
+		return
+	}
+
+	previousCount := 0
+	var targets []cloud.PipelineExecution
+	if msg.Append {
+		// Copy on append so clones of the model are not affected
+		previousCount = len(m.RollbackTargets)
+		targets = m.RollbackTargets[:previousCount:previousCount]
+	}
+	for _, execution := range msg.Executions {
+		if execution.ExecutionID != m.SelectedStage.ExecutionID {
+			targets = append(targets, execution)
+		}
+	}
+
+	if len(targets) == 0 && msg.NextToken == "" {
+		m.Err = fmt.Errorf(constants.MsgErrorNoRollbackTargets, m.SelectedStage.Name)
+		return
+	}
+
+	m.RollbackTargets = targets
+	m.RollbackToken = msg.NextToken
+	m.CurrentView = constants.ViewRollbackTargets
+	view.UpdateTableForView(m)
+
+	// Keep the cursor on the first target of the new page
+	m.Table.SetCursor(previousCount)
+}
+
+// HandleRollbackTargetSelection handles the selection of the execution to roll the stage
+// back to or of the next page
+func HandleRollbackTargetSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	if selected := m.Table.SelectedRow(); len(selected) > 0 {
+		if selected[0] == "Load More" {
+			if m.SelectedPipeline == nil || m.SelectedStage == nil || m.RollbackToken == "" {
+				return WrapModel(m), nil
+			}
+
+			newModel := m.Clone()
+			newModel.IsLoading = true
+			newModel.LoadingMsg = constants.MsgLoadingRollbackTargets
+			return WrapModel(newModel), loadRollbackTargets(m, m.SelectedPipeline.Name, m.SelectedStage.Name, m.RollbackToken)
+		}
+
+		for _, execution := range m.RollbackTargets {
+			if execution.ExecutionID == selected[0] {
+				newModel := m.Clone()
+				newModel.RollbackTarget = &execution
+				newModel.CurrentView = constants.ViewRollbackStage
+				view.UpdateTableForView(newModel)
+				return WrapModel(newModel), nil
+			}
+		}
+	}
+	return WrapModel(m), nil
+}
+
+// loadRollbackTargets returns a command loading a page of the executions a stage can be rolled back to
+func loadRollbackTargets(m *model.Model, pipelineName, stageName, nextToken string) tea.Cmd {
+	return withRequest(m, func(ctx context.Context) tea.Msg {
+		// Get the provider from the registry
+		provider, err := m.Registry.Get("AWS")
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Get the RollbackStageOperation from the provider
+		rollbackOperation, err := provider.GetRollbackStageOperation()
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Get the rollback targets using the operation
+		executions, token, err := rollbackOperation.GetRollbackTargets(ctx, pipelineName, stageName, nextToken)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.RollbackTargetsMsg{
+			Executions: executions,
+			NextToken:  token,
+			Append:     nextToken != "",
+		}
+	})
+}
+
+// HandleGuardedConfirmation handles the confirmation of a stage rollback or condition
+// override. Confirming asks for the stage name before anything is changed.
+func HandleGuardedConfirmation(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.SelectedStage == nil {
+		return WrapModel(m), nil
+	}
+
+	if selected := m.Table.SelectedRow(); len(selected) > 0 {
+		newModel := m.Clone()
+		switch selected[0] {
+		case "Confirm":
+			newModel.ManualInput = true
+			newModel.TextInput.Focus()
+			newModel.TextInput.Placeholder = fmt.Sprintf(constants.MsgEnterStageNameConfirm, m.SelectedStage.Name)
+			return WrapModel(newModel), nil
+		case "Cancel":
+			newModel.CurrentView = constants.ViewStageOperations
+			newModel.RollbackTargets = nil
+			newModel.RollbackToken = ""
+			newModel.RollbackTarget = nil
+			newModel.OverrideCondition = ""
+			view.UpdateTableForView(newModel)
+			return WrapModel(newModel), nil
+		}
+	}
+	return WrapModel(m), nil
+}
+
+// HandleStageNameConfirmation rolls back the stage or overrides its condition once the
+// entered name matches the selected stage
+func HandleStageNameConfirmation(m *model.Model, stageName string) (tea.Model, tea.Cmd) {
+	if m.SelectedStage == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoStage)}
+		}
+	}
+	if strings.TrimSpace(stageName) != m.SelectedStage.Name {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorStageNameMismatch, m.SelectedStage.Name)}
+		}
+	}
+
+	newModel := m.Clone()
+	newModel.ManualInput = false
+	newModel.ResetTextInput()
+	newModel.IsLoading = true
+	if m.CurrentView == constants.ViewOverrideCondition {
+		newModel.LoadingMsg = constants.MsgOverridingCondition
+		return WrapModel(newModel), ExecuteOverrideCondition(m)
+	}
+	newModel.LoadingMsg = constants.MsgRollingBackStage
+	return WrapModel(newModel), ExecuteRollbackStage(m)
+}
+
+// ExecuteRollbackStage rolls the selected stage back to the selected execution
+func ExecuteRollbackStage(m *model.Model) tea.Cmd {
+	return withRequest(m, func(ctx context.Context) tea.Msg {
+		if m.SelectedPipeline == nil {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoPipeline)}
+		}
+		if m.SelectedStage == nil {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoStage)}
+		}
+		if m.RollbackTarget == nil {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoExecution)}
+		}
+
+		// Get the provider from the registry
+		provider, err := m.Registry.Get("AWS")
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Get the RollbackStageOperation from the provider
+		rollbackOperation, err := provider.GetRollbackStageOperation()
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Roll the stage back using the operation
+		executionID, err := rollbackOperation.RollbackStage(ctx, m.SelectedPipeline.Name, m.SelectedStage.Name, m.RollbackTarget.ExecutionID)
+		return model.RollbackStageMsg{ExecutionID: executionID, Err: err}
+	})
+}
+
+// HandleRollbackStageResult handles the result of rolling back a stage
+func HandleRollbackStageResult(m *model.Model, executionID string, err error) {
+	if err != nil {
+		m.Err = err
+		return
+	}
+
+	if m.SelectedPipeline != nil && m.SelectedStage != nil {
+		m.Success = fmt.Sprintf(constants.MsgRollbackStageSuccess, m.SelectedPipeline.Name, m.SelectedStage.Name, executionID)
+	}
+
+	// Reset pipeline state, dropping the cached status the change made stale
+	m.SelectedPipeline = nil
+	resetStageState(m)
+	invalidateCache(m, constants.CachePipelineStatus)
+
+	// Navigate back to the operation selection view
+	m.CurrentView = constants.ViewSelectOperation
+}
+
+// ExecuteOverrideCondition overrides the selected failed condition of the selected stage
+func ExecuteOverrideCondition(m *model.Model) tea.Cmd {
+	return withRequest(m, func(ctx context.Context) tea.Msg {
+		if m.SelectedPipeline == nil {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoPipeline)}
+		}
+		if m.SelectedStage == nil {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoStage)}
+		}
+
+		// Get the provider from the registry
+		provider, err := m.Registry.Get("AWS")
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Get the OverrideStageConditionOperation from the provider
+		overrideOperation, err := provider.GetOverrideStageConditionOperation()
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Override the condition using the operation
+		err = overrideOperation.OverrideStageCondition(ctx, m.SelectedPipeline.Name, m.SelectedStage.Name, m.SelectedStage.ExecutionID, m.OverrideCondition)
+		return model.OverrideConditionMsg{Err: err}
+	})
+}
+
+// HandleOverrideConditionResult handles the result of overriding a stage condition
+func HandleOverrideConditionResult(m *model.Model, err error) {
+	if err != nil {
+		m.Err = err
+		return
+	}
+
+	if m.SelectedPipeline != nil && m.SelectedStage != nil {
+		m.Success = fmt.Sprintf(constants.MsgOverrideConditionSuccess, m.SelectedPipeline.Name, m.SelectedStage.Name)
+	}
+
+	// Reset pipeline state, dropping the cached status the change made stale
+	m.SelectedPipeline = nil
+	resetStageState(m)
+	invalidateCache(m, constants.CachePipelineStatus)
+
+	// Navigate back to the operation selection view
+	m.CurrentView = constants.ViewSelectOperation
+}
@@ -0,0 +1,197 @@
+package view
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+)
+
+// Connectors drawn between the stages of the pipeline graph
+const (
+	graphStageArrow        = " ──▶ "
+	graphDisabledArrow     = " ─╳▶ "
+	graphStageArrowPadding = "\n\n" // Aligns the arrow with the first row of actions
+)
+
+// graphJunctions maps the directions a junction of the action graph connects, in the
+// order left, right, up, down, to its box-drawing character
+var graphJunctions = map[[4]bool]string{
+	{true, true, false, false}: "─",
+	{true, true, false, true}:  "┬",
+	{true, true, true, false}:  "┴",
+	{true, true, true, true}:   "┼",
+	{true, false, true, true}:  "┤",
+	{false, true, true, true}:  "├",
+	{true, false, true, false}: "┘",
+	{false, true, true, false}: "└",
+	{true, false, false, true}: "┐",
+	{false, true, false, true}: "┌",
+	{false, false, true, true}: "│",
+}
+
+// RenderPipelineGraph renders the stages of a pipeline from left to right, each stage
+// showing its actions grouped by run order with parallel actions stacked, wrapping the
+// stages onto more lines when they do not fit in the given width
+func RenderPipelineGraph(pipeline *cloud.PipelineStatus, width int) string {
+	if pipeline == nil || len(pipeline.Stages) == 0 {
+		return ""
+	}
+
+	var lines []string
+	var line []string
+	lineWidth := 0
+	for i, stage := range pipeline.Stages {
+		block := renderGraphStage(stage)
+		if i > 0 {
+			block = lipgloss.JoinHorizontal(lipgloss.Top, renderGraphStageArrow(stage), block)
+		}
+		blockWidth := lipgloss.Width(block)
+		if len(line) > 0 && lineWidth+blockWidth > width {
+			lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Top, line...))
+			line, lineWidth = nil, 0
+		}
+		line = append(line, block)
+		lineWidth += blockWidth
+	}
+	lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Top, line...))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// renderGraphStageArrow renders the arrow into a stage, crossed out when the inbound
+// transition of the stage is disabled
+func renderGraphStageArrow(stage cloud.StageStatus) string {
+	if stage.InboundTransition != nil && !stage.InboundTransition.Enabled {
+		return graphStageArrowPadding + lipgloss.NewStyle().Foreground(lipgloss.Color(constants.ColorError)).Render(graphDisabledArrow)
+	}
+	return graphStageArrowPadding + graphStageArrow
+}
+
+// renderGraphStage renders a stage as a box holding the stage name and its action graph
+func renderGraphStage(stage cloud.StageStatus) string {
+	color := lipgloss.Color(getGraphStatusColor(stage.Status))
+	title := lipgloss.NewStyle().Bold(true).Foreground(color).Render(stage.Name + " " + getGraphStatusSymbol(stage.Status))
+
+	body := lipgloss.NewStyle().Foreground(lipgloss.Color(constants.ColorSubtle)).Render("No actions")
+	if len(stage.Actions) > 0 {
+		body = strings.Join(getGraphActionRows(stage.Actions), "\n")
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(color).
+		Padding(0, 1).
+		Render(lipgloss.JoinVertical(lipgloss.Left, title, body))
+}
+
+// getGraphActionRows lays out the run order groups of a stage's actions from left to
+// right, joining consecutive groups with connectors that fan out and in
+func getGraphActionRows(actions []cloud.ActionStatus) []string {
+	groups := groupActionsByRunOrder(actions)
+
+	height := 0
+	widths := make([]int, len(groups))
+	for i, group := range groups {
+		height = max(height, len(group))
+		for _, action := range group {
+			widths[i] = max(widths[i], lipgloss.Width(getGraphActionLabel(action)))
+		}
+	}
+
+	rows := make([]string, height)
+	for row := range rows {
+		var b strings.Builder
+		for i, group := range groups {
+			if i > 0 {
+				b.WriteString(getGraphConnector(len(groups[i-1]), len(group), row))
+			}
+			label := ""
+			if row < len(group) {
+				label = getGraphActionLabel(group[row])
+				b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(getGraphStatusColor(group[row].Status))).Render(label))
+			}
+			b.WriteString(strings.Repeat(" ", widths[i]-lipgloss.Width(label)))
+		}
+		rows[row] = strings.TrimRight(b.String(), " ")
+	}
+	return rows
+}
+
+// groupActionsByRunOrder groups actions that run in parallel, ordered by run order and
+// keeping the declared order of the actions within each group
+func groupActionsByRunOrder(actions []cloud.ActionStatus) [][]cloud.ActionStatus {
+	sorted := make([]cloud.ActionStatus, len(actions))
+	copy(sorted, actions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].RunOrder < sorted[j].RunOrder
+	})
+
+	var groups [][]cloud.ActionStatus
+	for i, action := range sorted {
+		if i == 0 || action.RunOrder != sorted[i-1].RunOrder {
+			groups = append(groups, nil)
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], action)
+	}
+	return groups
+}
+
+// getGraphConnector returns one row of the connector from a group of left actions to a
+// group of right actions
+func getGraphConnector(left, right, row int) string {
+	last := max(left, right) - 1
+	if row > last {
+		return strings.Repeat(" ", 6)
+	}
+
+	directions := [4]bool{row < left, row < right, row > 0, row < last}
+	in, out := " ", "  "
+	if directions[0] {
+		in = "─"
+	}
+	if directions[1] {
+		out = "─▶"
+	}
+	return " " + in + graphJunctions[directions] + out + " "
+}
+
+// getGraphActionLabel returns the label of an action in the pipeline graph
+func getGraphActionLabel(action cloud.ActionStatus) string {
+	return getGraphStatusSymbol(action.Status) + " " + action.Name
+}
+
+// getGraphStatusSymbol returns the symbol of a stage or action status
+func getGraphStatusSymbol(status string) string {
+	switch status {
+	case "Succeeded":
+		return "✓"
+	case "Failed":
+		return "✗"
+	case "InProgress":
+		return "●"
+	case "Stopped", "Stopping", "Abandoned":
+		return "■"
+	default:
+		return "○"
+	}
+}
+
+// getGraphStatusColor returns the color of a stage or action status
+func getGraphStatusColor(status string) string {
+	switch status {
+	case "Succeeded":
+		return constants.ColorSuccess
+	case "Failed":
+		return constants.ColorError
+	case "InProgress":
+		return constants.ColorInfo
+	case "Stopped", "Stopping", "Abandoned":
+		return constants.ColorWarning
+	default:
+		return constants.ColorSubtle
+	}
+}
@@ -0,0 +1,84 @@
+package view
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+)
+
+func TestRenderPipelineGraph(t *testing.T) {
+	pipeline := &cloud.PipelineStatus{
+		Name: "TestPipeline",
+		Stages: []cloud.StageStatus{
+			{
+				Name:    "Source",
+				Status:  "Succeeded",
+				Actions: []cloud.ActionStatus{{Name: "Checkout", Status: "Succeeded", RunOrder: 1}},
+			},
+			{
+				Name:              "Build",
+				Status:            "InProgress",
+				InboundTransition: &cloud.TransitionState{Enabled: true},
+				Actions: []cloud.ActionStatus{
+					{Name: "Package", Status: "Unknown", RunOrder: 3},
+					{Name: "UnitTests", Status: "InProgress", RunOrder: 2},
+					{Name: "Compile", Status: "Succeeded", RunOrder: 1},
+					{Name: "Lint", Status: "Failed", RunOrder: 2},
+				},
+			},
+			{
+				Name:              "Deploy",
+				Status:            "Unknown",
+				InboundTransition: &cloud.TransitionState{Enabled: false},
+			},
+		},
+	}
+
+	graph := RenderPipelineGraph(pipeline, 200)
+	lines := strings.Split(graph, "\n")
+	if len(lines) != 5 {
+		t.Fatalf("Expected the stages on one line of boxes, got\n%s", graph)
+	}
+
+	// Parallel actions fan out from and back into the actions before and after them
+	if !strings.Contains(lines[2], "✓ Compile ─┬─▶ ● UnitTests ─┬─▶ ○ Package") {
+		t.Errorf("Expected the actions in run order with a fan-out, got\n%s", graph)
+	}
+	if !strings.Contains(lines[3], "└─▶ ✗ Lint      ─┘") {
+		t.Errorf("Expected the parallel action below with a fan-in, got\n%s", graph)
+	}
+
+	// The arrow into a stage with a disabled transition is crossed out
+	if !strings.Contains(lines[2], "│ ──▶ │") || !strings.Contains(lines[2], "│ ─╳▶ │") {
+		t.Errorf("Expected an enabled and a disabled transition, got\n%s", graph)
+	}
+	if !strings.Contains(graph, "No actions") {
+		t.Errorf("Expected the stage without actions to say so, got\n%s", graph)
+	}
+
+	// Stages that do not fit the width wrap onto the next line
+	narrow := RenderPipelineGraph(pipeline, 60)
+	if lines := strings.Split(narrow, "\n"); len(lines) <= 5 || !strings.Contains(narrow, "Deploy ○") {
+		t.Errorf("Expected the stages to wrap, got\n%s", narrow)
+	}
+}
+
+func TestGetGraphConnector(t *testing.T) {
+	testCases := []struct {
+		left, right int
+		expected    []string
+	}{
+		{1, 1, []string{" ───▶ "}},
+		{1, 2, []string{" ─┬─▶ ", "  └─▶ "}},
+		{3, 1, []string{" ─┬─▶ ", " ─┤   ", " ─┘   "}},
+		{2, 2, []string{" ─┬─▶ ", " ─┴─▶ "}},
+	}
+	for _, tc := range testCases {
+		for row, expected := range tc.expected {
+			if got := getGraphConnector(tc.left, tc.right, row); got != expected {
+				t.Errorf("getGraphConnector(%d, %d, %d) = %q, expected %q", tc.left, tc.right, row, got, expected)
+			}
+		}
+	}
+}
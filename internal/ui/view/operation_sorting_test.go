@@ -97,6 +97,10 @@ func (p *MockProvider) GetProfiles() ([]string, error) {
 	return []string{}, nil
 }
 
+func (p *MockProvider) GetProfileDetails() ([]cloud.ProfileDetails, error) {
+	return []cloud.ProfileDetails{}, nil
+}
+
 func (p *MockProvider) LoadConfig(profile, region string) error {
 	return nil
 }
@@ -117,6 +121,42 @@ func (p *MockProvider) GetStartPipelineOperation() (cloud.StartPipelineOperation
 	return nil, nil
 }
 
+func (p *MockProvider) GetExecutionHistoryOperation() (cloud.ExecutionHistoryOperation, error) {
+	return nil, nil
+}
+
+func (p *MockProvider) GetStopPipelineExecutionOperation() (cloud.StopPipelineExecutionOperation, error) {
+	return nil, nil
+}
+
+func (p *MockProvider) GetRetryStageExecutionOperation() (cloud.RetryStageExecutionOperation, error) {
+	return nil, nil
+}
+
+func (p *MockProvider) GetApprovalsInboxOperation() (cloud.ApprovalsInboxOperation, error) {
+	return nil, nil
+}
+
+func (p *MockProvider) GetStageTransitionOperation() (cloud.StageTransitionOperation, error) {
+	return nil, nil
+}
+
+func (p *MockProvider) GetRollbackStageOperation() (cloud.RollbackStageOperation, error) {
+	return nil, nil
+}
+
+func (p *MockProvider) GetOverrideStageConditionOperation() (cloud.OverrideStageConditionOperation, error) {
+	return nil, nil
+}
+
+func (p *MockProvider) GetPipelineDefinitionOperation() (cloud.PipelineDefinitionOperation, error) {
+	return nil, nil
+}
+
+func (p *MockProvider) GetExecutionWatchOperation() (cloud.ExecutionWatchOperation, error) {
+	return nil, nil
+}
+
 func (p *MockProvider) GetAuthenticationMethods() []string {
 	return []string{}
 }
@@ -125,7 +165,7 @@ func (p *MockProvider) GetAuthConfigKeys(method string) []string {
 	return []string{}
 }
 
-func (p *MockProvider) Authenticate(method string, authConfig map[string]string) error {
+func (p *MockProvider) Authenticate(ctx context.Context, method string, authConfig map[string]string) error {
 	return nil
 }
 
@@ -133,6 +173,10 @@ func (p *MockProvider) IsAuthenticated() bool {
 	return true
 }
 
+func (p *MockProvider) GetCallerIdentity(ctx context.Context) (*cloud.CallerIdentity, error) {
+	return &cloud.CallerIdentity{}, nil
+}
+
 func (p *MockProvider) GetConfigKeys() []string {
 	return []string{}
 }
@@ -157,7 +201,7 @@ func (p *MockProvider) GetStatus(ctx context.Context) ([]cloud.PipelineStatus, e
 	return []cloud.PipelineStatus{}, nil
 }
 
-func (p *MockProvider) StartPipeline(ctx context.Context, pipelineName string, commitID string) error {
+func (p *MockProvider) StartPipeline(ctx context.Context, pipelineName string, sourceRevisions []cloud.SourceRevision, variables []cloud.PipelineVariable) error {
 	return nil
 }
 
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/table"
 
@@ -20,7 +21,7 @@ func UpdateTableForView(m *model.Model) {
 
 	// Set the table height based on the current view
 	tableHeight := constants.TableHeight
-	if m.CurrentView == constants.ViewPipelineStages {
+	if m.CurrentView == constants.ViewPipelineStages || m.CurrentView == constants.ViewExecutionWatch {
 		tableHeight = constants.TableHeightLarge
 	}
 
@@ -50,7 +51,8 @@ func getColumnsForView(m *model.Model) []table.Column {
 		}
 	case constants.ViewAuthConfig:
 		return []table.Column{
-			{Title: m.ProviderState.AuthState.CurrentAuthConfigKey, Width: constants.TableDefaultWidth},
+			{Title: "Setting", Width: constants.TableDefaultWidth},
+			{Title: "Value", Width: constants.TableDescWidth},
 		}
 	case constants.ViewProviderConfig:
 		return []table.Column{
@@ -58,7 +60,12 @@ func getColumnsForView(m *model.Model) []table.Column {
 		}
 	case constants.ViewAWSConfig:
 		if m.AwsProfile == "" {
-			return []table.Column{{Title: "Profile", Width: constants.TableDefaultWidth}}
+			return []table.Column{
+				{Title: "Profile", Width: constants.TableNarrowWidth},
+				{Title: "Account", Width: constants.TableShortWidth},
+				{Title: "Role", Width: constants.TableNarrowWidth},
+				{Title: "Auth", Width: constants.TableNarrowWidth},
+			}
 		}
 		return []table.Column{{Title: "Region", Width: constants.TableDefaultWidth}}
 	case constants.ViewSelectService:
@@ -77,10 +84,53 @@ func getColumnsForView(m *model.Model) []table.Column {
 			{Title: "Description", Width: constants.TableDescWidth},
 		}
 	case constants.ViewApprovals:
+		if m.SelectedOperation != nil && m.SelectedOperation.Name == "Approvals Inbox" {
+			return []table.Column{
+				{Title: "Account", Width: constants.TableShortWidth},
+				{Title: "Region", Width: constants.TableShortWidth},
+				{Title: "Pipeline", Width: constants.TableNarrowWidth},
+				{Title: "Stage", Width: constants.TableShortWidth},
+				{Title: "Action", Width: constants.TableShortWidth},
+				{Title: "✓", Width: constants.TableMarkWidth},
+			}
+		}
 		return []table.Column{
 			{Title: "Pipeline", Width: constants.TableWideWidth},
 			{Title: "Stage", Width: constants.TableDefaultWidth},
 			{Title: "Action", Width: constants.TableNarrowWidth},
+			{Title: "✓", Width: constants.TableMarkWidth},
+		}
+	case constants.ViewBulkConfirmation:
+		return []table.Column{
+			{Title: "Action", Width: constants.TableDefaultWidth},
+			{Title: "Description", Width: constants.TableDescWidth},
+		}
+	case constants.ViewBulkResults:
+		if m.SelectedOperation != nil && m.SelectedOperation.Name == "Approvals Inbox" {
+			return []table.Column{
+				{Title: "Account", Width: constants.TableShortWidth},
+				{Title: "Region", Width: constants.TableShortWidth},
+				{Title: "Pipeline", Width: constants.TableNarrowWidth},
+				{Title: "Stage", Width: constants.TableShortWidth},
+				{Title: "Action", Width: constants.TableShortWidth},
+				{Title: "Result", Width: constants.TableDescWidth},
+			}
+		}
+		return []table.Column{
+			{Title: "Pipeline", Width: constants.TableNarrowWidth},
+			{Title: "Stage", Width: constants.TableShortWidth},
+			{Title: "Action", Width: constants.TableShortWidth},
+			{Title: "Result", Width: constants.TableDescWidth},
+		}
+	case constants.ViewInboxProfiles:
+		return []table.Column{
+			{Title: "Profile", Width: constants.TableDefaultWidth},
+			{Title: "Selected", Width: constants.TableNarrowWidth},
+		}
+	case constants.ViewInboxRegions:
+		return []table.Column{
+			{Title: "Region", Width: constants.TableDefaultWidth},
+			{Title: "Selected", Width: constants.TableNarrowWidth},
 		}
 	case constants.ViewConfirmation:
 		return []table.Column{
@@ -102,6 +152,70 @@ func getColumnsForView(m *model.Model) []table.Column {
 			{Title: "Stage", Width: constants.TableDefaultWidth},
 			{Title: "Status", Width: constants.TableNarrowWidth},
 			{Title: "Last Updated", Width: constants.TableNarrowWidth},
+			{Title: "Transition", Width: constants.TableShortWidth},
+		}
+	case constants.ViewSelectSourceAction:
+		return []table.Column{
+			{Title: "Source Action", Width: constants.TableDefaultWidth},
+			{Title: "Provider", Width: constants.TableDefaultWidth},
+			{Title: "Revision Type", Width: constants.TableDefaultWidth},
+		}
+	case constants.ViewPipelineVariables:
+		return []table.Column{
+			{Title: "Variable", Width: constants.TableDefaultWidth},
+			{Title: "Value", Width: constants.TableDefaultWidth},
+			{Title: "Description", Width: constants.TableDescWidth},
+		}
+	case constants.ViewExecutionHistory:
+		return []table.Column{
+			{Title: "Execution ID", Width: constants.TableNarrowWidth},
+			{Title: "Status", Width: constants.TableShortWidth},
+			{Title: "Trigger", Width: constants.TableNarrowWidth},
+			{Title: "Revision", Width: constants.TableShortWidth},
+			{Title: "Started", Width: constants.TableNarrowWidth},
+			{Title: "Duration", Width: constants.TableShortWidth},
+		}
+	case constants.ViewExecutionWatch:
+		return []table.Column{
+			{Title: "Stage / Action", Width: constants.TableDefaultWidth},
+			{Title: "Status", Width: constants.TableNarrowWidth},
+			{Title: "Elapsed", Width: constants.TableShortWidth},
+		}
+	case constants.ViewStageOperations, constants.ViewStopExecution, constants.ViewRetryStage,
+		constants.ViewStageTransition, constants.ViewRollbackStage, constants.ViewOverrideCondition:
+		return []table.Column{
+			{Title: "Action", Width: constants.TableDefaultWidth},
+			{Title: "Description", Width: constants.TableDescWidth},
+		}
+	case constants.ViewPipelineDefinition:
+		return []table.Column{
+			{Title: "Definition", Width: constants.TableWideWidth},
+			{Title: "Details", Width: constants.TableDescWidth},
+		}
+	case constants.ViewDefinitionDiff:
+		return []table.Column{
+			{Title: "", Width: constants.TableMarkWidth},
+			{Title: "Line", Width: constants.TableWideWidth + constants.TableDescWidth},
+		}
+	case constants.ViewRollbackTargets:
+		return []table.Column{
+			{Title: "Execution ID", Width: constants.TableNarrowWidth},
+			{Title: "Status", Width: constants.TableShortWidth},
+			{Title: "Trigger", Width: constants.TableNarrowWidth},
+			{Title: "Revision", Width: constants.TableShortWidth},
+			{Title: "Started", Width: constants.TableNarrowWidth},
+		}
+	case constants.ViewStageActions:
+		return []table.Column{
+			{Title: "Action", Width: constants.TableDefaultWidth},
+			{Title: "Status", Width: constants.TableShortWidth},
+			{Title: "Provider", Width: constants.TableNarrowWidth},
+			{Title: "Last Change", Width: constants.TableNarrowWidth},
+		}
+	case constants.ViewExecutionDetails, constants.ViewActionDetails:
+		return []table.Column{
+			{Title: "Property", Width: constants.TableDefaultWidth},
+			{Title: "Value", Width: constants.TableWideWidth},
 		}
 	case constants.ViewFunctionStatus:
 		return []table.Column{
@@ -150,18 +264,16 @@ func getRowsForView(m *model.Model) []table.Row {
 		}
 		return rows
 	case constants.ViewAuthConfig:
-		key := m.ProviderState.AuthState.CurrentAuthConfigKey
-		options, ok := m.ProviderState.ConfigOptions[key]
-		if !ok {
+		keys := m.ProviderState.AuthState.ConfigKeys
+		if len(keys) == 0 {
 			return []table.Row{}
 		}
 
-		rows := make([]table.Row, len(options)+1)
-		rows[0] = table.Row{"Manual Entry"}
-		for i, option := range options {
-			rows[i+1] = table.Row{option}
+		rows := make([]table.Row, 0, len(keys)+1)
+		for _, key := range keys {
+			rows = append(rows, table.Row{key, getAuthConfigValue(m, key)})
 		}
-		return rows
+		return append(rows, table.Row{constants.AuthConfigSignInRow, ""})
 	case constants.ViewProviderConfig:
 		key := m.ProviderState.CurrentConfigKey
 		options, ok := m.ProviderState.ConfigOptions[key]
@@ -178,9 +290,10 @@ func getRowsForView(m *model.Model) []table.Row {
 	case constants.ViewAWSConfig:
 		if m.AwsProfile == "" {
 			rows := make([]table.Row, len(m.Profiles)+1)
-			rows[0] = table.Row{"Manual Entry"}
+			rows[0] = table.Row{"Manual Entry", "", "", ""}
 			for i, profile := range m.Profiles {
-				rows[i+1] = table.Row{profile}
+				details := m.ProfileDetails[profile]
+				rows[i+1] = table.Row{profile, details.Account, details.Role, details.AuthType}
 			}
 			return rows
 		}
@@ -312,15 +425,73 @@ func getRowsForView(m *model.Model) []table.Row {
 
 		return rows
 	case constants.ViewApprovals:
-		rows := make([]table.Row, len(m.Approvals))
-		for i, approval := range m.Approvals {
+		approvals := FilterApprovals(m.Approvals, m.ApprovalsFilter)
+		rows := make([]table.Row, len(approvals))
+		for i, approval := range approvals {
+			mark := ""
+			if containsString(m.MarkedApprovals, approval.Token) {
+				mark = "✓"
+			}
+			if m.SelectedOperation != nil && m.SelectedOperation.Name == "Approvals Inbox" {
+				rows[i] = table.Row{
+					GetApprovalAccount(approval),
+					approval.Region,
+					approval.PipelineName,
+					approval.StageName,
+					approval.ActionName,
+					mark,
+				}
+				continue
+			}
 			rows[i] = table.Row{
 				approval.PipelineName,
 				approval.StageName,
 				approval.ActionName,
+				mark,
 			}
 		}
 		return rows
+	case constants.ViewBulkConfirmation:
+		return []table.Row{
+			{"Approve", fmt.Sprintf("Approve the %d marked approvals", len(m.MarkedApprovals))},
+			{"Reject", fmt.Sprintf("Reject the %d marked approvals", len(m.MarkedApprovals))},
+		}
+	case constants.ViewBulkResults:
+		rows := make([]table.Row, len(m.BulkResults))
+		for i, result := range m.BulkResults {
+			outcome := "Rejected"
+			if m.ApproveAction {
+				outcome = "Approved"
+			}
+			switch {
+			case result.Skipped:
+				outcome = "Skipped"
+			case result.Err != nil:
+				outcome = fmt.Sprintf("Failed: %v", result.Err)
+			}
+			if m.SelectedOperation != nil && m.SelectedOperation.Name == "Approvals Inbox" {
+				rows[i] = table.Row{
+					GetApprovalAccount(result.Approval),
+					result.Approval.Region,
+					result.Approval.PipelineName,
+					result.Approval.StageName,
+					result.Approval.ActionName,
+					outcome,
+				}
+				continue
+			}
+			rows[i] = table.Row{
+				result.Approval.PipelineName,
+				result.Approval.StageName,
+				result.Approval.ActionName,
+				outcome,
+			}
+		}
+		return rows
+	case constants.ViewInboxProfiles:
+		return getInboxSelectionRows(m.Profiles, m.InboxProfiles)
+	case constants.ViewInboxRegions:
+		return getInboxSelectionRows(m.Regions, m.InboxRegions)
 	case constants.ViewConfirmation:
 		return []table.Row{
 			{"Approve", "Approve the pipeline stage"},
@@ -328,8 +499,14 @@ func getRowsForView(m *model.Model) []table.Row {
 		}
 	case constants.ViewExecutingAction:
 		if m.SelectedOperation != nil && m.SelectedOperation.Name == "Start Pipeline" {
+			description := "Start pipeline with latest commit"
+			if m.ManualCommitID && m.CommitID != "" {
+				description = fmt.Sprintf("Start pipeline at revision %s", m.CommitID)
+			}
 			return []table.Row{
-				{"Execute", "Start pipeline with latest commit"},
+				{"Execute", description},
+				{"Source Revision", "Start pipeline from a specific source revision"},
+				{"Variables", "Set pipeline variables (V2 pipelines)"},
 				{"Cancel", "Cancel and return to main menu"},
 			}
 		}
@@ -363,6 +540,269 @@ func getRowsForView(m *model.Model) []table.Row {
 				stage.Name,
 				stage.Status,
 				stage.LastUpdated,
+				GetTransitionStatus(stage.InboundTransition),
+			}
+		}
+		return rows
+	case constants.ViewSelectSourceAction:
+		rows := make([]table.Row, len(m.SourceActions))
+		for i, action := range m.SourceActions {
+			revisionType := action.RevisionType
+			if revisionType == "" {
+				revisionType = "Not supported"
+			}
+			rows[i] = table.Row{
+				action.Name,
+				action.Provider,
+				revisionType,
+			}
+		}
+		return rows
+	case constants.ViewPipelineVariables:
+		rows := make([]table.Row, len(m.PipelineVariables))
+		for i, variable := range m.PipelineVariables {
+			value := variable.Value
+			if value != "" && value == variable.DefaultValue {
+				value += " (default)"
+			}
+			rows[i] = table.Row{
+				variable.Name,
+				value,
+				variable.Description,
+			}
+		}
+		return rows
+	case constants.ViewExecutionHistory:
+		rows := make([]table.Row, 0, len(m.Executions)+1)
+		for _, execution := range m.Executions {
+			revision := ""
+			if len(execution.SourceRevisions) > 0 {
+				revision = execution.SourceRevisions[0].RevisionID
+			}
+			duration := execution.Duration
+			if duration == "" {
+				duration = "-"
+			}
+			rows = append(rows, table.Row{
+				execution.ExecutionID,
+				execution.Status,
+				execution.TriggerType,
+				revision,
+				execution.StartTime,
+				duration,
+			})
+		}
+		// Offer the next page while there are older executions
+		if m.ExecutionsToken != "" {
+			rows = append(rows, table.Row{"Load More", "", "Older executions", "", "", ""})
+		}
+		return rows
+	case constants.ViewStageOperations:
+		if m.SelectedStage == nil {
+			return []table.Row{}
+		}
+		var rows []table.Row
+		if len(m.SelectedStage.Actions) > 0 {
+			rows = append(rows, table.Row{"View Actions", "Show the status of each action in this stage"})
+		}
+		if isStageRunning(m.SelectedStage) {
+			rows = append(rows, table.Row{"Stop Execution", "Stop the pipeline execution running this stage"})
+		}
+		if isStageFailed(m.SelectedStage) {
+			rows = append(rows,
+				table.Row{"Retry Failed Actions", "Retry only the actions that failed in this stage"},
+				table.Row{"Retry All Actions", "Retry every action in this stage"},
+			)
+		}
+		if canRollbackStage(m.SelectedPipeline, m.SelectedStage) {
+			rows = append(rows, table.Row{"Rollback Stage", "Roll this stage back to a previous successful execution"})
+		}
+		for _, condition := range m.SelectedStage.Conditions {
+			if isConditionOverridable(condition) {
+				rows = append(rows, table.Row{
+					fmt.Sprintf("Override %s Condition", GetConditionLabel(condition.Type)),
+					"Skip the failed condition and let the execution continue",
+				})
+			}
+		}
+		if transition := m.SelectedStage.InboundTransition; transition != nil {
+			if transition.Enabled {
+				rows = append(rows, table.Row{"Disable Transition", "Keep pipeline executions from entering this stage"})
+			} else {
+				rows = append(rows, table.Row{"Enable Transition", "Let pipeline executions enter this stage again"})
+			}
+		}
+		return rows
+	case constants.ViewStageActions:
+		if m.SelectedStage == nil {
+			return []table.Row{}
+		}
+		rows := make([]table.Row, len(m.SelectedStage.Actions))
+		for i, action := range m.SelectedStage.Actions {
+			rows[i] = table.Row{
+				action.Name,
+				action.Status,
+				action.Provider,
+				action.LastStatusChange,
+			}
+		}
+		return rows
+	case constants.ViewActionDetails:
+		if m.SelectedAction == nil {
+			return []table.Row{}
+		}
+		action := m.SelectedAction
+		return []table.Row{
+			{"Name", action.Name},
+			{"Status", action.Status},
+			{"Category", valueOrDash(action.Category)},
+			{"Provider", valueOrDash(action.Provider)},
+			{"Last Status Change", action.LastStatusChange},
+			{"External Execution ID", valueOrDash(action.ExternalExecutionID)},
+			{"External Execution URL", valueOrDash(action.ExternalExecutionURL)},
+			{"Error Code", valueOrDash(action.ErrorCode)},
+			{"Error Message", valueOrDash(action.ErrorMessage)},
+			{"Summary", valueOrDash(action.Summary)},
+		}
+	case constants.ViewRetryStage:
+		description := "Retry the failed actions of the stage"
+		if m.RetryMode == cloud.RetryModeAllActions {
+			description = "Retry all actions of the stage"
+		}
+		return []table.Row{
+			{"Confirm", description},
+			{"Cancel", "Return to the stage operations"},
+		}
+	case constants.ViewStageTransition:
+		description := "Enable the transition into the stage"
+		if m.SelectedStage != nil && m.SelectedStage.InboundTransition != nil && m.SelectedStage.InboundTransition.Enabled {
+			description = "Disable the transition into the stage"
+		}
+		return []table.Row{
+			{"Confirm", description},
+			{"Cancel", "Return to the stage operations"},
+		}
+	case constants.ViewPipelineDefinition:
+		nodes := GetDefinitionNodes(m.PipelineDefinition, m.DefinitionExpanded)
+		rows := make([]table.Row, len(nodes))
+		for i, node := range nodes {
+			marker := "  "
+			if node.Expandable {
+				marker = "▸ "
+				if containsString(m.DefinitionExpanded, node.Key) {
+					marker = "▾ "
+				}
+			}
+			rows[i] = table.Row{
+				strings.Repeat("  ", node.Depth) + marker + node.Label,
+				node.Detail,
+			}
+		}
+		return rows
+	case constants.ViewDefinitionDiff:
+		if len(m.DefinitionDiff) == 0 {
+			return []table.Row{{"", "No differences"}}
+		}
+		rows := make([]table.Row, len(m.DefinitionDiff))
+		for i, line := range m.DefinitionDiff {
+			if line.Kind == "~" {
+				rows[i] = table.Row{"", line.Text}
+				continue
+			}
+			rows[i] = table.Row{line.Kind, line.Text}
+		}
+		return rows
+	case constants.ViewRollbackTargets:
+		rows := make([]table.Row, len(m.RollbackTargets))
+		for i, execution := range m.RollbackTargets {
+			revision := ""
+			if len(execution.SourceRevisions) > 0 {
+				revision = execution.SourceRevisions[0].RevisionID
+			}
+			rows[i] = table.Row{
+				execution.ExecutionID,
+				execution.Status,
+				execution.TriggerType,
+				revision,
+				execution.StartTime,
+			}
+		}
+		// Offer the next page while there are older targets
+		if m.RollbackToken != "" {
+			rows = append(rows, table.Row{"Load More", "", "Older executions", "", ""})
+		}
+		return rows
+	case constants.ViewRollbackStage:
+		description := "Roll the stage back to the selected execution"
+		if m.RollbackTarget != nil {
+			description = fmt.Sprintf("Roll the stage back to execution %s", m.RollbackTarget.ExecutionID)
+		}
+		return []table.Row{
+			{"Confirm", description},
+			{"Cancel", "Return to the stage operations"},
+		}
+	case constants.ViewOverrideCondition:
+		return []table.Row{
+			{"Confirm", fmt.Sprintf("Override the failed %s condition of the stage", strings.ToLower(GetConditionLabel(m.OverrideCondition)))},
+			{"Cancel", "Return to the stage operations"},
+		}
+	case constants.ViewStopExecution:
+		return []table.Row{
+			{"Stop and Wait", "Let in-progress actions finish, then stop the execution"},
+			{"Stop and Abandon", "Stop immediately without waiting for in-progress actions"},
+		}
+	case constants.ViewExecutionDetails:
+		if m.SelectedExecution == nil {
+			return []table.Row{}
+		}
+		execution := m.SelectedExecution
+
+		endTime := execution.EndTime
+		if endTime == "" {
+			endTime = "-"
+		}
+		duration := execution.Duration
+		if duration == "" {
+			duration = "-"
+		}
+
+		rows := []table.Row{
+			{"Execution ID", execution.ExecutionID},
+			{"Status", execution.Status},
+			{"Trigger", execution.TriggerType},
+			{"Started", execution.StartTime},
+			{"Ended", endTime},
+			{"Duration", duration},
+		}
+
+		// Only add optional details if they're available
+		if execution.StatusSummary != "" {
+			rows = append(rows, table.Row{"Status Summary", execution.StatusSummary})
+		}
+		if execution.TriggerDetail != "" {
+			rows = append(rows, table.Row{"Trigger Detail", execution.TriggerDetail})
+		}
+		for _, revision := range execution.SourceRevisions {
+			value := revision.RevisionID
+			if revision.RevisionSummary != "" {
+				value = fmt.Sprintf("%s (%s)", revision.RevisionID, revision.RevisionSummary)
+			}
+			rows = append(rows, table.Row{"Source: " + revision.ActionName, value})
+		}
+
+		return rows
+	case constants.ViewExecutionWatch:
+		if m.ExecutionProgress == nil {
+			return []table.Row{}
+		}
+		now := time.Now()
+		var rows []table.Row
+		for _, stage := range m.ExecutionProgress.Stages {
+			rows = append(rows, table.Row{stage.Name, stage.Status, GetStageElapsed(stage, now)})
+			for _, group := range groupActionsByRunOrder(stage.Actions) {
+				for _, action := range group {
+					rows = append(rows, table.Row{fmt.Sprintf("  [%d] %s", action.RunOrder, action.Name), action.Status, ""})
+				}
 			}
 		}
 		return rows
@@ -440,7 +880,7 @@ func getRowsForView(m *model.Model) []table.Row {
 			}
 			return []table.Row{
 				{"Latest Commit", "Use latest commit from source"},
-				{"Manual Input", "Enter specific commit ID"},
+				{"Manual Input", "Enter specific source revision"},
 			}
 		}
 		// For approval summary, don't show any rows since we're showing text input
@@ -450,11 +890,24 @@ func getRowsForView(m *model.Model) []table.Row {
 	}
 }
 
+// getAuthConfigValue returns the entered value of an auth config key, masking secrets
+func getAuthConfigValue(m *model.Model, key string) string {
+	value := m.ProviderState.AuthState.AuthConfig[key]
+	if value != "" && constants.SecretAuthConfigKeys[key] {
+		return "********"
+	}
+	return value
+}
+
 // getAuthMethodDescription returns a description for an authentication method
 func getAuthMethodDescription(providerName, method string) string {
 	descriptions := map[string]map[string]string{
 		"AWS": {
-			"profile": "Use AWS profile from ~/.aws/credentials",
+			"profile":            "Use AWS profile from ~/.aws/credentials",
+			"environment":        "Use the AWS_* environment variables",
+			"static_keys":        "Enter an access key, kept only in memory",
+			"credential_process": "Run a command printing credentials",
+			"web_identity":       "Assume a role with a web identity token file",
 		},
 		"Azure": {
 			"cli":        "Use Azure CLI authentication",
@@ -474,3 +927,279 @@ func getAuthMethodDescription(providerName, method string) string {
 
 	return ""
 }
+
+// isStageRunning checks if a stage's pipeline execution can still be stopped
+func isStageRunning(stage *cloud.StageStatus) bool {
+	return stage.ExecutionID != "" && (stage.Status == "InProgress" || stage.Status == "Stopping")
+}
+
+// isStageFailed checks if a stage failed and can be retried
+func isStageFailed(stage *cloud.StageStatus) bool {
+	return stage.ExecutionID != "" && stage.Status == "Failed"
+}
+
+// canRollbackStage checks if a stage can be rolled back. The first stage holds the
+// source actions and cannot be rolled back, neither can a stage that is still running.
+func canRollbackStage(pipeline *cloud.PipelineStatus, stage *cloud.StageStatus) bool {
+	if pipeline == nil || len(pipeline.Stages) == 0 || pipeline.Stages[0].Name == stage.Name {
+		return false
+	}
+	return stage.ExecutionID != "" && !isStageRunning(stage)
+}
+
+// isConditionOverridable checks if a stage condition failed and can be overridden
+func isConditionOverridable(condition cloud.ConditionStatus) bool {
+	if condition.Type != cloud.ConditionTypeBeforeEntry && condition.Type != cloud.ConditionTypeOnSuccess {
+		return false
+	}
+	return condition.Status == "Failed" || condition.Status == "Errored"
+}
+
+// GetConditionLabel returns the readable name of a stage condition type
+func GetConditionLabel(conditionType string) string {
+	switch conditionType {
+	case cloud.ConditionTypeBeforeEntry:
+		return "Before Entry"
+	case cloud.ConditionTypeOnSuccess:
+		return "On Success"
+	case cloud.ConditionTypeOnFailure:
+		return "On Failure"
+	default:
+		return conditionType
+	}
+}
+
+// valueOrDash returns the value, or a dash when it is empty
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// GetApprovalAccount returns the account an approval belongs to, falling back to
+// the profile it was found with when the account ID is unknown
+func GetApprovalAccount(approval cloud.ApprovalAction) string {
+	if approval.AccountID != "" {
+		return approval.AccountID
+	}
+	return approval.Profile
+}
+
+// getInboxSelectionRows returns the rows for choosing the profiles or regions of the approvals inbox
+func getInboxSelectionRows(options, selected []string) []table.Row {
+	selectedSet := make(map[string]bool, len(selected))
+	for _, value := range selected {
+		selectedSet[value] = true
+	}
+
+	rows := make([]table.Row, 0, len(options)+1)
+	rows = append(rows, table.Row{"Continue", fmt.Sprintf("%d selected", len(selected))})
+	for _, option := range options {
+		mark := ""
+		if selectedSet[option] {
+			mark = "✓"
+		}
+		rows = append(rows, table.Row{option, mark})
+	}
+
+	// Keep manually configured values that are not in the option list selectable
+	for _, value := range selected {
+		if !containsString(options, value) {
+			rows = append(rows, table.Row{value, "✓"})
+		}
+	}
+	return rows
+}
+
+// containsString checks if a slice contains a string
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterApprovals returns the approvals whose pipeline, stage or action contains the filter
+func FilterApprovals(approvals []cloud.ApprovalAction, filter string) []cloud.ApprovalAction {
+	if filter == "" {
+		return approvals
+	}
+
+	filter = strings.ToLower(filter)
+	var filtered []cloud.ApprovalAction
+	for _, approval := range approvals {
+		if strings.Contains(strings.ToLower(approval.PipelineName), filter) ||
+			strings.Contains(strings.ToLower(approval.StageName), filter) ||
+			strings.Contains(strings.ToLower(approval.ActionName), filter) {
+			filtered = append(filtered, approval)
+		}
+	}
+	return filtered
+}
+
+// GetTransitionStatus returns whether the transition into a stage is enabled,
+// or a dash for stages without an inbound transition
+func GetTransitionStatus(transition *cloud.TransitionState) string {
+	switch {
+	case transition == nil:
+		return "-"
+	case transition.Enabled:
+		return "Enabled"
+	default:
+		return "Disabled"
+	}
+}
+
+// Keys of the rows listed above the pipeline definition tree
+const (
+	DefinitionKeyExportJSON = "export:json"
+	DefinitionKeyExportYAML = "export:yaml"
+	DefinitionKeyDiff       = "diff"
+)
+
+// DefinitionNode represents a row of the pipeline definition tree
+type DefinitionNode struct {
+	Key        string
+	Label      string
+	Detail     string
+	Depth      int
+	Expandable bool
+}
+
+// GetDefinitionNodes returns the rows of the pipeline definition tree, listing the
+// children of the expanded nodes only. Actions are listed in run order.
+func GetDefinitionNodes(definition *cloud.PipelineDefinition, expanded []string) []DefinitionNode {
+	if definition == nil {
+		return nil
+	}
+
+	nodes := []DefinitionNode{
+		{Key: DefinitionKeyExportJSON, Label: "Export JSON", Detail: "Save the definition as a JSON file"},
+		{Key: DefinitionKeyExportYAML, Label: "Export YAML", Detail: "Save the definition as a YAML file"},
+		{Key: DefinitionKeyDiff, Label: "Diff Local File", Detail: "Compare the definition with a local JSON or YAML file"},
+		{Key: "pipeline", Label: "Pipeline", Detail: getDefinitionSummary(definition)},
+		{Key: "role", Label: "Role", Detail: valueOrDash(definition.RoleArn)},
+	}
+	if store := definition.ArtifactStore; store != nil {
+		nodes = append(nodes, DefinitionNode{Key: "artifact-store", Label: "Artifact Store", Detail: fmt.Sprintf("%s: %s", store.Type, store.Location)})
+	}
+
+	if len(definition.Variables) > 0 {
+		nodes = append(nodes, DefinitionNode{
+			Key:        "variables",
+			Label:      "Variables",
+			Detail:     fmt.Sprintf("%d variables", len(definition.Variables)),
+			Expandable: true,
+		})
+		if containsString(expanded, "variables") {
+			for _, variable := range definition.Variables {
+				detail := valueOrDash(variable.DefaultValue)
+				if variable.Description != "" {
+					detail += " (" + variable.Description + ")"
+				}
+				nodes = append(nodes, DefinitionNode{Key: "variable:" + variable.Name, Label: variable.Name, Detail: detail, Depth: 1})
+			}
+		}
+	}
+
+	for i, stage := range definition.Stages {
+		stageKey := fmt.Sprintf("stage:%d", i)
+		nodes = append(nodes, DefinitionNode{
+			Key:        stageKey,
+			Label:      "Stage: " + stage.Name,
+			Detail:     fmt.Sprintf("%d actions", len(stage.Actions)),
+			Expandable: true,
+		})
+		if !containsString(expanded, stageKey) {
+			continue
+		}
+
+		actions := make([]cloud.ActionDefinition, len(stage.Actions))
+		copy(actions, stage.Actions)
+		sort.SliceStable(actions, func(a, b int) bool {
+			return actions[a].RunOrder < actions[b].RunOrder
+		})
+
+		for _, action := range actions {
+			actionKey := fmt.Sprintf("%s:action:%s", stageKey, action.Name)
+			actionType := action.ActionTypeID
+			nodes = append(nodes, DefinitionNode{
+				Key:        actionKey,
+				Label:      fmt.Sprintf("[%d] %s", action.RunOrder, action.Name),
+				Detail:     fmt.Sprintf("%s · %s/%s v%s", actionType.Category, actionType.Owner, actionType.Provider, actionType.Version),
+				Depth:      1,
+				Expandable: true,
+			})
+			if containsString(expanded, actionKey) {
+				nodes = append(nodes, getActionDefinitionNodes(actionKey, action)...)
+			}
+		}
+	}
+
+	return nodes
+}
+
+// getDefinitionSummary returns the name, type, execution mode and version of a pipeline
+func getDefinitionSummary(definition *cloud.PipelineDefinition) string {
+	parts := []string{definition.Name}
+	if definition.PipelineType != "" {
+		parts = append(parts, definition.PipelineType)
+	}
+	if definition.ExecutionMode != "" {
+		parts = append(parts, definition.ExecutionMode)
+	}
+	parts = append(parts, fmt.Sprintf("version %d", definition.Version))
+	return strings.Join(parts, " · ")
+}
+
+// getActionDefinitionNodes returns the artifacts, settings and configuration of an action
+func getActionDefinitionNodes(actionKey string, action cloud.ActionDefinition) []DefinitionNode {
+	var nodes []DefinitionNode
+	addNode := func(label, detail string) {
+		if detail != "" {
+			nodes = append(nodes, DefinitionNode{Key: actionKey + ":" + label, Label: label, Detail: detail, Depth: 2})
+		}
+	}
+
+	addNode("Input Artifacts", joinArtifactNames(action.InputArtifacts))
+	addNode("Output Artifacts", joinArtifactNames(action.OutputArtifacts))
+	addNode("Region", action.Region)
+	addNode("Namespace", action.Namespace)
+	addNode("Role", action.RoleArn)
+
+	keys := make([]string, 0, len(action.Configuration))
+	for key := range action.Configuration {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		addNode(key, action.Configuration[key])
+	}
+
+	return nodes
+}
+
+// joinArtifactNames returns the names of the artifacts separated by commas
+func joinArtifactNames(artifacts []cloud.ArtifactDefinition) string {
+	names := make([]string, len(artifacts))
+	for i, artifact := range artifacts {
+		names[i] = artifact.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// GetStageElapsed returns how long a stage of a watched execution has been running, or
+// ran for once it stopped
+func GetStageElapsed(stage cloud.StageProgress, now time.Time) string {
+	if stage.StartTime.IsZero() {
+		return "-"
+	}
+	end := stage.EndTime
+	if end.IsZero() {
+		end = now
+	}
+	return end.Sub(stage.StartTime).Round(time.Second).String()
+}
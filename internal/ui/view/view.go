@@ -2,7 +2,10 @@ package view
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
 	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
 	"github.com/HenryOwenz/cloudgate/internal/ui/model"
 	"github.com/charmbracelet/lipgloss"
@@ -50,15 +53,24 @@ func renderTitle(m *model.Model) string {
 	return m.Styles.Title.Render(getTitleText(m))
 }
 
-// renderContext renders the context based on the current view
+// renderContext renders the context based on the current view, followed by the
+// refresh indicator of the status tables
 func renderContext(m *model.Model) string {
-	return m.Styles.Context.Render(getContextText(m))
+	return m.Styles.Context.Render(getContextText(m) + getRefreshText(m))
 }
 
-// renderLoadingSpinner renders the loading spinner if needed
+// renderLoadingSpinner renders the loading spinner if needed, or the notice of a
+// cancelled load
 func renderLoadingSpinner(m *model.Model) string {
 	if m.IsLoading {
-		return m.Spinner.View()
+		cancelText := m.Styles.Help.Render(fmt.Sprintf("  %s: cancel", constants.KeyEsc))
+		if m.LoadingMsg != "" {
+			return m.Spinner.View() + " " + m.LoadingMsg + cancelText
+		}
+		return m.Spinner.View() + cancelText
+	}
+	if m.RequestNotice != "" {
+		return m.Styles.Error.Render(m.RequestNotice)
 	}
 	return ""
 }
@@ -75,6 +87,10 @@ func renderMainContent(m *model.Model) string {
 		return m.TextInput.View()
 	}
 
+	if m.CurrentView == constants.ViewPipelineGraph {
+		return renderPipelineGraph(m)
+	}
+
 	return renderTable(m)
 }
 
@@ -90,6 +106,8 @@ func getContextText(m *model.Model) string {
 		return getProvidersContextText()
 	case constants.ViewAWSConfig:
 		return getAWSConfigContextText(m)
+	case constants.ViewAuthConfig:
+		return getAuthConfigContextText(m)
 	case constants.ViewSelectService:
 		return getSelectServiceContextText(m)
 	case constants.ViewSelectCategory:
@@ -98,14 +116,33 @@ func getContextText(m *model.Model) string {
 		return getSelectOperationContextText(m)
 	case constants.ViewApprovals:
 		return getApprovalsContextText(m)
+	case constants.ViewInboxProfiles, constants.ViewInboxRegions:
+		return getInboxContextText(m)
+	case constants.ViewBulkConfirmation:
+		return getBulkConfirmationContextText(m)
+	case constants.ViewBulkResults:
+		return getBulkResultsContextText(m)
 	case constants.ViewConfirmation, constants.ViewSummary:
 		return getConfirmationSummaryContextText(m)
 	case constants.ViewExecutingAction:
 		return getExecutingActionContextText(m)
 	case constants.ViewPipelineStatus:
 		return getPipelineStatusContextText(m)
-	case constants.ViewPipelineStages:
+	case constants.ViewPipelineStages, constants.ViewPipelineGraph, constants.ViewSelectSourceAction,
+		constants.ViewPipelineVariables, constants.ViewExecutionHistory:
 		return getPipelineStagesContextText(m)
+	case constants.ViewExecutionDetails:
+		return getExecutionDetailsContextText(m)
+	case constants.ViewExecutionWatch:
+		return getExecutionWatchContextText(m)
+	case constants.ViewPipelineDefinition, constants.ViewDefinitionDiff:
+		return getPipelineDefinitionContextText(m)
+	case constants.ViewStageOperations, constants.ViewStopExecution, constants.ViewRetryStage,
+		constants.ViewStageTransition, constants.ViewRollbackTargets, constants.ViewRollbackStage,
+		constants.ViewOverrideCondition, constants.ViewStageActions:
+		return getStageOperationsContextText(m)
+	case constants.ViewActionDetails:
+		return getActionDetailsContextText(m)
 	case constants.ViewFunctionStatus:
 		return getFunctionStatusContextText(m)
 	case constants.ViewFunctionDetails:
@@ -131,16 +168,89 @@ func getAWSConfigContextText(m *model.Model) string {
 	}
 	// If in manual entry mode for region, show the text input in the context
 	if m.ManualInput {
-		return fmt.Sprintf("Profile: %s\n\nEnter AWS Region: %s", m.AwsProfile, m.TextInput.View())
+		return fmt.Sprintf("Profile: %s%s\n\nEnter AWS Region: %s", m.AwsProfile, getProfileDetailsText(m), m.TextInput.View())
+	}
+	return fmt.Sprintf("Profile: %s%s%s", m.AwsProfile, getProfileDetailsText(m), getDeviceAuthorizationText(m))
+}
+
+// getAuthConfigContextText returns the context text for the auth config view, which
+// names the profile and MFA device when it asks for an MFA code
+func getAuthConfigContextText(m *model.Model) string {
+	if m.ProviderState.AuthState.Method != constants.AWSMFAAuth {
+		if m.ManualInput {
+			return fmt.Sprintf("Authentication Method: %s\n\nEnter %s", m.ProviderState.AuthState.Method,
+				m.ProviderState.AuthState.CurrentAuthConfigKey)
+		}
+		return "Authentication Method: " + m.ProviderState.AuthState.Method
+	}
+
+	text := "Profile: " + m.AwsProfile
+	if device := m.ProfileDetails[m.AwsProfile].MFADevice; device != "" {
+		text += "\nMFA device: " + device
+	}
+	return text
+}
+
+// getDeviceAuthorizationText returns how to approve the pending sign in, if any
+func getDeviceAuthorizationText(m *model.Model) string {
+	authorization := m.DeviceAuthorization
+	if authorization == nil {
+		return ""
+	}
+
+	url := authorization.VerificationURIComplete
+	if url == "" {
+		url = authorization.VerificationURI
 	}
-	return fmt.Sprintf("Profile: %s", m.AwsProfile)
+	return "\n\n" + fmt.Sprintf(constants.MsgSignInInstructions, url, authorization.UserCode)
+}
+
+// getProfileDetailsText returns the configured account, role and authentication type
+// of the selected profile, one per line
+func getProfileDetailsText(m *model.Model) string {
+	details, ok := m.ProfileDetails[m.AwsProfile]
+	if !ok {
+		return ""
+	}
+
+	var text string
+	if details.Account != "" {
+		text += "\nAccount: " + details.Account
+	}
+	if details.Role != "" {
+		text += "\nRole: " + details.Role
+	}
+	if details.AuthType != "" {
+		text += "\nAuth: " + details.AuthType
+	}
+	return text
+}
+
+// getAccountText returns the profile and region, followed by the account and identity
+// the provider signs its requests as once they were looked up for the profile
+func getAccountText(m *model.Model, profile, region string) string {
+	text := fmt.Sprintf("Profile: %s\nRegion: %s", profile, region)
+	if profile != m.AwsProfile {
+		return text
+	}
+
+	if m.CallerIdentityErr != nil {
+		return text + "\nAccount: " + fmt.Sprintf(constants.MsgAccountUnverified, m.CallerIdentityErr)
+	}
+	identity := m.CallerIdentity
+	if identity == nil {
+		return text
+	}
+	text += "\nAccount: " + identity.Account
+	if identity.AccountAlias != "" {
+		text += fmt.Sprintf(" (%s)", identity.AccountAlias)
+	}
+	return text + "\nIdentity: " + identity.ARN
 }
 
 // getSelectServiceContextText returns the context text for the select service view
 func getSelectServiceContextText(m *model.Model) string {
-	return fmt.Sprintf("Profile: %s\nRegion: %s",
-		m.AwsProfile,
-		m.AwsRegion)
+	return getAccountText(m, m.AwsProfile, m.AwsRegion)
 }
 
 // getSelectCategoryContextText returns the context text for the select category view
@@ -148,9 +258,8 @@ func getSelectCategoryContextText(m *model.Model) string {
 	if m.SelectedService == nil {
 		return ""
 	}
-	return fmt.Sprintf("Profile: %s\nRegion: %s\nService: %s",
-		m.AwsProfile,
-		m.AwsRegion,
+	return fmt.Sprintf("%s\nService: %s",
+		getAccountText(m, m.AwsProfile, m.AwsRegion),
 		m.SelectedService.Name)
 }
 
@@ -159,18 +268,80 @@ func getSelectOperationContextText(m *model.Model) string {
 	if m.SelectedService == nil || m.SelectedCategory == nil {
 		return ""
 	}
-	return fmt.Sprintf("Profile: %s\nRegion: %s\nService: %s\nCategory: %s",
-		m.AwsProfile,
-		m.AwsRegion,
+	return fmt.Sprintf("%s\nService: %s\nCategory: %s",
+		getAccountText(m, m.AwsProfile, m.AwsRegion),
 		m.SelectedService.Name,
 		m.SelectedCategory.Name)
 }
 
 // getApprovalsContextText returns the context text for the approvals view
 func getApprovalsContextText(m *model.Model) string {
-	return fmt.Sprintf("Profile: %s\nRegion: %s",
-		m.AwsProfile,
-		m.AwsRegion)
+	contextText := getAccountText(m, m.AwsProfile, m.AwsRegion)
+	if m.SelectedOperation != nil && m.SelectedOperation.Name == "Approvals Inbox" {
+		contextText = getInboxContextText(m)
+	}
+	if len(m.PipelineFailures) > 0 {
+		contextText += fmt.Sprintf("\nFailed to load: %s", strings.Join(m.PipelineFailures, ", "))
+	}
+	if m.ApprovalsFilter != "" {
+		contextText += fmt.Sprintf("\nFilter: %s", m.ApprovalsFilter)
+	}
+	if len(m.MarkedApprovals) > 0 {
+		contextText += fmt.Sprintf("\nMarked: %d", len(m.MarkedApprovals))
+	}
+	return contextText
+}
+
+// getBulkConfirmationContextText returns the context text for the bulk approval confirmation view
+func getBulkConfirmationContextText(m *model.Model) string {
+	var pipelines []string
+	for _, approval := range m.Approvals {
+		if containsString(m.MarkedApprovals, approval.Token) && !containsString(pipelines, approval.PipelineName) {
+			pipelines = append(pipelines, approval.PipelineName)
+		}
+	}
+	contextText := fmt.Sprintf("Marked: %d approvals\nPipelines: %s",
+		len(m.MarkedApprovals),
+		strings.Join(pipelines, ", "))
+	if m.ManualInput {
+		action := "Reject"
+		if m.ApproveAction {
+			action = "Approve"
+		}
+		contextText += fmt.Sprintf("\nAction: %s", action)
+	}
+	return contextText
+}
+
+// getBulkResultsContextText returns the context text for the bulk approval results view
+func getBulkResultsContextText(m *model.Model) string {
+	failed, skipped := 0, 0
+	for _, result := range m.BulkResults {
+		switch {
+		case result.Skipped:
+			skipped++
+		case result.Err != nil:
+			failed++
+		}
+	}
+	contextText := fmt.Sprintf("Succeeded: %d\nFailed: %d",
+		len(m.BulkResults)-failed-skipped,
+		failed)
+	if skipped > 0 {
+		contextText += fmt.Sprintf("\nSkipped: %d", skipped)
+	}
+	return contextText
+}
+
+// getInboxContextText returns the context text for the approvals inbox views
+func getInboxContextText(m *model.Model) string {
+	contextText := fmt.Sprintf("Profiles: %s\nRegions: %s",
+		strings.Join(m.InboxProfiles, ", "),
+		strings.Join(m.InboxRegions, ", "))
+	if len(m.InboxFailures) > 0 {
+		contextText += fmt.Sprintf("\nFailed to load: %s", strings.Join(m.InboxFailures, ", "))
+	}
+	return contextText
 }
 
 // getConfirmationSummaryContextText returns the context text for the confirmation and summary views
@@ -179,20 +350,91 @@ func getConfirmationSummaryContextText(m *model.Model) string {
 		if m.SelectedPipeline == nil {
 			return ""
 		}
-		return fmt.Sprintf("Profile: %s\nRegion: %s\nPipeline: %s",
-			m.AwsProfile,
-			m.AwsRegion,
+		if m.SelectedSource != nil {
+			return fmt.Sprintf("%s\nPipeline: %s\nSource Action: %s (%s)\nRevision Type: %s",
+				getAccountText(m, m.AwsProfile, m.AwsRegion),
+				m.SelectedPipeline.Name,
+				m.SelectedSource.Name,
+				m.SelectedSource.Provider,
+				m.SelectedSource.RevisionType)
+		}
+		return fmt.Sprintf("%s\nPipeline: %s",
+			getAccountText(m, m.AwsProfile, m.AwsRegion),
 			m.SelectedPipeline.Name)
 	}
 	if m.SelectedApproval == nil {
 		return ""
 	}
-	return fmt.Sprintf("Profile: %s\nRegion: %s\nPipeline: %s\nStage: %s\nAction: %s",
-		m.AwsProfile,
-		m.AwsRegion,
+	// Approvals of the inbox may come from other profiles and regions
+	profile, region := m.AwsProfile, m.AwsRegion
+	if m.SelectedApproval.Profile != "" {
+		profile, region = m.SelectedApproval.Profile, m.SelectedApproval.Region
+	}
+	contextText := fmt.Sprintf("%s\nPipeline: %s\nStage: %s\nAction: %s",
+		getAccountText(m, profile, region),
 		m.SelectedApproval.PipelineName,
 		m.SelectedApproval.StageName,
 		m.SelectedApproval.ActionName)
+	if m.CurrentView == constants.ViewConfirmation {
+		contextText += getApprovalDetailsText(m.SelectedApproval, time.Now())
+	}
+	return contextText
+}
+
+// getApprovalDetailsText returns what an approver needs to know about the approval
+func getApprovalDetailsText(approval *cloud.ApprovalAction, now time.Time) string {
+	var details strings.Builder
+	if approval.ExecutionID != "" {
+		fmt.Fprintf(&details, "\nExecution: %s", approval.ExecutionID)
+	}
+	for _, revision := range approval.SourceRevisions {
+		fmt.Fprintf(&details, "\nRevision: %s %s", revision.ActionName, revision.RevisionID)
+		if revision.RevisionSummary != "" {
+			fmt.Fprintf(&details, " - %s", firstLine(revision.RevisionSummary))
+		}
+	}
+	if !approval.RequestedAt.IsZero() {
+		fmt.Fprintf(&details, "\nWaiting: %s", formatApprovalDuration(now.Sub(approval.RequestedAt)))
+	}
+	if !approval.ExpiresAt.IsZero() {
+		expiresAt := approval.ExpiresAt.UTC().Format("Jan 02 15:04:05") + " UTC"
+		if remaining := approval.ExpiresAt.Sub(now); remaining > 0 {
+			fmt.Fprintf(&details, "\nExpires: %s (in %s)", expiresAt, formatApprovalDuration(remaining))
+		} else {
+			fmt.Fprintf(&details, "\nExpires: %s (expired)", expiresAt)
+		}
+	}
+	if approval.CustomData != "" {
+		fmt.Fprintf(&details, "\nDetails: %s", approval.CustomData)
+	}
+	if approval.ExternalEntityLink != "" {
+		fmt.Fprintf(&details, "\nReview: %s", approval.ExternalEntityLink)
+	}
+	return details.String()
+}
+
+// formatApprovalDuration formats a duration in days, hours and minutes
+func formatApprovalDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	days := d / (24 * time.Hour)
+	hours := (d % (24 * time.Hour)) / time.Hour
+	minutes := (d % time.Hour) / time.Minute
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
+
+// firstLine returns the first line of a multi-line text
+func firstLine(text string) string {
+	if i := strings.IndexByte(text, '\n'); i >= 0 {
+		return text[:i]
+	}
+	return text
 }
 
 // getExecutingActionContextText returns the context text for the executing action view
@@ -205,20 +447,33 @@ func getExecutingActionContextText(m *model.Model) string {
 		revisionID := "Latest commit"
 		if m.ManualCommitID && m.CommitID != "" {
 			revisionID = m.CommitID
+			if m.SelectedSource != nil {
+				revisionID = fmt.Sprintf("%s (%s)", m.CommitID, m.SelectedSource.Name)
+			}
 		}
 
-		return fmt.Sprintf("Profile: %s\nRegion: %s\nPipeline: %s\nRevisionID: %s",
-			m.AwsProfile,
-			m.AwsRegion,
+		contextText := fmt.Sprintf("%s\nPipeline: %s\nRevisionID: %s",
+			getAccountText(m, m.AwsProfile, m.AwsRegion),
 			m.SelectedPipeline.Name,
 			revisionID)
+
+		// Show the variables that override their declared defaults
+		for _, variable := range m.PipelineVariables {
+			if variable.Value != variable.DefaultValue {
+				contextText += fmt.Sprintf("\nVariable: %s=%s", variable.Name, variable.Value)
+			}
+		}
+		return contextText
 	}
 	if m.SelectedApproval == nil {
 		return ""
 	}
-	return fmt.Sprintf("Profile: %s\nRegion: %s\nPipeline: %s\nStage: %s\nAction: %s\nComment: %s",
-		m.AwsProfile,
-		m.AwsRegion,
+	profile, region := m.AwsProfile, m.AwsRegion
+	if m.SelectedApproval.Profile != "" {
+		profile, region = m.SelectedApproval.Profile, m.SelectedApproval.Region
+	}
+	return fmt.Sprintf("%s\nPipeline: %s\nStage: %s\nAction: %s\nComment: %s",
+		getAccountText(m, profile, region),
 		m.SelectedApproval.PipelineName,
 		m.SelectedApproval.StageName,
 		m.SelectedApproval.ActionName,
@@ -227,9 +482,11 @@ func getExecutingActionContextText(m *model.Model) string {
 
 // getPipelineStatusContextText returns the context text for the pipeline status view
 func getPipelineStatusContextText(m *model.Model) string {
-	return fmt.Sprintf("Profile: %s\nRegion: %s",
-		m.AwsProfile,
-		m.AwsRegion)
+	contextText := getAccountText(m, m.AwsProfile, m.AwsRegion)
+	if len(m.PipelineFailures) > 0 {
+		contextText += fmt.Sprintf("\nFailed to load: %s", strings.Join(m.PipelineFailures, ", "))
+	}
+	return contextText
 }
 
 // getPipelineStagesContextText returns the context text for the pipeline stages view
@@ -237,17 +494,122 @@ func getPipelineStagesContextText(m *model.Model) string {
 	if m.SelectedPipeline == nil {
 		return ""
 	}
-	return fmt.Sprintf("Profile: %s\nRegion: %s\nPipeline: %s",
-		m.AwsProfile,
-		m.AwsRegion,
+	return fmt.Sprintf("%s\nPipeline: %s",
+		getAccountText(m, m.AwsProfile, m.AwsRegion),
 		m.SelectedPipeline.Name)
 }
 
+// getExecutionDetailsContextText returns the context text for the execution details view
+func getExecutionDetailsContextText(m *model.Model) string {
+	if m.SelectedPipeline == nil || m.SelectedExecution == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s\nPipeline: %s\nExecution: %s",
+		getAccountText(m, m.AwsProfile, m.AwsRegion),
+		m.SelectedPipeline.Name,
+		m.SelectedExecution.ExecutionID)
+}
+
+// getExecutionWatchContextText returns the context text for the execution watch view
+func getExecutionWatchContextText(m *model.Model) string {
+	if m.SelectedPipeline == nil {
+		return ""
+	}
+	status := "Loading..."
+	if progress := m.ExecutionProgress; progress != nil {
+		status = progress.Status
+		if progress.StatusSummary != "" {
+			status += fmt.Sprintf(" (%s)", firstLine(progress.StatusSummary))
+		}
+	}
+	contextText := fmt.Sprintf("%s\nPipeline: %s\nExecution: %s\nStatus: %s",
+		getAccountText(m, m.AwsProfile, m.AwsRegion),
+		m.SelectedPipeline.Name,
+		m.WatchExecutionID,
+		status)
+	if m.WatchErr != nil {
+		contextText += fmt.Sprintf("\nPoll failed: %v", m.WatchErr)
+	}
+	return contextText
+}
+
+// getPipelineDefinitionContextText returns the context text for the pipeline definition views
+func getPipelineDefinitionContextText(m *model.Model) string {
+	if m.PipelineDefinition == nil {
+		return ""
+	}
+	contextText := fmt.Sprintf("%s\nPipeline: %s",
+		getAccountText(m, m.AwsProfile, m.AwsRegion),
+		m.PipelineDefinition.Name)
+	if m.CurrentView == constants.ViewDefinitionDiff {
+		added, removed := 0, 0
+		for _, line := range m.DefinitionDiff {
+			switch line.Kind {
+			case "+":
+				added++
+			case "-":
+				removed++
+			}
+		}
+		contextText += fmt.Sprintf("\nLocal File: %s\nChanges: +%d -%d", m.DefinitionFile, added, removed)
+	} else if m.Success != "" {
+		contextText += "\n" + m.Success
+	}
+	return contextText
+}
+
+// getStageOperationsContextText returns the context text for the stage operations views
+func getStageOperationsContextText(m *model.Model) string {
+	if m.SelectedPipeline == nil || m.SelectedStage == nil {
+		return ""
+	}
+	contextText := fmt.Sprintf("%s\nPipeline: %s\nStage: %s (%s)\nExecution: %s",
+		getAccountText(m, m.AwsProfile, m.AwsRegion),
+		m.SelectedPipeline.Name,
+		m.SelectedStage.Name,
+		m.SelectedStage.Status,
+		m.SelectedStage.ExecutionID)
+	if m.CurrentView == constants.ViewRetryStage {
+		contextText += fmt.Sprintf("\nRetry Mode: %s", m.RetryMode)
+	}
+	if m.CurrentView == constants.ViewRollbackStage && m.RollbackTarget != nil {
+		contextText += fmt.Sprintf("\nRollback Target: %s (started %s)", m.RollbackTarget.ExecutionID, m.RollbackTarget.StartTime)
+	}
+	for _, condition := range m.SelectedStage.Conditions {
+		contextText += fmt.Sprintf("\n%s Condition: %s", GetConditionLabel(condition.Type), condition.Status)
+		if condition.Summary != "" && (m.CurrentView != constants.ViewOverrideCondition || condition.Type == m.OverrideCondition) {
+			contextText += fmt.Sprintf(" (%s)", firstLine(condition.Summary))
+		}
+	}
+	if transition := m.SelectedStage.InboundTransition; transition != nil {
+		contextText += fmt.Sprintf("\nTransition: %s", GetTransitionStatus(transition))
+		if !transition.Enabled {
+			contextText += fmt.Sprintf("\nDisabled Reason: %s\nDisabled By: %s (%s)",
+				valueOrDash(transition.DisabledReason),
+				valueOrDash(transition.LastChangedBy),
+				valueOrDash(transition.LastChangedAt))
+		}
+	}
+	return contextText
+}
+
+// getActionDetailsContextText returns the context text for the action details view
+func getActionDetailsContextText(m *model.Model) string {
+	if m.SelectedPipeline == nil || m.SelectedStage == nil || m.SelectedAction == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s\nPipeline: %s\nStage: %s\nAction: %s (%s)",
+		getAccountText(m, m.AwsProfile, m.AwsRegion),
+		m.SelectedPipeline.Name,
+		m.SelectedStage.Name,
+		m.SelectedAction.Name,
+		m.SelectedAction.Status)
+}
+
 // getFunctionStatusContextText returns the context text for the function status view
 func getFunctionStatusContextText(m *model.Model) string {
-	return fmt.Sprintf("Profile: %s\nRegion: %s\nService: %s\nCategory: %s",
-		m.AwsProfile,
-		m.AwsRegion,
+	return fmt.Sprintf("%s\nService: %s\nCategory: %s",
+		getAccountText(m, m.AwsProfile, m.AwsRegion),
 		m.SelectedService.Name,
 		m.SelectedCategory.Name)
 }
@@ -257,9 +619,8 @@ func getFunctionDetailsContextText(m *model.Model) string {
 	if m.SelectedFunction == nil {
 		return ""
 	}
-	return fmt.Sprintf("Profile: %s\nRegion: %s\nFunction: %s",
-		m.AwsProfile,
-		m.AwsRegion,
+	return fmt.Sprintf("%s\nFunction: %s",
+		getAccountText(m, m.AwsProfile, m.AwsRegion),
 		m.SelectedFunction.Name)
 }
 
@@ -267,21 +628,45 @@ func getFunctionDetailsContextText(m *model.Model) string {
 func getTitleText(m *model.Model) string {
 	// Map of view types to their corresponding titles
 	titleMap := map[constants.View]string{
-		constants.ViewProviders:       constants.TitleProviders,
-		constants.ViewSelectService:   constants.TitleSelectService,
-		constants.ViewSelectCategory:  constants.TitleSelectCategory,
-		constants.ViewSelectOperation: constants.TitleSelectOperation,
-		constants.ViewApprovals:       constants.TitleApprovals,
-		constants.ViewConfirmation:    constants.TitleConfirmation,
-		constants.ViewSummary:         constants.TitleSummary,
-		constants.ViewExecutingAction: constants.TitleExecutingAction,
-		constants.ViewPipelineStatus:  constants.TitlePipelineStatus,
-		constants.ViewPipelineStages:  constants.TitlePipelineStages,
-		constants.ViewError:           constants.TitleError,
-		constants.ViewSuccess:         constants.TitleSuccess,
-		constants.ViewHelp:            constants.TitleHelp,
-		constants.ViewFunctionStatus:  constants.TitleFunctionStatus,
-		constants.ViewFunctionDetails: constants.TitleFunctionDetails,
+		constants.ViewProviders:        constants.TitleProviders,
+		constants.ViewAuthMethodSelect: constants.TitleAuthMethod,
+		constants.ViewAuthConfig:       constants.TitleAuthConfig,
+		constants.ViewSelectService:    constants.TitleSelectService,
+		constants.ViewSelectCategory:   constants.TitleSelectCategory,
+		constants.ViewSelectOperation:  constants.TitleSelectOperation,
+		constants.ViewApprovals:        constants.TitleApprovals,
+		constants.ViewConfirmation:     constants.TitleConfirmation,
+		constants.ViewSummary:          constants.TitleSummary,
+		constants.ViewExecutingAction:  constants.TitleExecutingAction,
+		constants.ViewPipelineStatus:   constants.TitlePipelineStatus,
+		constants.ViewPipelineStages:   constants.TitlePipelineStages,
+		constants.ViewError:            constants.TitleError,
+		constants.ViewSuccess:          constants.TitleSuccess,
+		constants.ViewHelp:             constants.TitleHelp,
+		constants.ViewFunctionStatus:   constants.TitleFunctionStatus,
+		constants.ViewFunctionDetails:  constants.TitleFunctionDetails,
+
+		constants.ViewSelectSourceAction: constants.TitleSelectSourceAction,
+		constants.ViewPipelineVariables:  constants.TitlePipelineVariables,
+		constants.ViewExecutionHistory:   constants.TitleExecutionHistory,
+		constants.ViewExecutionDetails:   constants.TitleExecutionDetails,
+		constants.ViewExecutionWatch:     constants.TitleExecutionWatch,
+		constants.ViewPipelineGraph:      constants.TitlePipelineGraph,
+		constants.ViewStageOperations:    constants.TitleStageOperations,
+		constants.ViewStopExecution:      constants.TitleStopExecution,
+		constants.ViewRetryStage:         constants.TitleRetryStage,
+		constants.ViewStageTransition:    constants.TitleStageTransition,
+		constants.ViewRollbackTargets:    constants.TitleRollbackTargets,
+		constants.ViewRollbackStage:      constants.TitleRollbackStage,
+		constants.ViewOverrideCondition:  constants.TitleOverrideCondition,
+		constants.ViewPipelineDefinition: constants.TitlePipelineDefinition,
+		constants.ViewDefinitionDiff:     constants.TitleDefinitionDiff,
+		constants.ViewStageActions:       constants.TitleStageActions,
+		constants.ViewActionDetails:      constants.TitleActionDetails,
+		constants.ViewInboxProfiles:      constants.TitleInboxProfiles,
+		constants.ViewInboxRegions:       constants.TitleInboxRegions,
+		constants.ViewBulkConfirmation:   constants.TitleBulkConfirmation,
+		constants.ViewBulkResults:        constants.TitleBulkResults,
 	}
 
 	// Special case for AWS config view
@@ -292,6 +677,21 @@ func getTitleText(m *model.Model) string {
 		return constants.TitleSelectRegion
 	}
 
+	// Special case for the MFA code prompt of a role profile
+	if m.CurrentView == constants.ViewAuthConfig && m.ProviderState.AuthState.Method == constants.AWSMFAAuth {
+		return constants.TitleMFACode
+	}
+
+	// Special case for the approvals of the approvals inbox
+	if m.CurrentView == constants.ViewApprovals && m.SelectedOperation != nil && m.SelectedOperation.Name == "Approvals Inbox" {
+		return constants.TitleApprovalsInbox
+	}
+
+	// Special case for the revision input of the pipeline start flow
+	if m.CurrentView == constants.ViewSummary && m.SelectedOperation != nil && m.SelectedOperation.Name == "Start Pipeline" {
+		return constants.TitleSourceRevision
+	}
+
 	// Return the title from the map, or empty string if not found
 	if title, ok := titleMap[m.CurrentView]; ok {
 		return title
@@ -307,16 +707,38 @@ func getHelpText(m *model.Model) string {
 		manualInputHelpText = "%s: confirm • %s: cancel • %s: quit"
 		summaryHelpText     = "↑/↓: navigate • %s: select • %s: back • %s: quit"
 		providersHelpText   = "↑/↓: navigate • %s: select • %s: quit"
+		approvalsHelpText   = "↑/↓: navigate • %s: mark • %s: mark all • %s: filter • %s%s: select • %s: back • %s: quit"
+		stagesHelpText      = "↑/↓: navigate • %s: graph • %s%s: select • %s: back • %s: quit"
+		graphHelpText       = "%s: table • %s%s: back • %s: quit"
+		refreshingHelpText  = "↑/↓: navigate • %s%s: select • %s: back • %s: quit"
+		watchHelpText       = "↑/↓: navigate • %s: restart watch • %s: back • %s: quit"
 	)
 
 	// Special cases based on view and state
 	switch {
 	case m.CurrentView == constants.ViewProviders:
 		return fmt.Sprintf(providersHelpText, constants.KeyEnter, constants.KeyQ)
-	case m.CurrentView == constants.ViewAWSConfig && m.ManualInput:
+	case (m.CurrentView == constants.ViewAWSConfig || m.CurrentView == constants.ViewAuthConfig) && m.ManualInput:
 		return fmt.Sprintf(manualInputHelpText, constants.KeyEnter, constants.KeyEsc, constants.KeyCtrlC)
 	case m.CurrentView == constants.ViewSummary && m.ManualInput:
 		return fmt.Sprintf(manualInputHelpText, constants.KeyEnter, constants.KeyEsc, constants.KeyCtrlC)
+	case (m.CurrentView == constants.ViewStopExecution || m.CurrentView == constants.ViewStageTransition ||
+		m.CurrentView == constants.ViewRollbackStage || m.CurrentView == constants.ViewOverrideCondition ||
+		m.CurrentView == constants.ViewPipelineDefinition) && m.ManualInput:
+		return fmt.Sprintf(manualInputHelpText, constants.KeyEnter, constants.KeyEsc, constants.KeyCtrlC)
+	case (m.CurrentView == constants.ViewApprovals || m.CurrentView == constants.ViewBulkConfirmation) && m.ManualInput:
+		return fmt.Sprintf(manualInputHelpText, constants.KeyEnter, constants.KeyEsc, constants.KeyCtrlC)
+	case m.CurrentView == constants.ViewApprovals:
+		return fmt.Sprintf(approvalsHelpText, constants.KeyMark, constants.KeyMarkAll, constants.KeyFilter,
+			getRefreshHelpText(m), constants.KeyEnter, constants.KeyEsc, constants.KeyQ)
+	case m.CurrentView == constants.ViewPipelineStages:
+		return fmt.Sprintf(stagesHelpText, constants.KeyGraph, getRefreshHelpText(m), constants.KeyEnter, constants.KeyEsc, constants.KeyQ)
+	case m.CurrentView == constants.ViewPipelineGraph:
+		return fmt.Sprintf(graphHelpText, constants.KeyGraph, getRefreshHelpText(m), constants.KeyEsc, constants.KeyQ)
+	case m.CurrentView == constants.ViewPipelineStatus || m.CurrentView == constants.ViewFunctionStatus:
+		return fmt.Sprintf(refreshingHelpText, getRefreshHelpText(m), constants.KeyEnter, constants.KeyEsc, constants.KeyQ)
+	case m.CurrentView == constants.ViewExecutionWatch:
+		return fmt.Sprintf(watchHelpText, constants.KeyRefresh, constants.KeyEsc, constants.KeyQ)
 	case m.CurrentView == constants.ViewSummary:
 		return fmt.Sprintf(summaryHelpText, constants.KeyEnter, constants.KeyEsc, constants.KeyQ)
 	default:
@@ -335,7 +757,7 @@ func renderTable(m *model.Model) string {
 	tableStyle := lipgloss.NewStyle().PaddingTop(1).PaddingRight(2).PaddingBottom(0).PaddingLeft(0)
 
 	// Use larger height for views that need more space
-	if m.CurrentView == constants.ViewPipelineStages {
+	if m.CurrentView == constants.ViewPipelineStages || m.CurrentView == constants.ViewExecutionWatch {
 		tableStyle = tableStyle.Height(constants.TableHeightLarge)
 	} else {
 		tableStyle = tableStyle.Height(constants.TableHeight)
@@ -344,3 +766,55 @@ func renderTable(m *model.Model) string {
 	// Render the table with the appropriate styles
 	return tableStyle.Render(m.Table.View())
 }
+
+// renderPipelineGraph renders the graph of the selected pipeline within the app width
+func renderPipelineGraph(m *model.Model) string {
+	width := constants.AppMaxWidth - 2*constants.PaddingY
+	if m.Width > 0 {
+		width = min(width, m.Width-2*constants.PaddingY)
+	}
+	return lipgloss.NewStyle().PaddingTop(1).Render(RenderPipelineGraph(m.SelectedPipeline, width))
+}
+
+// IsRefreshableView checks if a view shows a status table that is refreshed automatically
+func IsRefreshableView(v constants.View) bool {
+	switch v {
+	case constants.ViewPipelineStatus, constants.ViewPipelineStages, constants.ViewPipelineGraph,
+		constants.ViewApprovals, constants.ViewFunctionStatus:
+		return true
+	default:
+		return false
+	}
+}
+
+// getRefreshText returns the line showing when the status table was last refreshed
+// and whether the refresh is paused or failing
+func getRefreshText(m *model.Model) string {
+	if !IsRefreshableView(m.CurrentView) || m.LastRefreshed.IsZero() {
+		return ""
+	}
+
+	text := fmt.Sprintf("\nRefreshed: %s", m.LastRefreshed.Format(time.TimeOnly))
+	if m.RefreshPaused {
+		text += " (paused)"
+	} else if m.RefreshInterval > 0 {
+		text += fmt.Sprintf(" (every %s)", m.RefreshInterval)
+	}
+	if m.RefreshErr != nil {
+		text += fmt.Sprintf("\nRefresh failed: %v", m.RefreshErr)
+	}
+	return text
+}
+
+// getRefreshHelpText returns the help for refreshing the status tables, and for
+// pausing or resuming their auto-refresh
+func getRefreshHelpText(m *model.Model) string {
+	switch {
+	case m.RefreshInterval <= 0:
+		return fmt.Sprintf("%s: refresh • ", constants.KeyRefresh)
+	case m.RefreshPaused:
+		return fmt.Sprintf("%s: refresh • %s: resume refresh • ", constants.KeyRefresh, constants.KeyPauseRefresh)
+	default:
+		return fmt.Sprintf("%s: refresh • %s: pause refresh • ", constants.KeyRefresh, constants.KeyPauseRefresh)
+	}
+}
@@ -3,6 +3,7 @@ package view
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/HenryOwenz/cloudgate/internal/cloud"
 	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
@@ -240,6 +241,50 @@ func TestGetContextText(t *testing.T) {
 	}
 }
 
+func TestGetApprovalDetailsText(t *testing.T) {
+	now := time.Date(2024, time.March, 10, 12, 0, 0, 0, time.UTC)
+	approval := &cloud.ApprovalAction{
+		PipelineName:       "TestPipeline",
+		StageName:          "TestStage",
+		ActionName:         "TestAction",
+		ExecutionID:        "execution-1",
+		CustomData:         "Check the staging environment",
+		ExternalEntityLink: "https://example.com/staging",
+		RequestedAt:        now.Add(-26 * time.Hour),
+		ExpiresAt:          now.Add(-26 * time.Hour).Add(7 * 24 * time.Hour),
+		SourceRevisions: []cloud.ExecutionSourceRevision{
+			{ActionName: "Source", RevisionID: "abc1234", RevisionSummary: "Fix login\n\nLonger description"},
+		},
+	}
+
+	text := getApprovalDetailsText(approval, now)
+	for _, expected := range []string{
+		"Execution: execution-1",
+		"Revision: Source abc1234 - Fix login",
+		"Waiting: 1d 2h",
+		"Expires: Mar 16 10:00:00 UTC (in 5d 22h)",
+		"Details: Check the staging environment",
+		"Review: https://example.com/staging",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected approval details to contain '%s', got '%s'", expected, text)
+		}
+	}
+	if strings.Contains(text, "Longer description") {
+		t.Errorf("Expected only the first line of the commit message, got '%s'", text)
+	}
+
+	// An approval past its token lifetime is shown as expired
+	if text := getApprovalDetailsText(approval, now.Add(7*24*time.Hour)); !strings.Contains(text, "(expired)") {
+		t.Errorf("Expected the approval to be expired, got '%s'", text)
+	}
+
+	// Details CodePipeline does not report are left out
+	if text := getApprovalDetailsText(&cloud.ApprovalAction{ActionName: "TestAction"}, now); text != "" {
+		t.Errorf("Expected no approval details, got '%s'", text)
+	}
+}
+
 func TestGetTitleText(t *testing.T) {
 	// Test title text for different views
 	testCases := []struct {